@@ -105,7 +105,8 @@ func (r *repoImpl) LastLpHistory(height uint64) ([]schemas.LpHistory, error) {
 select lh.height,
        lh.pair_id,
        lh.liquidity0,
-       lh.liquidity1
+       lh.liquidity1,
+       lh.extra_liquidities
 from lp_history lh
 	 join (select pair_id, max(height) height
 	       from lp_history
@@ -109,7 +109,7 @@ func (t *lpHistoryTask) Execute(_ context.Context, _ time.Time, _ time.Time) err
 
 	latestLpMap := make(map[uint64][]string)
 	for _, h := range lastHistories {
-		latestLpMap[h.PairId] = []string{h.Liquidity0, h.Liquidity1}
+		latestLpMap[h.PairId] = append([]string{h.Liquidity0, h.Liquidity1}, h.ExtraLiquidities...)
 		if h.Height > t.lastProcessedHeight {
 			t.lastProcessedHeight = h.Height
 		}
@@ -143,7 +143,8 @@ func (t *lpHistoryTask) Execute(_ context.Context, _ time.Time, _ time.Time) err
 func (t lpHistoryTask) generateHistory(latestLpMap map[uint64][]string, txs []schemas.ParsedTxWithPrice) ([]schemas.LpHistory, error) {
 	history := []schemas.LpHistory{}
 
-	pairIdLpHistoryMap := make(map[uint64][2]cmath.LegacyDec)
+	// liquidities of asset0, asset1 and the extra assets of each pair
+	pairIdLpHistoryMap := make(map[uint64][]cmath.LegacyDec)
 
 	var currLpHistory schemas.LpHistory
 	currHeight := uint64(0)
@@ -151,10 +152,7 @@ func (t lpHistoryTask) generateHistory(latestLpMap map[uint64][]string, txs []sc
 	for _, tx := range txs {
 		if currHeight != tx.Height || currPairId != tx.PairId {
 			if currHeight > 0 {
-				lp := pairIdLpHistoryMap[currPairId]
-				currLpHistory.Liquidity0 = lp[repo.Liquidity0].String()
-				currLpHistory.Liquidity1 = lp[repo.Liquidity1].String()
-				history = append(history, currLpHistory)
+				history = append(history, withLiquidities(currLpHistory, pairIdLpHistoryMap[currPairId]))
 			}
 
 			currHeight = tx.Height
@@ -167,11 +165,7 @@ func (t lpHistoryTask) generateHistory(latestLpMap map[uint64][]string, txs []sc
 			}
 		}
 
-		volume0, err := cmath.LegacyNewDecFromStr(tx.Asset0Amount)
-		if err != nil {
-			return nil, errors.Wrap(err, "lpHistoryTask.generateHistory")
-		}
-		volume1, err := cmath.LegacyNewDecFromStr(tx.Asset1Amount)
+		volumes, err := decsFromStrings(append([]string{tx.Asset0Amount, tx.Asset1Amount}, tx.ExtraAssetAmounts...))
 		if err != nil {
 			return nil, errors.Wrap(err, "lpHistoryTask.generateHistory")
 		}
@@ -179,45 +173,62 @@ func (t lpHistoryTask) generateHistory(latestLpMap map[uint64][]string, txs []sc
 		lp, ok := pairIdLpHistoryMap[tx.PairId]
 		if !ok {
 			// initialize with the latest lp
+			lp = []cmath.LegacyDec{cmath.LegacyZeroDec(), cmath.LegacyZeroDec()}
 			if latestLp, ok := latestLpMap[tx.PairId]; ok {
-				lp[repo.Liquidity0], err = cmath.LegacyNewDecFromStr(latestLp[repo.Liquidity0])
+				lp, err = decsFromStrings(latestLp)
 				if err != nil {
 					return nil, errors.Wrap(err, "lpHistoryTask.generateHistory")
 				}
-				lp[repo.Liquidity1], err = cmath.LegacyNewDecFromStr(latestLp[repo.Liquidity1])
-				if err != nil {
-					return nil, errors.Wrap(err, "lpHistoryTask.generateHistory")
-				}
-			} else {
-				lp[repo.Liquidity0] = cmath.LegacyZeroDec()
-				lp[repo.Liquidity1] = cmath.LegacyZeroDec()
 			}
 		}
 
-		lp[repo.Liquidity0] = lp[repo.Liquidity0].Add(volume0)
-		lp[repo.Liquidity1] = lp[repo.Liquidity1].Add(volume1)
+		for idx, volume := range volumes {
+			if idx == len(lp) {
+				lp = append(lp, cmath.LegacyZeroDec())
+			}
+			lp[idx] = lp[idx].Add(volume)
+		}
 		pairIdLpHistoryMap[tx.PairId] = lp
 	}
 
 	// append the last lp history
-	lp := pairIdLpHistoryMap[currPairId]
-	currLpHistory.Liquidity0 = lp[repo.Liquidity0].String()
-	currLpHistory.Liquidity1 = lp[repo.Liquidity1].String()
-	history = append(history, currLpHistory)
+	history = append(history, withLiquidities(currLpHistory, pairIdLpHistoryMap[currPairId]))
 
 	for id, lp := range pairIdLpHistoryMap {
-		if _, ok := latestLpMap[id]; ok {
-			latestLpMap[id][repo.Liquidity0] = lp[repo.Liquidity0].String()
-			latestLpMap[id][repo.Liquidity1] = lp[repo.Liquidity1].String()
-		} else {
-			lpHistory := []string{lp[repo.Liquidity0].String(), lp[repo.Liquidity1].String()}
-			latestLpMap[id] = lpHistory
+		latestLp := make([]string, 0, len(lp))
+		for _, l := range lp {
+			latestLp = append(latestLp, l.String())
 		}
+		latestLpMap[id] = latestLp
 	}
 
 	return history, nil
 }
 
+// withLiquidities sets the liquidities of h from lp, leaving the extra
+// liquidities nil for two asset pools.
+func withLiquidities(h schemas.LpHistory, lp []cmath.LegacyDec) schemas.LpHistory {
+	h.Liquidity0 = lp[repo.Liquidity0].String()
+	h.Liquidity1 = lp[repo.Liquidity1].String()
+	h.ExtraLiquidities = nil
+	for _, l := range lp[repo.Liquidity1+1:] {
+		h.ExtraLiquidities = append(h.ExtraLiquidities, l.String())
+	}
+	return h
+}
+
+func decsFromStrings(values []string) ([]cmath.LegacyDec, error) {
+	decs := make([]cmath.LegacyDec, 0, len(values))
+	for _, v := range values {
+		dec, err := cmath.LegacyNewDecFromStr(v)
+		if err != nil {
+			return nil, err
+		}
+		decs = append(decs, dec)
+	}
+	return decs, nil
+}
+
 func newRouterTask(config configs.AggregatorConfig, logger logging.Logger) task {
 	repo := router.NewSrcRepo(config.ChainId, config.DestDb)
 
@@ -646,6 +657,7 @@ func (t *pairStatsUpdateTask) Execute(ctx context.Context, start time.Time, end
 			s.Liquidity0InPrice = lp.Liquidity0InPrice
 			s.Liquidity1 = lp.Liquidity1
 			s.Liquidity1InPrice = lp.Liquidity1InPrice
+			s.ExtraLiquidities = lp.ExtraLiquidities
 			stats[i] = s
 		}
 
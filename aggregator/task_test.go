@@ -13,6 +13,7 @@ import (
 
 	"github.com/dezswap/cosmwasm-etl/pkg/db/schemas"
 	"github.com/dezswap/cosmwasm-etl/pkg/util"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -143,6 +144,54 @@ func TestLpHistoryTaskExecute(t *testing.T) {
 	assert.Equal(expected[0], rp.updatedLpHistory[0])
 }
 
+func TestLpHistoryTaskExecuteExtraAssets(t *testing.T) {
+	assert := assert.New(t)
+
+	history := []schemas.LpHistory{
+		{
+			Height:           1,
+			PairId:           1,
+			ChainId:          "cube_47-5",
+			Liquidity0:       "1000000",
+			Liquidity1:       "1000000",
+			ExtraLiquidities: pq.StringArray{"1000000"},
+			Timestamp:        1692939766,
+		},
+	}
+
+	txs := []schemas.ParsedTxWithPrice{
+		{
+			PairId:            1,
+			ChainId:           "cube_47-5",
+			Asset0Amount:      "1000000",
+			Asset1Amount:      "0",
+			ExtraAssetAmounts: pq.StringArray{"-500000"},
+			Height:            2,
+			Timestamp:         1692939767,
+		},
+	}
+
+	rp := repoMock{}
+	rp.On("LastLpHistory", mock.Anything).Return(history, nil)
+	rp.On("GetParsedTxsWithLimit", mock.Anything, mock.Anything).Return(txs, nil)
+	rp.On("UpdateLpHistory", mock.Anything).Return(nil)
+
+	task := lpHistoryTask{
+		taskImpl: taskImpl{
+			chainId: "",
+			destDb:  &rp,
+			logger:  logging.Discard,
+		},
+		srcDb: &rp,
+	}
+
+	err := task.Execute(context.Background(), time.Time{}, time.Time{})
+	assert.NoError(err)
+	assert.Equal("2000000.000000000000000000", rp.updatedLpHistory[0].Liquidity0)
+	assert.Equal("1000000.000000000000000000", rp.updatedLpHistory[0].Liquidity1)
+	assert.Equal(pq.StringArray{"500000.000000000000000000"}, rp.updatedLpHistory[0].ExtraLiquidities)
+}
+
 func TestPairStatsRecentUpdateTaskExecute(t *testing.T) {
 	assert := assert.New(t)
 
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/dezswap/cosmwasm-etl/collector/archive"
+	"github.com/dezswap/cosmwasm-etl/collector/datastore"
+	collectorrepo "github.com/dezswap/cosmwasm-etl/collector/repo"
+	"github.com/dezswap/cosmwasm-etl/configs"
+	"github.com/dezswap/cosmwasm-etl/pkg/logging"
+	"github.com/dezswap/cosmwasm-etl/pkg/s3client"
+)
+
+const (
+	sourceS3 = "s3"
+	sourceDb = "db"
+
+	defaultSegmentSize = 10000
+)
+
+// readRecordFunc returns the per-height record to archive, or ok=false when
+// the height has no data.
+type readRecordFunc func(height uint64) (record []byte, ok bool, err error)
+
+// Converts per-height collector output into compressed archive segments.
+func main() {
+	source := flag.String("source", sourceS3, "per-height data to convert: s3 (block and pair files) or db (collector_blocks rows)")
+	from := flag.Uint64("from", 0, "first height to convert (default: resume after the archived height)")
+	to := flag.Uint64("to", 0, "last height to convert (default: latest collected height)")
+	segmentSize := flag.Uint64("segment-size", defaultSegmentSize, "number of consecutive heights per segment")
+	codec := flag.String("codec", string(archive.CodecGzip), "segment compression codec")
+	flag.Parse()
+
+	c := configs.New()
+	logger := logging.New("collector-archive", c.Log)
+	chainId := c.Collector.ChainId
+	if chainId == "" {
+		fail("collector.chainid is required")
+	}
+
+	s3Client, err := s3client.NewClient(c.S3)
+	if err != nil {
+		fail(err.Error())
+	}
+
+	type job struct {
+		kind   string
+		latest func() (uint64, error)
+		read   readRecordFunc
+	}
+	jobs := []job{}
+	switch *source {
+	case sourceS3:
+		latest := func() (uint64, error) {
+			height, err := s3Client.GetLatestProcessedBlockNumber(datastore.GetBlockFolderPath(chainId)...)
+			if err != nil {
+				return 0, err
+			}
+			return uint64(height), nil
+		}
+		jobs = append(jobs,
+			job{datastore.BLOCK_SUFFIX, latest, s3BlockReader(s3Client, chainId)},
+			job{datastore.PAIR_SUFFIX, latest, s3PairReader(s3Client, chainId)},
+		)
+	case sourceDb:
+		repo := collectorrepo.New(c.Rdb)
+		latest := func() (uint64, error) { return repo.GetSyncedHeight(chainId) }
+		jobs = append(jobs, job{archive.COLLECTOR_BLOCK_SUFFIX, latest, dbBlockReader(repo, chainId)})
+	default:
+		fail(fmt.Sprintf("unknown source: %s", *source))
+	}
+
+	for _, j := range jobs {
+		store := archive.NewStore(s3Client, archive.GetArchiveFolderPath(chainId, j.kind)...)
+		writer, err := store.NewWriter(*segmentSize, archive.Codec(*codec))
+		if err != nil {
+			fail(err.Error())
+		}
+
+		start := *from
+		if start == 0 {
+			manifest, err := store.Manifest()
+			if err != nil && !errors.Is(err, archive.ErrNotArchived) {
+				fail(err.Error())
+			}
+			start = manifest.LatestHeight + 1
+		}
+		end := *to
+		if end == 0 {
+			if end, err = j.latest(); err != nil {
+				fail(err.Error())
+			}
+		}
+
+		count, err := convert(writer, start, end, j.read)
+		if err != nil {
+			fail(fmt.Sprintf("convert %s: %s", j.kind, err))
+		}
+		logger.Infof("archived %d %s records of heights %d..%d", count, j.kind, start, end)
+	}
+}
+
+// convert archives the records of heights [from, to] and flushes the last segment.
+func convert(writer *archive.Writer, from, to uint64, read readRecordFunc) (uint64, error) {
+	count := uint64(0)
+	for height := from; height <= to; height++ {
+		record, ok, err := read(height)
+		if err != nil {
+			return count, fmt.Errorf("height %d: %w", height, err)
+		}
+		if !ok {
+			continue
+		}
+		if err := writer.Put(height, record); err != nil {
+			return count, err
+		}
+		count++
+	}
+	return count, writer.Flush()
+}
+
+func s3BlockReader(client s3client.S3ClientInterface, chainId string) readRecordFunc {
+	return func(height uint64) ([]byte, bool, error) {
+		return readS3File(client, client.GetBlockFilePath(int64(height), datastore.GetBlockFolderPath(chainId)...)...)
+	}
+}
+
+func s3PairReader(client s3client.S3ClientInterface, chainId string) readRecordFunc {
+	return func(height uint64) ([]byte, bool, error) {
+		return readS3File(client, append(datastore.GetPairFolderPath(chainId), fmt.Sprintf("%d.json", height))...)
+	}
+}
+
+func readS3File(client s3client.S3ClientInterface, path ...string) ([]byte, bool, error) {
+	data, err := client.GetFileFromS3(path...)
+	if err != nil {
+		if archive.IsNotFound(err) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	return data, true, nil
+}
+
+func dbBlockReader(repo collectorrepo.Repository, chainId string) readRecordFunc {
+	return func(height uint64) ([]byte, bool, error) {
+		txs, blockTime, err := repo.GetBlockTxs(chainId, height)
+		if errors.Is(err, collectorrepo.ErrNotFound) {
+			return nil, false, nil
+		} else if err != nil {
+			return nil, false, err
+		}
+		header, err := repo.GetBlockHeader(chainId, height)
+		if err != nil {
+			return nil, false, err
+		}
+
+		record, err := json.Marshal(archive.CollectorBlock{
+			BlockTime:  blockTime,
+			BlockHash:  header.Hash,
+			ParentHash: header.ParentHash,
+			Txs:        txs,
+		})
+		if err != nil {
+			return nil, false, err
+		}
+		return record, true, nil
+	}
+}
+
+func fail(message string) {
+	fmt.Fprintln(os.Stderr, message)
+	os.Exit(1)
+}
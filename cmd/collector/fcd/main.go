@@ -1,6 +1,7 @@
 package main
 
 import (
+	"fmt"
 	"math"
 	"net/http"
 	"os"
@@ -18,6 +19,10 @@ import (
 	"gorm.io/gorm"
 )
 
+const columbus4ChainId = "columbus-4"
+
+// columbus4_pairs are the TerraSwap pairs collected on columbus-4 when no
+// targets are configured.
 var columbus4_pairs = []string{
 	"terra1a5cc08jt5knh0yx64pg6dtym4c4l8t63rhlag3", "terra1u2g4fc0k4tq6z6lrdwhm4gry3q55dg8k9anjtw", "terra1g7an9lfz22gkv74238dhc2j6ymfp4jy0k55yxk", "terra1xlgl3xvkha2y6mssy9s4qe70sq295825sdmt2q", "terra1uenpalqlmfaf4efgtqsvzpa3gh898d9h2a232g",
 	"terra170lzdyflaamashcqkst23k9ew773dtg67tfu5m", "terra1yngadscckdtd68nzw5r5va36jccjmmasm7klpp", "terra1dq27eeasl3rtlc8j3ls5yz8wurnksahj240tsr", "terra13yc7dcphaxpgd538msys7r75d3lwa5mu9u6d88", "terra1prfcyujt9nsn5kfj5n925sfd737r2n8tk5lmpv",
@@ -44,10 +49,22 @@ var columbus4_pairs = []string{
 	"terra1zey9knmvs2frfrjnf4cfv4prc4ts3mrsefstrj", "terra1ze5f2lm5clq2cdd9y2ve3lglfrq6ap8cqncld8", "terra1wrwf3um5vm30vpwnlpvjzgwpf5fjknt68nah05",
 }
 
-// TerraSwap Columbus-4 network-specific transaction collector from the FCD server.
+// Collects the tx history of addresses from an FCD server into fcd_tx_log.
+// Targets come from collector.fcd.target_addresses or the parser pair table,
+// and the Columbus-4 TerraSwap pairs are collected when neither is set on
+// columbus-4. Rerunning resumes each address from its collected offsets and
+// registers addresses collected before progress was tracked in
+// fcd_address_progress, which scopes the parser FCD source to the chain.
 func main() {
 	cfg := configs.New()
+	logger := logging.New("fcd_collector", cfg.Log)
+	defer catch(logger)
+
 	fcdCfg := cfg.Collector.FcdConfig
+	if err := fcdCfg.Validate(); err != nil {
+		panic(err)
+	}
+
 	dbCon := cfg.Rdb
 	db, err := db.OpenGormPostgres(
 		dbCon,
@@ -67,31 +84,34 @@ func main() {
 			DisableKeepAlives: false,            // Use HTTP Keep-Alive
 		},
 	})
-	repo := fcd_collector.NewFcdRepo(fcdIns)
-
-	// default setting is for columbus-4 network
-	targets := columbus4_pairs
-	cfg.Collector.UntilHeight = terraswap.COLUMBUS_4_END_HEIGHT
-
-	app := fcd_collector.New(repo, store)
-	logger := logging.New("col4_collector", cfg.Log)
-	defer catch(logger)
+	repo := fcd_collector.NewFcdRepo(fcd.NewRateLimited(fcdIns, fcdCfg.RequestsPerSec))
 
-	errTolerance := 3
-	wait := 3 * time.Second
-	for _, addr := range targets {
-		logger.Infof("start collecting addr(%s)", addr)
-		for errCount := uint(0); errCount <= uint(errTolerance); {
-			if err := app.Collect(addr, uint32(cfg.Collector.UntilHeight)); err != nil {
-				errCount++
-				logger.Errorf("errCount: %d, err: %s", errCount, err)
-			} else {
-				break
-			}
-			wait := wait * time.Duration(math.Pow(2, float64(errCount)))
-			time.Sleep(wait)
+	chainId := cfg.Collector.ChainId
+	targets, err := fcd_collector.TargetAddresses(store, chainId, fcdCfg)
+	if err != nil {
+		panic(err)
+	}
+	untilHeight := cfg.Collector.UntilHeight
+	if chainId == columbus4ChainId {
+		if len(targets) == 0 && fcdCfg.TargetSource == configs.FcdTargetSourceConfig {
+			targets = columbus4_pairs
 		}
-		logger.Infof("finished collecting addr(%s)", addr)
+		if untilHeight == 0 {
+			untilHeight = terraswap.COLUMBUS_4_END_HEIGHT
+		}
+	}
+	if untilHeight == 0 || untilHeight > math.MaxUint32 {
+		// FCD lists txs from the newest, so no bound starts at the latest tx.
+		untilHeight = math.MaxUint32
+	}
+	if len(targets) == 0 {
+		panic(fmt.Errorf("no target addresses: set collector.fcd.target_addresses or collector.fcd.target_source"))
+	}
+
+	app := fcd_collector.New(chainId, repo, store, fcdCfg.PageSize)
+	logger.Infof("collecting %d addresses until height %d with %d workers", len(targets), untilHeight, fcdCfg.Concurrency)
+	if err := fcd_collector.CollectAddresses(app, targets, uint32(untilHeight), fcdCfg, logger); err != nil {
+		panic(err)
 	}
 }
 
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/dezswap/cosmwasm-etl/collector"
+	"github.com/dezswap/cosmwasm-etl/collector/repo"
+	"github.com/dezswap/cosmwasm-etl/configs"
+	"github.com/dezswap/cosmwasm-etl/pkg/logging"
+)
+
+const gapsCommand = "gaps"
+
+// runGaps reports heights missing from collector_blocks and
+// collector_pool_snapshots, and blocks stored with another tx filter version
+// than collector.tx_filter selects, and re-collects them with --backfill.
+//
+//	collector gaps [--from N] [--to N] [--backfill]
+func runGaps(c configs.Config, logger logging.Logger, args []string) {
+	flags := flag.NewFlagSet(gapsCommand, flag.ExitOnError)
+	from := flags.Uint64("from", 0, "first height to scan (default: collector.start_height, or 1)")
+	to := flags.Uint64("to", 0, "last height to scan (default: collector synced height)")
+	backfill := flags.Bool("backfill", false, "re-collect the missing heights from the node")
+	_ = flags.Parse(args)
+
+	cc := c.Collector
+	collectorRepo := repo.New(c.Rdb)
+
+	start := *from
+	if start == 0 {
+		start = max(cc.StartHeight, 1)
+	}
+	end := *to
+	if end == 0 {
+		synced, err := collectorRepo.GetSyncedHeight(cc.ChainId)
+		if err != nil && !errors.Is(err, repo.ErrNotFound) {
+			panic(err)
+		}
+		end = synced
+	}
+	if start > end {
+		panic(fmt.Errorf("invalid gap scan range: from=%d to=%d", start, end))
+	}
+
+	filterVersion := collector.UnfilteredVersion
+	if cc.TxFilter {
+		filterVersion = collector.AddressFilterVersion
+	}
+	gaps, err := collector.FindGaps(collectorRepo, cc.ChainId, start, end, cc.PoolSnapshotInterval, filterVersion)
+	if err != nil {
+		panic(err)
+	}
+	if err := json.NewEncoder(os.Stdout).Encode(gaps); err != nil {
+		panic(err)
+	}
+	logger.Infof("found %d missing blocks, %d missing pool snapshots and %d filter version mismatches in heights %d..%d",
+		len(gaps.MissingBlocks), len(gaps.MissingPoolSnapshots), len(gaps.FilterMismatches), start, end)
+
+	if !*backfill || len(gaps.Heights()) == 0 {
+		return
+	}
+	source, err := newNodeSource(cc, logger)
+	if err != nil {
+		panic(err)
+	}
+	txFilter, err := newTxFilter(cc, source)
+	if err != nil {
+		panic(err)
+	}
+	if err := collector.BackfillGaps(collectorRepo, source, txFilter, cc, gaps, logger); err != nil {
+		panic(err)
+	}
+}
@@ -2,16 +2,23 @@ package main
 
 import (
 	"fmt"
+	"net"
 	"os"
 	"runtime/debug"
 
 	"github.com/davecgh/go-spew/spew"
 	"github.com/dezswap/cosmwasm-etl/collector"
+	"github.com/dezswap/cosmwasm-etl/collector/archive"
 	"github.com/dezswap/cosmwasm-etl/collector/repo"
 	"github.com/dezswap/cosmwasm-etl/configs"
+	"github.com/dezswap/cosmwasm-etl/parser/dex"
+	parserrepo "github.com/dezswap/cosmwasm-etl/parser/dex/repo"
+	"github.com/dezswap/cosmwasm-etl/parser/dex/srcstore"
 	"github.com/dezswap/cosmwasm-etl/parser/dex/srcstore/terraswap"
 	"github.com/dezswap/cosmwasm-etl/pkg/grpc"
+	"github.com/dezswap/cosmwasm-etl/pkg/grpc/collectorservice"
 	"github.com/dezswap/cosmwasm-etl/pkg/logging"
+	"github.com/dezswap/cosmwasm-etl/pkg/s3client"
 )
 
 const app = "collector"
@@ -38,14 +45,103 @@ func main() {
 		panic(err)
 	}
 
-	source, err := terraswap.NewFromConfig(c.Collector.NodeConfig, c.Collector.PairFactoryContractAddress)
+	if len(os.Args) > 1 && os.Args[1] == gapsCommand {
+		runGaps(c, logger, os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == snapshotCommand {
+		runSnapshot(c, logger, os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == pruneCommand {
+		runPrune(c, logger, os.Args[2:])
+		return
+	}
+
+	source, txFilter, err := newSource(c.Collector, logger)
 	if err != nil {
 		panic(err)
 	}
 
-	if err := collector.DoCollect(repo.New(c.Rdb), source, c.Collector, logger); err != nil {
+	collectorRepo := repo.New(c.Rdb)
+	if c.Collector.GrpcServerPort > 0 {
+		lis, err := net.Listen("tcp", fmt.Sprintf(":%d", c.Collector.GrpcServerPort))
+		if err != nil {
+			panic(err)
+		}
+		server := collectorservice.NewGrpcServer(newServedRepo(c, collectorRepo))
+		go func() {
+			logger.Infof("serving collector gRPC API on %s", lis.Addr())
+			if err := server.Serve(lis); err != nil {
+				logger.Errorf("collector gRPC API stopped: %s", err)
+			}
+		}()
+		defer server.GracefulStop()
+	}
+
+	var parserRewinder collector.ParserRewinder
+	if c.Collector.RollbackOnFork {
+		parserRewinder = newParserRewinder(parserrepo.NewRewinder(c.Collector.ChainId, c.Collector.ParserRdb(c.Rdb)), c.Parser.DexConfig.ValidationInterval)
+	}
+
+	if err := collector.DoCollect(collectorRepo, source, txFilter, parserRewinder, c.Collector, logger); err != nil {
+		panic(err)
+	}
+}
+
+// newServedRepo returns the repository the gRPC API reads from. With
+// s3.archive set, blocks pruned from collector_blocks after collector-archive
+// are served from the collector_block archive.
+func newServedRepo(c configs.Config, collectorRepo repo.Repository) repo.Repository {
+	if !c.S3.Archive {
+		return collectorRepo
+	}
+	s3Client, err := s3client.NewClient(c.S3)
+	if err != nil {
 		panic(err)
 	}
+	return archive.NewRepository(s3Client, collectorRepo)
+}
+
+// newSource builds the node source and, when collector.tx_filter is set, the
+// tx filter backed by the factory pairs of that source.
+func newSource(c configs.CollectorConfig, logger logging.Logger) (dex.SourceDataStore, collector.TxFilter, error) {
+	source, err := newNodeSource(c, logger)
+	if err != nil {
+		return nil, nil, err
+	}
+	txFilter, err := newTxFilter(c, source)
+	if err != nil {
+		return nil, nil, err
+	}
+	if wsHost := c.NodeConfig.RestClientConfig.WebsocketHost; wsHost != "" {
+		source = srcstore.NewSubscription(wsHost, source, logger)
+	}
+	return source, txFilter, nil
+}
+
+// newNodeSource builds the node source, querying snapshot pools in batches
+// when collector.pool_snapshot.concurrency is set.
+func newNodeSource(c configs.CollectorConfig, logger logging.Logger) (dex.SourceDataStore, error) {
+	source, err := terraswap.NewFromConfig(c.NodeConfig, c.PairFactoryContractAddress)
+	if err != nil {
+		return nil, err
+	}
+	if c.PoolSnapshot.Concurrency == 0 {
+		return source, nil
+	}
+	return srcstore.NewBatchedPools(source, c.PoolSnapshot, logger)
+}
+
+func newTxFilter(c configs.CollectorConfig, source dex.SourceDataStore) (collector.TxFilter, error) {
+	if !c.TxFilter {
+		return nil, nil
+	}
+	pairs, ok := source.(dex.PairSource)
+	if !ok {
+		return nil, fmt.Errorf("collector source cannot list pairs for tx_filter")
+	}
+	return collector.NewAddressFilter(c.PairFactoryContractAddress, pairs), nil
 }
 
 func catch(logger logging.Logger) {
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/dezswap/cosmwasm-etl/collector"
+	"github.com/dezswap/cosmwasm-etl/collector/archive"
+	"github.com/dezswap/cosmwasm-etl/collector/repo"
+	"github.com/dezswap/cosmwasm-etl/configs"
+	"github.com/dezswap/cosmwasm-etl/pkg/logging"
+	"github.com/dezswap/cosmwasm-etl/pkg/s3client"
+)
+
+const pruneCommand = "prune"
+
+// runPrune deletes collector_blocks and collector_pool_snapshots rows below
+// collector.retention.prune_below_height, never above the lowest parser
+// synced height nor, for pool snapshots, the parser validation cursor. The
+// plan is printed as JSON, and --dry-run only counts the rows.
+//
+//	collector prune [--below N] [--archived-only] [--dry-run]
+func runPrune(c configs.Config, logger logging.Logger, args []string) {
+	cc := c.Collector
+	flags := flag.NewFlagSet(pruneCommand, flag.ExitOnError)
+	below := flags.Uint64("below", cc.Retention.PruneBelowHeight, "prune rows below this height")
+	archivedOnly := flags.Bool("archived-only", cc.Retention.ArchivedOnly, "keep blocks not yet in the collector_block archive")
+	dryRun := flags.Bool("dry-run", false, "report the rows that would be removed without deleting them")
+	_ = flags.Parse(args)
+
+	if *below == 0 {
+		panic(fmt.Errorf("missing prune height: set --below or collector.retention.prune_below_height"))
+	}
+
+	requested := *below
+	if *archivedOnly {
+		archived, err := archivedHeight(c.S3, cc.ChainId)
+		if err != nil {
+			panic(err)
+		}
+		requested = min(requested, archived+1)
+	}
+
+	plan, err := collector.Prune(repo.NewPruner(c.Rdb, cc.ParserRdb(c.Rdb)), cc.ChainId, requested, *dryRun, logger)
+	if err != nil {
+		panic(err)
+	}
+	if err := json.NewEncoder(os.Stdout).Encode(plan); err != nil {
+		panic(err)
+	}
+}
+
+// archivedHeight returns the latest height in the collector_block archive, or
+// 0 when nothing is archived.
+func archivedHeight(c configs.S3Config, chainId string) (uint64, error) {
+	client, err := s3client.NewClient(c)
+	if err != nil {
+		return 0, err
+	}
+	manifest, err := archive.NewStore(client, archive.GetArchiveFolderPath(chainId, archive.COLLECTOR_BLOCK_SUFFIX)...).Manifest()
+	if errors.Is(err, archive.ErrNotArchived) {
+		return 0, nil
+	}
+	return manifest.LatestHeight, err
+}
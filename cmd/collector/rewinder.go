@@ -0,0 +1,26 @@
+package main
+
+import (
+	"math"
+
+	"github.com/dezswap/cosmwasm-etl/collector"
+	parserrepo "github.com/dezswap/cosmwasm-etl/parser/dex/repo"
+)
+
+type parserRewinder struct {
+	rewinder           parserrepo.Rewinder
+	validationInterval uint
+}
+
+var _ collector.ParserRewinder = (*parserRewinder)(nil)
+
+// newParserRewinder rewinds the parser rows with the parser repo rewinder,
+// moving the validation cursor back on validationInterval boundaries.
+func newParserRewinder(rewinder parserrepo.Rewinder, validationInterval uint) collector.ParserRewinder {
+	return &parserRewinder{rewinder: rewinder, validationInterval: validationInterval}
+}
+
+func (r *parserRewinder) RewindAbove(height uint64) error {
+	_, err := r.rewinder.Rewind(height+1, math.MaxInt64, r.validationInterval)
+	return err
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"math"
+	"testing"
+
+	parserrepo "github.com/dezswap/cosmwasm-etl/parser/dex/repo"
+	"github.com/stretchr/testify/require"
+)
+
+type parserRepoRewinderMock struct {
+	parserrepo.Rewinder
+	calls []uint64
+}
+
+func (m *parserRepoRewinderMock) Rewind(from, to uint64, validationInterval uint) (parserrepo.RewindCounts, error) {
+	m.calls = []uint64{from, to, uint64(validationInterval)}
+	return parserrepo.RewindCounts{}, nil
+}
+
+func TestParserRewinderRewindsEverythingAboveFork(t *testing.T) {
+	rewinder := &parserRepoRewinderMock{}
+
+	err := newParserRewinder(rewinder, 100).RewindAbove(7)
+
+	require.NoError(t, err)
+	require.Equal(t, []uint64{8, math.MaxInt64, 100}, rewinder.calls)
+}
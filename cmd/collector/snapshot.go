@@ -0,0 +1,58 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dezswap/cosmwasm-etl/collector"
+	"github.com/dezswap/cosmwasm-etl/collector/repo"
+	"github.com/dezswap/cosmwasm-etl/configs"
+	"github.com/dezswap/cosmwasm-etl/pkg/logging"
+)
+
+const snapshotCommand = "snapshot"
+
+// runSnapshot stores pool snapshots of the given heights on demand, including
+// heights that are not multiples of collector.pool_snapshot_interval.
+//
+//	collector snapshot --heights N[,N...]
+func runSnapshot(c configs.Config, logger logging.Logger, args []string) {
+	flags := flag.NewFlagSet(snapshotCommand, flag.ExitOnError)
+	heightList := flags.String("heights", "", "comma separated heights to snapshot")
+	_ = flags.Parse(args)
+
+	heights, err := parseHeights(*heightList)
+	if err != nil {
+		panic(err)
+	}
+	if len(heights) == 0 {
+		panic(fmt.Errorf("missing heights: set --heights"))
+	}
+
+	cc := c.Collector
+	source, err := newNodeSource(cc, logger)
+	if err != nil {
+		panic(err)
+	}
+	if err := collector.SnapshotHeights(repo.New(c.Rdb), source, cc.ChainId, heights, logger); err != nil {
+		panic(err)
+	}
+}
+
+func parseHeights(list string) ([]uint64, error) {
+	heights := []uint64{}
+	for _, field := range strings.Split(list, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		height, err := strconv.ParseUint(field, 10, 64)
+		if err != nil || height == 0 {
+			return nil, fmt.Errorf("invalid height %q", field)
+		}
+		heights = append(heights, height)
+	}
+	return heights, nil
+}
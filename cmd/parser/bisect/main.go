@@ -0,0 +1,122 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/dezswap/cosmwasm-etl/configs"
+	p_dex "github.com/dezswap/cosmwasm-etl/parser/dex"
+	"github.com/dezswap/cosmwasm-etl/parser/dex/dexwiring"
+	"github.com/dezswap/cosmwasm-etl/parser/dex/repo"
+	"github.com/dezswap/cosmwasm-etl/pkg/db/schemas"
+	"github.com/dezswap/cosmwasm-etl/pkg/logging"
+)
+
+type bisectReport struct {
+	ChainID    string               `json:"chain_id"`
+	Good       uint64               `json:"good_height"`
+	Bad        uint64               `json:"bad_height"`
+	Divergence p_dex.PoolDivergence `json:"divergence"`
+	ParsedTxs  []schemas.ParsedTx   `json:"parsed_txs"`
+}
+
+// bisector is the parser state bisect reads.
+type bisector struct {
+	source             p_dex.SourceDataStore
+	parsed             p_dex.ParsedPoolsReader
+	results            repo.PoolValidationResultRepo
+	comparer           repo.ParsedTxComparer
+	chainId            string
+	validationInterval uint64
+}
+
+func main() {
+	contract := flag.String("contract", "", "pool contract to bisect")
+	good := flag.Uint64("good", 0, "height the pool matched at (default: bad height - validation interval)")
+	bad := flag.Uint64("bad", 0, "height the pool mismatched at (default: first failed pool validation)")
+	flag.Parse()
+
+	if *contract == "" {
+		fail("required flags: --contract")
+	}
+
+	c := configs.New()
+	dc := c.Parser.DexConfig
+	if err := dc.Validate(); err != nil {
+		fail(fmt.Sprintf("invalid parser dex config: %s", err))
+	}
+
+	readStore, err := dexwiring.NewTargetReadStore(c, dc)
+	if err != nil {
+		fail(err.Error())
+	}
+	source, err := dexwiring.NewSourceDataStore(dc, c.Rdb, readStore, logging.Discard)
+	if err != nil {
+		fail(err.Error())
+	}
+	b := bisector{
+		source:             source,
+		parsed:             repo.New(dc.ChainId, c.Rdb),
+		results:            repo.NewPoolValidationResultRepo(dc.ChainId, c.Rdb),
+		comparer:           repo.NewParsedTxComparer(dc.ChainId, c.Rdb),
+		chainId:            dc.ChainId,
+		validationInterval: uint64(dc.ValidationInterval),
+	}
+
+	report, err := b.bisect(*contract, *good, *bad)
+	if err != nil {
+		fail(err.Error())
+	}
+	if err := json.NewEncoder(os.Stdout).Encode(report); err != nil {
+		fail(err.Error())
+	}
+}
+
+// bisect narrows the pool divergence of contract down to a single height and
+// lists the raw and the stored parsed txs of that height. A zero bad height
+// is the first failed pool validation, a zero good height is one validation
+// interval below bad.
+func (b *bisector) bisect(contract string, good, bad uint64) (bisectReport, error) {
+	if bad == 0 {
+		failed, err := b.results.FirstFailedPoolValidation()
+		if err != nil {
+			return bisectReport{}, fmt.Errorf("load first failed pool validation: %w", err)
+		}
+		if failed == nil {
+			return bisectReport{}, errors.New("no failed pool validation, pass --bad")
+		}
+		bad = failed.Height
+	}
+	if good == 0 && bad > b.validationInterval {
+		good = bad - b.validationInterval
+	}
+
+	divergence, err := p_dex.BisectPoolDivergence(b.source, b.parsed, contract, good, bad)
+	if err != nil {
+		return bisectReport{}, err
+	}
+	hashes := make([]string, 0, len(divergence.RawTxs))
+	for _, tx := range divergence.RawTxs {
+		hashes = append(hashes, tx.Hash)
+	}
+	parsedTxs, err := b.comparer.StoredParsedTxs(divergence.Height, hashes)
+	if err != nil {
+		return bisectReport{}, fmt.Errorf("load parsed txs at height %d: %w", divergence.Height, err)
+	}
+
+	return bisectReport{
+		ChainID:    b.chainId,
+		Good:       good,
+		Bad:        bad,
+		Divergence: divergence,
+		ParsedTxs:  parsedTxs,
+	}, nil
+}
+
+func fail(msg string) {
+	_, _ = fmt.Fprintln(os.Stderr, msg)
+	os.Exit(1)
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/dezswap/cosmwasm-etl/parser"
+	p_dex "github.com/dezswap/cosmwasm-etl/parser/dex"
+	"github.com/dezswap/cosmwasm-etl/parser/dex/repo"
+	"github.com/dezswap/cosmwasm-etl/pkg/db/schemas"
+	"github.com/dezswap/cosmwasm-etl/pkg/eventlog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// bisectSource has a pool1 of height*10 asset0, and the parsed pool1 gets
+// one asset0 too many from divergedAt on.
+type bisectSource struct {
+	divergedAt uint64
+}
+
+func (*bisectSource) GetSourceSyncedHeight() (uint64, error) {
+	return 0, nil
+}
+
+func (*bisectSource) GetSourceTxs(height uint64) (parser.RawTxs, error) {
+	hash := "tx-" + strconv.FormatUint(height, 10)
+	return parser.RawTxs{{
+		Hash: hash,
+		LogResults: eventlog.LogResults{{
+			Type:       eventlog.WasmType,
+			Attributes: eventlog.Attributes{{Key: "_contract_address", Value: "pool1"}},
+		}},
+	}}, nil
+}
+
+func (*bisectSource) GetPoolInfos(height uint64) ([]p_dex.PoolInfo, error) {
+	return []p_dex.PoolInfo{bisectPool(height * 10)}, nil
+}
+
+func (s *bisectSource) ParsedPoolsInfo(_, to uint64) ([]p_dex.PoolInfo, error) {
+	amount := to * 10
+	if to >= s.divergedAt {
+		amount++
+	}
+	return []p_dex.PoolInfo{bisectPool(amount)}, nil
+}
+
+func bisectPool(asset0 uint64) p_dex.PoolInfo {
+	return p_dex.PoolInfo{
+		ContractAddr: "pool1",
+		TotalShare:   "1000",
+		Assets:       []p_dex.Asset{{Addr: "token0", Amount: strconv.FormatUint(asset0, 10)}, {Addr: "token1", Amount: "500"}},
+	}
+}
+
+type bisectResults struct {
+	repo.PoolValidationResultRepo
+	failed *p_dex.PoolValidationResult
+}
+
+func (r *bisectResults) FirstFailedPoolValidation() (*p_dex.PoolValidationResult, error) {
+	return r.failed, nil
+}
+
+type bisectComparer struct {
+	repo.ParsedTxComparer
+	height uint64
+	hashes []string
+}
+
+func (c *bisectComparer) StoredParsedTxs(height uint64, hashes []string) ([]schemas.ParsedTx, error) {
+	c.height, c.hashes = height, hashes
+	return []schemas.ParsedTx{{Height: height, Hash: hashes[0], Contract: "pool1"}}, nil
+}
+
+func newBisector(divergedAt uint64, failed *p_dex.PoolValidationResult) (bisector, *bisectComparer) {
+	source := &bisectSource{divergedAt: divergedAt}
+	comparer := &bisectComparer{}
+	return bisector{
+		source:             source,
+		parsed:             source,
+		results:            &bisectResults{failed: failed},
+		comparer:           comparer,
+		chainId:            "chain-1",
+		validationInterval: 100,
+	}, comparer
+}
+
+func Test_bisect_DefaultsToFirstFailedValidation(t *testing.T) {
+	b, comparer := newBisector(250, &p_dex.PoolValidationResult{Height: 300, Status: p_dex.PoolValidationFailed})
+
+	report, err := b.bisect("pool1", 0, 0)
+
+	require.NoError(t, err)
+	assert.Equal(t, uint64(200), report.Good)
+	assert.Equal(t, uint64(300), report.Bad)
+	assert.Equal(t, uint64(250), report.Divergence.Height)
+	assert.Equal(t, uint64(250), comparer.height)
+	assert.Equal(t, []string{"tx-250"}, comparer.hashes)
+	require.Len(t, report.ParsedTxs, 1)
+	assert.Equal(t, "tx-250", report.ParsedTxs[0].Hash)
+}
+
+func Test_bisect_UsesGivenHeights(t *testing.T) {
+	b, _ := newBisector(57, nil)
+
+	report, err := b.bisect("pool1", 10, 80)
+
+	require.NoError(t, err)
+	assert.Equal(t, uint64(10), report.Good)
+	assert.Equal(t, uint64(57), report.Divergence.Height)
+	assert.Equal(t, uint64(56), report.Divergence.LastMatchingHeight)
+}
+
+func Test_bisect_RequiresBadHeightWithoutFailedValidation(t *testing.T) {
+	b, _ := newBisector(57, nil)
+
+	_, err := b.bisect("pool1", 0, 0)
+
+	require.ErrorContains(t, err, "no failed pool validation")
+}
@@ -7,18 +7,20 @@
 // 1. input targetHeight
 // 2. validate heights (dbHeight < sourceHeight, dbHeight < targetHeight, targetHeight <= sourceHeight)
 // 3. read pool states at targetHeight
-// 4. save checkpoint to DB
+// 4. save checkpoint to DB, or print it with -preview
 package main
 
 import (
+	"encoding/json"
 	"flag"
 	"net/http"
+	"os"
 	"time"
 
-	"github.com/dezswap/cosmwasm-etl/collector/datastore"
 	"github.com/dezswap/cosmwasm-etl/configs"
 	"github.com/dezswap/cosmwasm-etl/parser/checkpoint"
 	pdex "github.com/dezswap/cosmwasm-etl/parser/dex"
+	"github.com/dezswap/cosmwasm-etl/parser/dex/dexwiring"
 	"github.com/dezswap/cosmwasm-etl/parser/dex/repo"
 	"github.com/dezswap/cosmwasm-etl/parser/dex/srcstore"
 	pts "github.com/dezswap/cosmwasm-etl/parser/dex/srcstore/terraswap"
@@ -42,18 +44,24 @@ func main() {
 	grpc.SetLogConfig(c.Log)
 
 	var targetHeight uint64
+	var preview bool
 	flag.Uint64Var(&targetHeight, "height", 0, "target block height")
+	flag.BoolVar(&preview, "preview", false, "print the checkpoint txs as JSON without inserting them")
 	flag.Parse()
 
-	logger := logging.New("checkpoint", c.Log)
+	// the preview JSON is written to stdout, which the logger writes to as well
+	logger := logging.Discard
+	if !preview {
+		logger = logging.New("checkpoint", c.Log)
+	}
 	logger.WithField("version", version).Info("starting checkpoint")
 
-	if err := run(c, targetHeight); err != nil {
+	if err := run(c, targetHeight, preview, logger); err != nil {
 		panic(err)
 	}
 }
 
-func run(c configs.Config, targetHeight uint64) error {
+func run(c configs.Config, targetHeight uint64, preview bool, logger logging.Logger) error {
 	r := repo.New(c.Parser.DexConfig.ChainId, c.Rdb)
 	httpClient := &http.Client{
 		Transport: &http.Transport{
@@ -62,13 +70,26 @@ func run(c configs.Config, targetHeight uint64) error {
 			DisableKeepAlives: false,
 		},
 	}
-	ds := NewSourceDataStore(c, httpClient)
+	ds, err := NewSourceDataStore(c, httpClient)
+	if err != nil {
+		return err
+	}
+
+	builder := checkpoint.NewBuilder(r, ds, logger)
+	if !preview {
+		return builder.Build(targetHeight)
+	}
 
-	builder := checkpoint.NewBuilder(r, ds)
-	return builder.Build(targetHeight)
+	cp, err := builder.Preview(targetHeight)
+	if err != nil {
+		return err
+	}
+	return json.NewEncoder(os.Stdout).Encode(cp)
 }
 
-func NewSourceDataStore(c configs.Config, httpClient *http.Client) pdex.SourceDataStore {
+// NewSourceDataStore reads terraswap pools from the chain's LCD and the pools
+// of the other DEXes from the collector-backed read store of dexwiring.
+func NewSourceDataStore(c configs.Config, httpClient *http.Client) (pdex.SourceDataStore, error) {
 	dc := c.Parser.DexConfig
 
 	if dc.TargetApp == dex.Terraswap {
@@ -78,39 +99,27 @@ func NewSourceDataStore(c configs.Config, httpClient *http.Client) pdex.SourceDa
 		case terraswap.CLASSIC_V1_FACTORY:
 			lcd := col4.NewLcd(dc.NodeConfig.RestClientConfig.LcdHost, httpClient)
 			queryClient := columbusv1.NewCol4Client(lcd)
-			return pts.NewCol4Store(dc.FactoryAddress, r, lcd, queryClient)
+			return pts.NewCol4Store(dc.FactoryAddress, r, lcd, queryClient), nil
 		case terraswap.CLASSIC_V2_FACTORY:
 			lcd := cosmos45.NewLcd(dc.NodeConfig.RestClientConfig.LcdHost, httpClient)
 			queryClient := columbusv2.NewColumbusV2Client(lcd)
-			return pts.NewCol5Store(dc.FactoryAddress, r, lcd, queryClient)
+			return pts.NewCol5Store(dc.FactoryAddress, r, lcd, queryClient), nil
 		case terraswap.MAINNET_FACTORY:
 			lcd := cosmos45.NewLcd(dc.NodeConfig.RestClientConfig.LcdHost, httpClient)
 			queryClient := phoenix.NewPhoenixClient(lcd)
-			return pts.NewCol5Store(dc.FactoryAddress, r, lcd, queryClient)
+			return pts.NewCol5Store(dc.FactoryAddress, r, lcd, queryClient), nil
 		case terraswap.PISCO_FACTORY:
-			panic(errors.New("not implemented yet"))
+			lcd := cosmos45.NewLcd(dc.NodeConfig.RestClientConfig.LcdHost, httpClient)
+			queryClient := phoenix.NewPhoenixClient(lcd)
+			return pts.NewPiscoStore(dc.FactoryAddress, r, lcd, queryClient), nil
 		default:
-			panic(errors.Errorf("invalid factory address: %s", dc.FactoryAddress))
+			return nil, errors.Errorf("invalid factory address: %s", dc.FactoryAddress)
 		}
 	}
 
-	readStore := NewReadStore(c, dc.ChainId, httpClient)
-	return srcstore.New(readStore)
-}
-
-func NewReadStore(c configs.Config, chainId string, httpClient *http.Client) datastore.ReadStore {
-	nc := c.Parser.DexConfig.NodeConfig
-	serviceDesc := grpc.GetServiceDesc("checkpoint", nc.GrpcConfig)
-
-	var lcdClient datastore.LcdClient
-	if nc.FailoverLcdHost != "" {
-		lcdClient = datastore.NewLcdClient(nc.FailoverLcdHost, httpClient)
-	}
-
-	store, err := datastore.New(c, serviceDesc, lcdClient)
+	readStore, err := dexwiring.NewTargetReadStore(c, dc)
 	if err != nil {
-		panic(err)
+		return nil, errors.Wrap(err, "failed to create read store")
 	}
-
-	return datastore.NewReadStoreWithGrpc(chainId, store)
+	return srcstore.New(readStore), nil
 }
@@ -38,6 +38,16 @@ func dex_main(c configs.ParserDexConfig, logc configs.LogConfig, sentryc configs
 	}
 	defer catch(logger)
 
+	// an unfinished parser-reparse has moved the synced height below heights
+	// whose rows were deleted, parsing on from there would skip the restore
+	pendingReparse, err := repo.NewRewinder(c.ChainId, rdbc).PendingReparseHeight()
+	if err != nil {
+		panic(err)
+	}
+	if pendingReparse > 0 {
+		panic(fmt.Errorf("a parser-reparse up to height %d is unfinished, rerun parser-reparse before starting the parser", pendingReparse))
+	}
+
 	repo := repo.New(c.ChainId, rdbc)
 	app, err := dexwiring.NewTargetApp(repo, logger, c)
 	if err != nil {
@@ -50,6 +60,7 @@ func dex_main(c configs.ParserDexConfig, logc configs.LogConfig, sentryc configs
 	}
 
 	runner := p_dex.NewDexApp(app, rawDataStore, repo, logger, c)
+	notifier, _ := rawDataStore.(p_dex.HeightNotifier)
 
 	const BLOCK_SECONDS = 5 * time.Second
 	for errCount := uint(0); errCount <= c.ErrTolerance; {
@@ -75,6 +86,12 @@ func dex_main(c configs.ParserDexConfig, logc configs.LogConfig, sentryc configs
 			errCount = 0
 		}
 		wait := BLOCK_SECONDS * time.Duration(math.Pow(2, float64(errCount)))
+		if notifier != nil && errCount == 0 {
+			if synced, err := repo.GetSyncedHeight(); err == nil {
+				notifier.WaitForHeight(synced, wait)
+				continue
+			}
+		}
 		time.Sleep(wait)
 	}
 }
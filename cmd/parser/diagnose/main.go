@@ -7,10 +7,8 @@ import (
 	"fmt"
 	"math"
 	"os"
-	"strings"
 
 	"github.com/dezswap/cosmwasm-etl/configs"
-	"github.com/dezswap/cosmwasm-etl/parser"
 	p_dex "github.com/dezswap/cosmwasm-etl/parser/dex"
 	"github.com/dezswap/cosmwasm-etl/parser/dex/dexwiring"
 	"github.com/dezswap/cosmwasm-etl/parser/dex/repo"
@@ -23,6 +21,9 @@ type diagnosisReport struct {
 	To       uint64              `json:"to_height"`
 	Contract string              `json:"contract"`
 	Results  []diagnosisTxResult `json:"results"`
+	// ChangedTxCount is the number of results whose replay differs from the
+	// stored parsed_tx rows, set with --compare only.
+	ChangedTxCount *int `json:"changed_tx_count,omitempty"`
 }
 
 type diagnosisTxResult struct {
@@ -32,12 +33,16 @@ type diagnosisTxResult struct {
 	ParsedTxCount int              `json:"parsed_tx_count"`
 	ParsedTxs     []p_dex.ParsedTx `json:"parsed_txs,omitempty"`
 	Error         string           `json:"error,omitempty"`
+
+	Comparison *repo.ParsedTxComparison `json:"comparison,omitempty"`
 }
 
 func main() {
 	from := flag.Uint64("from", 0, "first height to diagnose")
 	to := flag.Uint64("to", 0, "last height to diagnose")
 	contract := flag.String("contract", "", "pair contract address to diagnose")
+	compare := flag.Bool("compare", false, "compare the replayed parse with the stored parsed_tx rows of each tx")
+	poolDelta := flag.Bool("pool-delta", false, "with --compare, also compare the pool balance delta of both versions")
 	flag.Parse()
 
 	if *from == 0 || *to == 0 || *contract == "" {
@@ -46,6 +51,9 @@ func main() {
 	if *from > *to {
 		fail("--from must be less than or equal to --to")
 	}
+	if *poolDelta && !*compare {
+		fail("--pool-delta requires --compare")
+	}
 
 	c := configs.New()
 	dc := c.Parser.DexConfig
@@ -58,7 +66,10 @@ func main() {
 		fail(err.Error())
 	}
 
-	report, err := diagnoseRange(target, source, tokenExceptions, dc.ChainId, *from, *to, *contract)
+	report, err := diagnoseRange(target, source, tokenExceptions, dc.ChainId, dc.RouterAddr, *from, *to, *contract)
+	if err == nil && *compare {
+		err = compareResults(repo.NewParsedTxComparer(dc.ChainId, c.Rdb), &report, *poolDelta)
+	}
 	if err := json.NewEncoder(os.Stdout).Encode(report); err != nil {
 		fail(err.Error())
 	}
@@ -92,8 +103,10 @@ func buildDiagnosticTargets(c configs.Config, dc configs.ParserDexConfig) (p_dex
 	return app, source, tokenExceptions, nil
 }
 
-// diagnoseRange replays parsing for matching raw transactions without writing parser state.
-func diagnoseRange(app p_dex.TargetApp, source p_dex.SourceDataStore, tokenExceptions map[string]bool, chainID string, from, to uint64, contract string) (diagnosisReport, error) {
+// diagnoseRange replays parsing for matching raw transactions without writing
+// parser state. Swaps executed through routerAddr get their route meta, as
+// they do when parsed by the parser.
+func diagnoseRange(app p_dex.TargetApp, source p_dex.SourceDataStore, tokenExceptions map[string]bool, chainID, routerAddr string, from, to uint64, contract string) (diagnosisReport, error) {
 	report := diagnosisReport{
 		ChainID:  chainID,
 		From:     from,
@@ -110,7 +123,7 @@ func diagnoseRange(app p_dex.TargetApp, source p_dex.SourceDataStore, tokenExcep
 			return report, fmt.Errorf("get source txs at height %d: %w", height, err)
 		}
 		for _, tx := range txs {
-			if !rawTxContainsContract(tx, contract) {
+			if !p_dex.RawTxContainsContract(tx, contract) {
 				continue
 			}
 			result := diagnosisTxResult{
@@ -122,12 +135,12 @@ func diagnoseRange(app p_dex.TargetApp, source p_dex.SourceDataStore, tokenExcep
 			if err != nil {
 				var partial *p_dex.PartialParseQuarantineError
 				if errors.As(err, &partial) {
-					result.ParsedTxs = partial.ParsedTxs
+					result.ParsedTxs = p_dex.AttributeRoutes(routerAddr, tx, partial.ParsedTxs)
 					result.ParsedTxCount = len(partial.ParsedTxs)
 				}
 				result.Error = err.Error()
 			} else {
-				result.ParsedTxs = parsedTxs
+				result.ParsedTxs = p_dex.AttributeRoutes(routerAddr, tx, parsedTxs)
 				result.ParsedTxCount = len(parsedTxs)
 			}
 			report.Results = append(report.Results, result)
@@ -139,22 +152,23 @@ func diagnoseRange(app p_dex.TargetApp, source p_dex.SourceDataStore, tokenExcep
 	return report, nil
 }
 
-// rawTxContainsContract reports whether a raw transaction directly mentions the target contract.
-func rawTxContainsContract(tx parser.RawTx, contract string) bool {
-	if contract == "" {
-		return false
-	}
-	if strings.Contains(tx.Hash, contract) || strings.Contains(tx.Sender, contract) {
-		return true
-	}
-	for _, log := range tx.LogResults {
-		for _, attr := range log.Attributes {
-			if strings.Contains(attr.Value, contract) {
-				return true
-			}
+// compareResults compares the replayed parse of each result with the stored
+// parsed_tx rows of its tx.
+func compareResults(comparer repo.ParsedTxComparer, report *diagnosisReport, poolDelta bool) error {
+	changed := 0
+	report.ChangedTxCount = &changed
+	for idx := range report.Results {
+		result := &report.Results[idx]
+		comparison, err := comparer.CompareParsedTxs(result.Height, result.Hash, result.ParsedTxs, poolDelta)
+		if err != nil {
+			return fmt.Errorf("compare tx %s at height %d: %w", result.Hash, result.Height, err)
+		}
+		result.Comparison = &comparison
+		if !comparison.Unchanged() {
+			changed++
 		}
 	}
-	return false
+	return nil
 }
 
 func fail(msg string) {
@@ -7,28 +7,13 @@ import (
 
 	"github.com/dezswap/cosmwasm-etl/parser"
 	p_dex "github.com/dezswap/cosmwasm-etl/parser/dex"
+	"github.com/dezswap/cosmwasm-etl/parser/dex/repo"
+	"github.com/dezswap/cosmwasm-etl/pkg/db/schemas"
 	"github.com/dezswap/cosmwasm-etl/pkg/eventlog"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
-func Test_rawTxContainsContract(t *testing.T) {
-	tx := parser.RawTx{
-		Hash:   "hash",
-		Sender: "sender",
-		LogResults: eventlog.LogResults{{
-			Type: eventlog.WasmType,
-			Attributes: eventlog.Attributes{
-				{Key: "_contract_address", Value: "pair1"},
-				{Key: "action", Value: "swap"},
-			},
-		}},
-	}
-
-	assert.True(t, rawTxContainsContract(tx, "pair1"))
-	assert.False(t, rawTxContainsContract(tx, "pair2"))
-}
-
 func Test_diagnoseRange_ReplaysOnlyMatchingTransactions(t *testing.T) {
 	target := &diagnoseTargetApp{}
 	source := &diagnoseSourceDataStore{
@@ -41,7 +26,7 @@ func Test_diagnoseRange_ReplaysOnlyMatchingTransactions(t *testing.T) {
 	}
 	tokenExceptions := map[string]bool{"token": true}
 
-	report, err := diagnoseRange(target, source, tokenExceptions, "chain-1", 10, 10, "pair1")
+	report, err := diagnoseRange(target, source, tokenExceptions, "chain-1", "", 10, 10, "pair1")
 
 	require.NoError(t, err)
 	require.Len(t, report.Results, 1)
@@ -66,7 +51,7 @@ func Test_diagnoseRange_ReturnsPartialReportOnHeightError(t *testing.T) {
 		},
 	}
 
-	report, err := diagnoseRange(target, source, map[string]bool{}, "chain-1", 10, 11, "pair1")
+	report, err := diagnoseRange(target, source, map[string]bool{}, "chain-1", "", 10, 11, "pair1")
 
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "get source txs at height 11")
@@ -75,6 +60,65 @@ func Test_diagnoseRange_ReturnsPartialReportOnHeightError(t *testing.T) {
 	assert.Equal(t, []uint64{10, 11}, target.updatedHeights)
 }
 
+func Test_diagnoseRange_AttributesRouterHops(t *testing.T) {
+	routed := rawTxWithContract("routed-hash", "pair1")
+	routed.LogResults[0].Attributes = append(eventlog.Attributes{{Key: "_contract_address", Value: "router"}}, routed.LogResults[0].Attributes...)
+	source := &diagnoseSourceDataStore{
+		txs: map[uint64]parser.RawTxs{10: {routed}},
+	}
+
+	report, err := diagnoseRange(&diagnoseTargetApp{}, source, map[string]bool{}, "chain-1", "router", 10, 10, "pair1")
+
+	require.NoError(t, err)
+	require.Len(t, report.Results, 1)
+	require.Len(t, report.Results[0].ParsedTxs, 1)
+	assert.Equal(t, "routed-hash-0", report.Results[0].ParsedTxs[0].Meta[p_dex.RouteIdMetaKey])
+	assert.Equal(t, 0, report.Results[0].ParsedTxs[0].Meta[p_dex.RouteHopMetaKey])
+}
+
+func Test_compareResults_CountsChangedTxs(t *testing.T) {
+	report := diagnosisReport{Results: []diagnosisTxResult{
+		{Height: 10, Hash: "same", ParsedTxs: []p_dex.ParsedTx{{Hash: "same"}}},
+		{Height: 10, Hash: "changed", ParsedTxs: []p_dex.ParsedTx{{Hash: "changed"}}},
+	}}
+	comparer := &diagnoseComparer{comparisons: map[string]repo.ParsedTxComparison{
+		"same":    {},
+		"changed": {Missing: []schemas.ParsedTx{{Hash: "changed"}}},
+	}}
+
+	require.NoError(t, compareResults(comparer, &report, true))
+
+	require.NotNil(t, report.ChangedTxCount)
+	assert.Equal(t, 1, *report.ChangedTxCount)
+	assert.True(t, report.Results[0].Comparison.Unchanged())
+	assert.Len(t, report.Results[1].Comparison.Missing, 1)
+	assert.Equal(t, []bool{true, true}, comparer.poolDeltas)
+}
+
+func Test_compareResults_ReturnsComparerError(t *testing.T) {
+	report := diagnosisReport{Results: []diagnosisTxResult{{Height: 10, Hash: "hash"}}}
+	comparer := &diagnoseComparer{err: errors.New("db error")}
+
+	err := compareResults(comparer, &report, false)
+
+	require.ErrorContains(t, err, "compare tx hash at height 10")
+}
+
+type diagnoseComparer struct {
+	comparisons map[string]repo.ParsedTxComparison
+	poolDeltas  []bool
+	err         error
+}
+
+func (c *diagnoseComparer) CompareParsedTxs(_ uint64, hash string, _ []p_dex.ParsedTx, poolDelta bool) (repo.ParsedTxComparison, error) {
+	c.poolDeltas = append(c.poolDeltas, poolDelta)
+	return c.comparisons[hash], c.err
+}
+
+func (*diagnoseComparer) StoredParsedTxs(uint64, []string) ([]schemas.ParsedTx, error) {
+	return nil, nil
+}
+
 type diagnoseTargetApp struct {
 	updatedHeights  []uint64
 	tokenExceptions map[string]bool
@@ -0,0 +1,200 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/dezswap/cosmwasm-etl/configs"
+	p_dex "github.com/dezswap/cosmwasm-etl/parser/dex"
+	"github.com/dezswap/cosmwasm-etl/parser/dex/dexwiring"
+	"github.com/dezswap/cosmwasm-etl/parser/dex/repo"
+	"github.com/dezswap/cosmwasm-etl/pkg/db/schemas"
+	"github.com/dezswap/cosmwasm-etl/pkg/logging"
+)
+
+const usage = "usage: parser-quarantine <list|show|retry|resolve|ignore> [flags]"
+
+type retryResult struct {
+	ID            uint64           `json:"id"`
+	Height        uint64           `json:"height"`
+	Hash          string           `json:"hash"`
+	Stage         string           `json:"stage"`
+	Partial       bool             `json:"partial"`
+	Status        string           `json:"status"`
+	ParsedTxCount int              `json:"parsed_tx_count"`
+	ParsedTxs     []p_dex.ParsedTx `json:"parsed_txs,omitempty"`
+	Error         string           `json:"error,omitempty"`
+}
+
+type closeResult struct {
+	ID     uint64 `json:"id"`
+	Status string `json:"status"`
+	Reason string `json:"reason"`
+}
+
+// quarantineTool is the parser state the subcommands read and write.
+type quarantineTool struct {
+	manager    repo.QuarantineManager
+	parserRepo p_dex.Repo
+	newTarget  func() (p_dex.TargetApp, error)
+	routerAddr string
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		fail(usage)
+	}
+	command, args := os.Args[1], os.Args[2:]
+
+	flags := flag.NewFlagSet(command, flag.ExitOnError)
+	id := flags.Uint64("id", 0, "parse_quarantine id")
+	reason := flags.String("reason", "", "why the quarantine is resolved or ignored")
+	filter := repo.QuarantineFilter{}
+	if command == "list" {
+		flags.StringVar(&filter.Status, "status", p_dex.QuarantineStatusPending, "status to list, empty for all")
+		flags.StringVar(&filter.Stage, "stage", "", "parse stage, e.g. wasm_transfer or partial_wasm_transfer")
+		flags.StringVar(&filter.Contract, "contract", "", "contract of the ambiguous event")
+		flags.StringVar(&filter.Action, "action", "", "action of the ambiguous event")
+		flags.Uint64Var(&filter.FromHeight, "from", 0, "first height to list")
+		flags.Uint64Var(&filter.ToHeight, "to", 0, "last height to list")
+		flags.IntVar(&filter.Limit, "limit", 100, "maximum number of quarantines, 0 for all")
+	}
+	_ = flags.Parse(args)
+
+	switch command {
+	case "list":
+	case "show", "retry":
+		if *id == 0 {
+			fail("required flags: --id")
+		}
+	case "resolve", "ignore":
+		if *id == 0 || *reason == "" {
+			fail("required flags: --id, --reason")
+		}
+	default:
+		fail(usage)
+	}
+
+	c := configs.New()
+	dc := c.Parser.DexConfig
+	if err := dc.Validate(); err != nil {
+		fail(fmt.Sprintf("invalid parser dex config: %s", err))
+	}
+	parserRepo := repo.New(dc.ChainId, c.Rdb)
+	tool := quarantineTool{
+		manager:    repo.NewQuarantineManager(dc.ChainId, c.Rdb),
+		parserRepo: parserRepo,
+		newTarget: func() (p_dex.TargetApp, error) {
+			return dexwiring.NewTargetApp(parserRepo, logging.Discard, dc)
+		},
+		routerAddr: dc.RouterAddr,
+	}
+
+	var (
+		output interface{}
+		err    error
+	)
+	switch command {
+	case "list":
+		output, err = tool.manager.ParseQuarantines(filter)
+	case "show":
+		output, err = tool.show(*id)
+	case "retry":
+		output, err = tool.retry(*id)
+	case "resolve":
+		output, err = tool.close(*id, p_dex.QuarantineStatusResolved, *reason)
+	case "ignore":
+		output, err = tool.close(*id, p_dex.QuarantineStatusIgnored, *reason)
+	}
+	if err != nil {
+		fail(err.Error())
+	}
+	if err := json.NewEncoder(os.Stdout).Encode(output); err != nil {
+		fail(err.Error())
+	}
+}
+
+// show returns the quarantine with its raw tx decoded.
+func (t *quarantineTool) show(id uint64) (interface{}, error) {
+	row, err := t.manager.ParseQuarantine(id)
+	if err != nil {
+		return nil, err
+	}
+	quarantine, err := repo.ToParseQuarantineDto(row)
+	if err != nil {
+		return nil, err
+	}
+	row.RawTx = nil
+	return struct {
+		schemas.ParseQuarantine
+		RawTx interface{} `json:"rawTx"`
+	}{row, quarantine.RawTx}, nil
+}
+
+// retry parses the raw tx of a pending quarantine again and resolves it when
+// the parse is no longer ambiguous. The parsed_tx rows a partial quarantine
+// inserted are replaced by the new ones. An ambiguous parse leaves the
+// quarantine pending and is reported in the result.
+func (t *quarantineTool) retry(id uint64) (retryResult, error) {
+	row, err := t.manager.ParseQuarantine(id)
+	if err != nil {
+		return retryResult{}, err
+	}
+	result := retryResult{
+		ID:      row.Id,
+		Height:  row.Height,
+		Hash:    row.Hash,
+		Stage:   row.Stage,
+		Partial: p_dex.IsPartialQuarantineStage(row.Stage),
+		Status:  row.Status,
+	}
+	if row.Status != p_dex.QuarantineStatusPending {
+		return result, fmt.Errorf("quarantine id=%d is %s, only pending quarantines are retried", id, row.Status)
+	}
+	quarantine, err := repo.ToParseQuarantineDto(row)
+	if err != nil {
+		return result, err
+	}
+
+	tokenExceptions, err := t.parserRepo.GetTokenExceptions()
+	if err != nil {
+		return result, fmt.Errorf("load token exceptions: %w", err)
+	}
+	target, err := t.newTarget()
+	if err != nil {
+		return result, err
+	}
+	txs, err := p_dex.ReplayQuarantine(target, t.routerAddr, tokenExceptions, quarantine)
+	if err != nil {
+		var partial *p_dex.PartialParseQuarantineError
+		if errors.As(err, &partial) {
+			result.ParsedTxs = partial.ParsedTxs
+			result.ParsedTxCount = len(partial.ParsedTxs)
+		}
+		result.Error = err.Error()
+		return result, nil
+	}
+	if err := t.parserRepo.ResolveParseQuarantine(quarantine.ID, quarantine.Height, txs); err != nil {
+		return result, err
+	}
+	result.Status = p_dex.QuarantineStatusResolved
+	result.ParsedTxs = txs
+	result.ParsedTxCount = len(txs)
+	return result, nil
+}
+
+// close resolves or ignores a pending quarantine without parsing it.
+func (t *quarantineTool) close(id uint64, status string, reason string) (closeResult, error) {
+	if err := t.manager.CloseParseQuarantine(id, status, reason); err != nil {
+		return closeResult{}, err
+	}
+	return closeResult{ID: id, Status: status, Reason: reason}, nil
+}
+
+func fail(msg string) {
+	_, _ = fmt.Fprintln(os.Stderr, msg)
+	os.Exit(1)
+}
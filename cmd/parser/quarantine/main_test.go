@@ -0,0 +1,132 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/dezswap/cosmwasm-etl/parser"
+	p_dex "github.com/dezswap/cosmwasm-etl/parser/dex"
+	"github.com/dezswap/cosmwasm-etl/parser/dex/repo"
+	"github.com/dezswap/cosmwasm-etl/pkg/db/schemas"
+	"github.com/dezswap/cosmwasm-etl/pkg/eventlog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type quarantineTargetApp struct {
+	parse func(tx parser.RawTx, height uint64) ([]p_dex.ParsedTx, error)
+}
+
+func (a *quarantineTargetApp) ParseTxs(tx parser.RawTx, height uint64) ([]p_dex.ParsedTx, error) {
+	return a.parse(tx, height)
+}
+
+func (*quarantineTargetApp) IsValidationExceptionCandidate(string) bool {
+	return false
+}
+
+func (*quarantineTargetApp) UpdateParsers(map[string]bool, uint64) error {
+	return nil
+}
+
+type quarantineManager struct {
+	rows   map[uint64]schemas.ParseQuarantine
+	closed map[uint64]string
+}
+
+func (m *quarantineManager) ParseQuarantines(repo.QuarantineFilter) ([]schemas.ParseQuarantine, error) {
+	return nil, nil
+}
+
+func (m *quarantineManager) ParseQuarantine(id uint64) (schemas.ParseQuarantine, error) {
+	row, ok := m.rows[id]
+	if !ok {
+		return row, errors.New("record not found")
+	}
+	return row, nil
+}
+
+func (m *quarantineManager) CloseParseQuarantine(id uint64, status string, _ string) error {
+	m.closed[id] = status
+	return nil
+}
+
+func newQuarantineTool(t *testing.T, row schemas.ParseQuarantine, parse func(parser.RawTx, uint64) ([]p_dex.ParsedTx, error)) (quarantineTool, *p_dex.RepoMock) {
+	rawTx, err := json.Marshal(parser.RawTx{Hash: row.Hash})
+	require.NoError(t, err)
+	row.RawTx = rawTx
+
+	parserRepo := &p_dex.RepoMock{}
+	parserRepo.On("GetTokenExceptions").Return(map[string]bool{}, nil)
+	return quarantineTool{
+		manager:    &quarantineManager{rows: map[uint64]schemas.ParseQuarantine{row.Id: row}, closed: map[uint64]string{}},
+		parserRepo: parserRepo,
+		newTarget: func() (p_dex.TargetApp, error) {
+			return &quarantineTargetApp{parse: parse}, nil
+		},
+	}, parserRepo
+}
+
+func Test_retry_ResolvesPartialQuarantine(t *testing.T) {
+	parsedTx := p_dex.ParsedTx{Hash: "hash", Type: p_dex.Transfer, ContractAddr: "token"}
+	tool, parserRepo := newQuarantineTool(t, schemas.ParseQuarantine{
+		Id: 3, Height: 10, Hash: "hash", Stage: p_dex.PartialQuarantineStagePrefix + "wasm_transfer", Status: p_dex.QuarantineStatusPending,
+	}, func(tx parser.RawTx, height uint64) ([]p_dex.ParsedTx, error) {
+		assert.Equal(t, "hash", tx.Hash)
+		assert.Equal(t, uint64(10), height)
+		return []p_dex.ParsedTx{parsedTx}, nil
+	})
+	parserRepo.On("ResolveParseQuarantine", uint64(3), uint64(10), []p_dex.ParsedTx{parsedTx}).Return(nil)
+
+	result, err := tool.retry(3)
+
+	require.NoError(t, err)
+	assert.True(t, result.Partial)
+	assert.Equal(t, p_dex.QuarantineStatusResolved, result.Status)
+	assert.Equal(t, 1, result.ParsedTxCount)
+	parserRepo.AssertExpectations(t)
+}
+
+func Test_retry_LeavesAmbiguousReplayPending(t *testing.T) {
+	tool, parserRepo := newQuarantineTool(t, schemas.ParseQuarantine{
+		Id: 4, Height: 11, Hash: "hash", Stage: "wasm_transfer", Status: p_dex.QuarantineStatusPending,
+	}, func(parser.RawTx, uint64) ([]p_dex.ParsedTx, error) {
+		return nil, &eventlog.AmbiguousEventError{Key: "amount", Values: []string{"1", "2"}}
+	})
+
+	result, err := tool.retry(4)
+
+	require.NoError(t, err)
+	assert.Equal(t, p_dex.QuarantineStatusPending, result.Status)
+	assert.NotEmpty(t, result.Error)
+	parserRepo.AssertNotCalled(t, "ResolveParseQuarantine", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func Test_retry_RejectsClosedQuarantine(t *testing.T) {
+	tool, _ := newQuarantineTool(t, schemas.ParseQuarantine{
+		Id: 5, Height: 12, Hash: "hash", Stage: "wasm_transfer", Status: p_dex.QuarantineStatusIgnored,
+	}, func(parser.RawTx, uint64) ([]p_dex.ParsedTx, error) {
+		t.Fatal("closed quarantines must not be replayed")
+		return nil, nil
+	})
+
+	_, err := tool.retry(5)
+
+	require.ErrorContains(t, err, "only pending quarantines are retried")
+}
+
+func Test_show_DecodesRawTx(t *testing.T) {
+	tool, _ := newQuarantineTool(t, schemas.ParseQuarantine{Id: 6, Hash: "hash"}, nil)
+
+	output, err := tool.show(6)
+	require.NoError(t, err)
+
+	bytes, err := json.Marshal(output)
+	require.NoError(t, err)
+	var decoded map[string]interface{}
+	require.NoError(t, json.Unmarshal(bytes, &decoded))
+	assert.Equal(t, float64(6), decoded["id"])
+	assert.Equal(t, "hash", decoded["rawTx"].(map[string]interface{})["hash"])
+}
@@ -0,0 +1,213 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/dezswap/cosmwasm-etl/configs"
+	p_dex "github.com/dezswap/cosmwasm-etl/parser/dex"
+	"github.com/dezswap/cosmwasm-etl/parser/dex/dexwiring"
+	"github.com/dezswap/cosmwasm-etl/parser/dex/repo"
+	"github.com/dezswap/cosmwasm-etl/pkg/logging"
+)
+
+type reparseReport struct {
+	ChainID      string             `json:"chain_id"`
+	From         uint64             `json:"from_height"`
+	To           uint64             `json:"to_height"`
+	DryRun       bool               `json:"dry_run"`
+	SyncedHeight uint64             `json:"synced_height"`
+	Deleted      *repo.RewindCounts `json:"deleted,omitempty"`
+	Reparsed     reparsedCounts     `json:"reparsed"`
+	Diffs        []repo.RowDiff     `json:"diffs,omitempty"`
+}
+
+type reparsedCounts struct {
+	Heights          int `json:"heights"`
+	ParsedTxs        int `json:"parsed_txs"`
+	PoolInfos        int `json:"pool_infos"`
+	Pairs            int `json:"pairs"`
+	ParseQuarantines int `json:"parse_quarantines"`
+}
+
+// reparser is the parser state reparse reads and writes.
+type reparser struct {
+	parserRepo p_dex.Repo
+	rewinder   repo.Rewinder
+	newTarget  func(p_dex.PairRepo) (p_dex.TargetApp, error)
+	source     p_dex.SourceDataStore
+	config     configs.ParserDexConfig
+	logger     logging.Logger
+}
+
+// pairsBefore hides the pairs created within the reparsed range, so the
+// target app starts from the pair state as of its first height.
+type pairsBefore struct {
+	p_dex.PairRepo
+	created map[string]bool
+}
+
+func (r *pairsBefore) GetPairs() (map[string]p_dex.Pair, error) {
+	pairs, err := r.PairRepo.GetPairs()
+	if err != nil {
+		return nil, err
+	}
+	for contract := range r.created {
+		delete(pairs, contract)
+	}
+	return pairs, nil
+}
+
+func main() {
+	from := flag.Uint64("from", 0, "first height to reparse")
+	to := flag.Uint64("to", 0, "last height to reparse")
+	dryRun := flag.Bool("dry-run", false, "print the differences between the stored and the re-parsed rows without writing")
+	flag.Parse()
+
+	if *from == 0 || *to == 0 {
+		fail("required flags: --from, --to")
+	}
+	if *from > *to {
+		fail("--from must be less than or equal to --to")
+	}
+
+	c := configs.New()
+	dc := c.Parser.DexConfig
+	if err := dc.Validate(); err != nil {
+		fail(fmt.Sprintf("invalid parser dex config: %s", err))
+	}
+
+	readStore, err := dexwiring.NewTargetReadStore(c, dc)
+	if err != nil {
+		fail(err.Error())
+	}
+	source, err := dexwiring.NewSourceDataStore(dc, c.Rdb, readStore, logging.Discard)
+	if err != nil {
+		fail(err.Error())
+	}
+	r := reparser{
+		parserRepo: repo.New(dc.ChainId, c.Rdb),
+		rewinder:   repo.NewRewinder(dc.ChainId, c.Rdb),
+		newTarget: func(pairRepo p_dex.PairRepo) (p_dex.TargetApp, error) {
+			return dexwiring.NewTargetApp(pairRepo, logging.Discard, dc)
+		},
+		source: source,
+		config: dc,
+		logger: logging.New("reparse", c.Log),
+	}
+
+	report, err := r.reparse(*from, *to, *dryRun)
+	if err := json.NewEncoder(os.Stdout).Encode(report); err != nil {
+		fail(err.Error())
+	}
+	if err != nil {
+		fail(err.Error())
+	}
+}
+
+// reparse parses [from, to] again. A dry run reports the row differences
+// against the stored rows. Otherwise the stored rows are replaced and the
+// synced height is restored once the range is written again.
+func (r *reparser) reparse(from, to uint64, dryRun bool) (reparseReport, error) {
+	report := reparseReport{ChainID: r.config.ChainId, From: from, To: to, DryRun: dryRun}
+
+	syncedHeight, err := r.parserRepo.GetSyncedHeight()
+	if err != nil {
+		return report, fmt.Errorf("load synced height: %w", err)
+	}
+	report.SyncedHeight = syncedHeight
+	pendingHeight, err := r.rewinder.PendingReparseHeight()
+	if err != nil {
+		return report, fmt.Errorf("load pending reparse: %w", err)
+	}
+	if pendingHeight > 0 {
+		// an interrupted reparse left the heights above the synced height
+		// without rows, so it has to go on from the synced height
+		report.SyncedHeight = pendingHeight
+		if from > syncedHeight+1 {
+			return report, fmt.Errorf("a reparse up to %d stopped after height %d, --from(%d) must not be above %d", pendingHeight, syncedHeight, from, syncedHeight+1)
+		}
+	}
+	if to > report.SyncedHeight {
+		return report, fmt.Errorf("--to(%d) is above the synced height(%d), heights not parsed yet are left to the parser", to, report.SyncedHeight)
+	}
+
+	tokenExceptions, err := r.parserRepo.GetTokenExceptions()
+	if err != nil {
+		return report, fmt.Errorf("load token exceptions: %w", err)
+	}
+	created, err := r.rewinder.PairsCreatedIn(from, to)
+	if err != nil {
+		return report, fmt.Errorf("load pairs created in range: %w", err)
+	}
+	createdSet := make(map[string]bool, len(created))
+	for _, contract := range created {
+		createdSet[contract] = true
+	}
+	target, err := r.newTarget(&pairsBefore{PairRepo: r.parserRepo, created: createdSet})
+	if err != nil {
+		return report, err
+	}
+
+	count := func(block p_dex.ParsedBlock) {
+		report.Reparsed.Heights++
+		report.Reparsed.ParsedTxs += len(block.Txs)
+		report.Reparsed.PoolInfos += len(block.Pools)
+		report.Reparsed.Pairs += len(block.Pairs)
+		report.Reparsed.ParseQuarantines += len(block.Quarantines)
+	}
+
+	if dryRun {
+		blocks := []p_dex.ParsedBlock{}
+		if err := p_dex.ParseRange(target, r.source, tokenExceptions, r.config, r.logger, from, to, func(block p_dex.ParsedBlock) error {
+			count(block)
+			blocks = append(blocks, block)
+			return nil
+		}); err != nil {
+			return report, err
+		}
+		if report.Diffs, err = r.rewinder.Diff(from, to, blocks); err != nil {
+			return report, fmt.Errorf("diff range: %w", err)
+		}
+		return report, nil
+	}
+
+	deleted, err := r.rewinder.StartReparse(from, to, r.config.ValidationInterval)
+	if err != nil {
+		return report, fmt.Errorf("rewind range: %w", err)
+	}
+	report.Deleted = &deleted
+	r.logger.Infof("deleted %d parsed txs, %d pool infos, %d pairs, %d parse quarantines and %d pool validation results of heights %d-%d",
+		deleted.ParsedTxs, deleted.PoolInfos, deleted.Pairs, deleted.ParseQuarantines, deleted.PoolValidationResults, from, to)
+
+	if err := p_dex.ParseRange(target, r.source, tokenExceptions, r.config, r.logger, from, to, func(block p_dex.ParsedBlock) error {
+		if err := r.parserRepo.Insert(block.Height-1, block.Height, block.Txs, block.Pools, block.Pairs, block.Quarantines); err != nil {
+			return err
+		}
+		count(block)
+		return nil
+	}); err != nil {
+		// the synced height stays at the last reparsed height and the
+		// original one is kept, so the rest of the range can be reparsed
+		// from there
+		return report, fmt.Errorf("reparse stopped after height %d, rerun parser-reparse --from %d --to %d: %w", from-1+uint64(report.Reparsed.Heights), from+uint64(report.Reparsed.Heights), to, err)
+	}
+
+	if pendingHeight > to {
+		// the heights between to and the kept synced height are still
+		// without rows from the interrupted reparse
+		r.logger.Infof("reparse up to %d is still pending, rerun parser-reparse --from %d --to %d", pendingHeight, to+1, pendingHeight)
+		return report, nil
+	}
+	if err := r.rewinder.FinishReparse(); err != nil {
+		return report, fmt.Errorf("restore synced height %d: %w", report.SyncedHeight, err)
+	}
+	return report, nil
+}
+
+func fail(msg string) {
+	_, _ = fmt.Fprintln(os.Stderr, msg)
+	os.Exit(1)
+}
@@ -0,0 +1,201 @@
+package main
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dezswap/cosmwasm-etl/configs"
+	"github.com/dezswap/cosmwasm-etl/parser"
+	p_dex "github.com/dezswap/cosmwasm-etl/parser/dex"
+	"github.com/dezswap/cosmwasm-etl/parser/dex/repo"
+	"github.com/dezswap/cosmwasm-etl/pkg/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type reparseTargetApp struct {
+	pairs map[string]p_dex.Pair
+}
+
+func (*reparseTargetApp) ParseTxs(tx parser.RawTx, _ uint64) ([]p_dex.ParsedTx, error) {
+	return []p_dex.ParsedTx{{Hash: tx.Hash, Type: p_dex.Transfer, Sender: "sender", ContractAddr: "pair1"}}, nil
+}
+
+func (*reparseTargetApp) IsValidationExceptionCandidate(string) bool {
+	return false
+}
+
+func (*reparseTargetApp) UpdateParsers(map[string]bool, uint64) error {
+	return nil
+}
+
+type reparseRewinder struct {
+	created    []string
+	diffBlocks []p_dex.ParsedBlock
+	rewound    bool
+	pending    uint64
+	finished   bool
+}
+
+func (r *reparseRewinder) PairsCreatedIn(uint64, uint64) ([]string, error) {
+	return r.created, nil
+}
+
+func (r *reparseRewinder) Diff(_, _ uint64, blocks []p_dex.ParsedBlock) ([]repo.RowDiff, error) {
+	r.diffBlocks = blocks
+	return []repo.RowDiff{{Table: "parsed_tx", Change: repo.RowAdded}}, nil
+}
+
+func (r *reparseRewinder) Rewind(uint64, uint64, uint) (repo.RewindCounts, error) {
+	return repo.RewindCounts{}, errors.New("reparse must keep the synced height")
+}
+
+func (r *reparseRewinder) StartReparse(uint64, uint64, uint) (repo.RewindCounts, error) {
+	r.rewound = true
+	return repo.RewindCounts{ParsedTxs: 2}, nil
+}
+
+func (r *reparseRewinder) PendingReparseHeight() (uint64, error) {
+	return r.pending, nil
+}
+
+func (r *reparseRewinder) FinishReparse() error {
+	r.finished = true
+	return nil
+}
+
+func newTestReparser(parserRepo *p_dex.RepoMock, rewinder *reparseRewinder, target *reparseTargetApp) reparser {
+	source := &p_dex.RawStoreMock{}
+	source.On("GetSourceTxs", uint64(10)).Return(parser.RawTxs{{Hash: "tx10"}}, nil)
+	source.On("GetSourceTxs", uint64(11)).Return(parser.RawTxs{{Hash: "tx11"}}, nil)
+
+	return reparser{
+		parserRepo: parserRepo,
+		rewinder:   rewinder,
+		newTarget: func(pairRepo p_dex.PairRepo) (p_dex.TargetApp, error) {
+			pairs, err := pairRepo.GetPairs()
+			target.pairs = pairs
+			return target, err
+		},
+		source: source,
+		config: configs.ParserDexConfig{ChainId: "chain-1", PoolSnapshotInterval: 100},
+		logger: logging.Discard,
+	}
+}
+
+func Test_reparse_DryRunDiffsWithoutWriting(t *testing.T) {
+	parserRepo := &p_dex.RepoMock{}
+	parserRepo.On("GetSyncedHeight").Return(uint64(20), nil)
+	parserRepo.On("GetTokenExceptions").Return(map[string]bool{}, nil)
+	parserRepo.On("GetPairs").Return(map[string]p_dex.Pair{"pair0": {ContractAddr: "pair0"}, "pair1": {ContractAddr: "pair1"}}, nil)
+	rewinder := &reparseRewinder{created: []string{"pair1"}}
+	target := &reparseTargetApp{}
+	r := newTestReparser(parserRepo, rewinder, target)
+
+	report, err := r.reparse(10, 11, true)
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string]p_dex.Pair{"pair0": {ContractAddr: "pair0"}}, target.pairs)
+	assert.False(t, rewinder.rewound)
+	assert.False(t, rewinder.finished)
+	require.Len(t, rewinder.diffBlocks, 2)
+	assert.Equal(t, reparsedCounts{Heights: 2, ParsedTxs: 2}, report.Reparsed)
+	assert.Len(t, report.Diffs, 1)
+	assert.Nil(t, report.Deleted)
+	parserRepo.AssertNotCalled(t, "Insert", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func Test_reparse_ReplacesRowsAndRestoresSyncedHeight(t *testing.T) {
+	parserRepo := &p_dex.RepoMock{}
+	parserRepo.On("GetSyncedHeight").Return(uint64(20), nil)
+	parserRepo.On("GetTokenExceptions").Return(map[string]bool{}, nil)
+	parserRepo.On("GetPairs").Return(map[string]p_dex.Pair{}, nil)
+	parserRepo.On("Insert", uint64(9), uint64(10), mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+	parserRepo.On("Insert", uint64(10), uint64(11), mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+	rewinder := &reparseRewinder{}
+	r := newTestReparser(parserRepo, rewinder, &reparseTargetApp{})
+
+	report, err := r.reparse(10, 11, false)
+
+	require.NoError(t, err)
+	assert.True(t, rewinder.rewound)
+	assert.True(t, rewinder.finished)
+	assert.Equal(t, &repo.RewindCounts{ParsedTxs: 2}, report.Deleted)
+	assert.Equal(t, 2, report.Reparsed.Heights)
+	parserRepo.AssertExpectations(t)
+}
+
+func Test_reparse_StopsAtFailedInsert(t *testing.T) {
+	parserRepo := &p_dex.RepoMock{}
+	parserRepo.On("GetSyncedHeight").Return(uint64(20), nil)
+	parserRepo.On("GetTokenExceptions").Return(map[string]bool{}, nil)
+	parserRepo.On("GetPairs").Return(map[string]p_dex.Pair{}, nil)
+	parserRepo.On("Insert", uint64(9), uint64(10), mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+	parserRepo.On("Insert", uint64(10), uint64(11), mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(errors.New("insert error")).Once()
+	rewinder := &reparseRewinder{}
+	r := newTestReparser(parserRepo, rewinder, &reparseTargetApp{})
+
+	_, err := r.reparse(10, 11, false)
+
+	require.ErrorContains(t, err, "rerun parser-reparse --from 11 --to 11")
+	assert.False(t, rewinder.finished)
+}
+
+func Test_reparse_ResumesInterruptedReparse(t *testing.T) {
+	parserRepo := &p_dex.RepoMock{}
+	parserRepo.On("GetSyncedHeight").Return(uint64(10), nil)
+	parserRepo.On("GetTokenExceptions").Return(map[string]bool{}, nil)
+	parserRepo.On("GetPairs").Return(map[string]p_dex.Pair{}, nil)
+	parserRepo.On("Insert", uint64(10), uint64(11), mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+	rewinder := &reparseRewinder{pending: 20}
+	r := newTestReparser(parserRepo, rewinder, &reparseTargetApp{})
+
+	report, err := r.reparse(11, 11, false)
+
+	require.NoError(t, err)
+	assert.Equal(t, uint64(20), report.SyncedHeight)
+	assert.False(t, rewinder.finished, "heights 12-20 are still without rows")
+	parserRepo.AssertExpectations(t)
+}
+
+func Test_reparse_FinishesInterruptedReparse(t *testing.T) {
+	parserRepo := &p_dex.RepoMock{}
+	parserRepo.On("GetSyncedHeight").Return(uint64(9), nil)
+	parserRepo.On("GetTokenExceptions").Return(map[string]bool{}, nil)
+	parserRepo.On("GetPairs").Return(map[string]p_dex.Pair{}, nil)
+	parserRepo.On("Insert", uint64(9), uint64(10), mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+	parserRepo.On("Insert", uint64(10), uint64(11), mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+	rewinder := &reparseRewinder{pending: 11}
+	r := newTestReparser(parserRepo, rewinder, &reparseTargetApp{})
+
+	_, err := r.reparse(10, 11, false)
+
+	require.NoError(t, err)
+	assert.True(t, rewinder.finished)
+	parserRepo.AssertExpectations(t)
+}
+
+func Test_reparse_RejectsGapAfterInterruptedReparse(t *testing.T) {
+	parserRepo := &p_dex.RepoMock{}
+	parserRepo.On("GetSyncedHeight").Return(uint64(10), nil)
+	rewinder := &reparseRewinder{pending: 20}
+	r := newTestReparser(parserRepo, rewinder, &reparseTargetApp{})
+
+	_, err := r.reparse(15, 20, false)
+
+	require.ErrorContains(t, err, "must not be above 11")
+	assert.False(t, rewinder.rewound)
+}
+
+func Test_reparse_RejectsHeightsAboveSyncedHeight(t *testing.T) {
+	parserRepo := &p_dex.RepoMock{}
+	parserRepo.On("GetSyncedHeight").Return(uint64(10), nil)
+	rewinder := &reparseRewinder{}
+	r := newTestReparser(parserRepo, rewinder, &reparseTargetApp{})
+
+	_, err := r.reparse(10, 11, false)
+
+	require.Error(t, err)
+	assert.False(t, rewinder.rewound)
+}
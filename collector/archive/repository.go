@@ -0,0 +1,80 @@
+package archive
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	collectorrepo "github.com/dezswap/cosmwasm-etl/collector/repo"
+	"github.com/dezswap/cosmwasm-etl/parser"
+	"github.com/dezswap/cosmwasm-etl/parser/dex"
+	"github.com/dezswap/cosmwasm-etl/pkg/s3client"
+	"github.com/pkg/errors"
+)
+
+// CollectorBlock is the archived form of a collector_blocks row.
+type CollectorBlock struct {
+	BlockTime  time.Time     `json:"block_time"`
+	BlockHash  string        `json:"block_hash"`
+	ParentHash string        `json:"parent_hash"`
+	Txs        parser.RawTxs `json:"txs"`
+}
+
+// repository serves block reads from archived collector_blocks rows and
+// falls back to the wrapped repository for heights that are not archived.
+type repository struct {
+	collectorrepo.Repository
+	client s3client.S3ClientInterface
+
+	mu     sync.Mutex
+	stores map[string]*Store
+}
+
+var _ collectorrepo.Repository = &repository{}
+
+func NewRepository(s3Client s3client.S3ClientInterface, base collectorrepo.Repository) collectorrepo.Repository {
+	return &repository{Repository: base, client: s3Client, stores: map[string]*Store{}}
+}
+
+func (r *repository) GetBlockTxs(chainID string, height uint64) (parser.RawTxs, time.Time, error) {
+	block, err := r.getBlock(chainID, height)
+	if errors.Is(err, ErrNotArchived) {
+		return r.Repository.GetBlockTxs(chainID, height)
+	} else if err != nil {
+		return nil, time.Time{}, err
+	}
+	return block.Txs, block.BlockTime, nil
+}
+
+func (r *repository) GetBlockHeader(chainID string, height uint64) (dex.BlockHeader, error) {
+	block, err := r.getBlock(chainID, height)
+	if errors.Is(err, ErrNotArchived) {
+		return r.Repository.GetBlockHeader(chainID, height)
+	} else if err != nil {
+		return dex.BlockHeader{}, err
+	}
+	return dex.BlockHeader{Height: height, Hash: block.BlockHash, ParentHash: block.ParentHash}, nil
+}
+
+func (r *repository) getBlock(chainID string, height uint64) (CollectorBlock, error) {
+	data, err := r.store(chainID).Get(height)
+	if err != nil {
+		return CollectorBlock{}, err
+	}
+	block := CollectorBlock{}
+	if err := json.Unmarshal(data, &block); err != nil {
+		return CollectorBlock{}, errors.Wrap(err, "archive.repository unmarshal block")
+	}
+	return block, nil
+}
+
+func (r *repository) store(chainID string) *Store {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	store, ok := r.stores[chainID]
+	if !ok {
+		store = NewStore(r.client, GetArchiveFolderPath(chainID, COLLECTOR_BLOCK_SUFFIX)...)
+		r.stores[chainID] = store
+	}
+	return store
+}
@@ -0,0 +1,158 @@
+// Package archive packs per-height collector output into compressed segments.
+//
+// A segment holds the records of up to SegmentSize consecutive heights that
+// share the aligned start height (height / SegmentSize * SegmentSize). The
+// layout is
+//
+//	magic "CWAR" | version u8 | codec u8 | start u64 | count u32 |
+//	count * (height u64, offset u64, length u64) | compressed body
+//
+// where offsets index into the decompressed body, so one decompression serves
+// every height of the segment. All integers are big endian.
+package archive
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"io"
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+type Codec string
+
+const (
+	CodecGzip Codec = "gzip"
+)
+
+const (
+	segmentVersion  = uint8(1)
+	segmentHeadSize = 4 + 1 + 1 + 8 + 4
+	indexEntrySize  = 8 + 8 + 8
+)
+
+var segmentMagic = []byte("CWAR")
+
+var codecIds = map[Codec]uint8{
+	CodecGzip: 1,
+}
+
+var (
+	ErrNotArchived    = errors.New("height is not archived")
+	ErrInvalidSegment = errors.New("invalid archive segment")
+)
+
+// Segment is a decoded archive segment.
+type Segment struct {
+	Start   uint64
+	body    []byte
+	offsets map[uint64][2]uint64
+}
+
+// Get returns the record stored for height.
+func (s *Segment) Get(height uint64) ([]byte, bool) {
+	pos, ok := s.offsets[height]
+	if !ok {
+		return nil, false
+	}
+	return s.body[pos[0] : pos[0]+pos[1]], true
+}
+
+// Heights returns the archived heights in ascending order.
+func (s *Segment) Heights() []uint64 {
+	heights := make([]uint64, 0, len(s.offsets))
+	for height := range s.offsets {
+		heights = append(heights, height)
+	}
+	sort.Slice(heights, func(i, j int) bool { return heights[i] < heights[j] })
+	return heights
+}
+
+// EncodeSegment compresses records keyed by height into a single segment.
+func EncodeSegment(codec Codec, start uint64, records map[uint64][]byte) ([]byte, error) {
+	codecId, ok := codecIds[codec]
+	if !ok {
+		return nil, errors.Errorf("EncodeSegment, unsupported codec %q", codec)
+	}
+
+	heights := make([]uint64, 0, len(records))
+	for height := range records {
+		if height < start {
+			return nil, errors.Errorf("EncodeSegment, height %d is below segment start %d", height, start)
+		}
+		heights = append(heights, height)
+	}
+	sort.Slice(heights, func(i, j int) bool { return heights[i] < heights[j] })
+
+	out := bytes.NewBuffer(make([]byte, 0, segmentHeadSize+len(heights)*indexEntrySize))
+	out.Write(segmentMagic)
+	out.WriteByte(segmentVersion)
+	out.WriteByte(codecId)
+	_ = binary.Write(out, binary.BigEndian, start)
+	_ = binary.Write(out, binary.BigEndian, uint32(len(heights)))
+
+	offset := uint64(0)
+	for _, height := range heights {
+		length := uint64(len(records[height]))
+		_ = binary.Write(out, binary.BigEndian, height)
+		_ = binary.Write(out, binary.BigEndian, offset)
+		_ = binary.Write(out, binary.BigEndian, length)
+		offset += length
+	}
+
+	zw := gzip.NewWriter(out)
+	for _, height := range heights {
+		if _, err := zw.Write(records[height]); err != nil {
+			return nil, errors.Wrap(err, "EncodeSegment, compress")
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, errors.Wrap(err, "EncodeSegment, compress")
+	}
+	return out.Bytes(), nil
+}
+
+// DecodeSegment parses and decompresses a segment produced by EncodeSegment.
+func DecodeSegment(data []byte) (*Segment, error) {
+	if len(data) < segmentHeadSize || !bytes.Equal(data[:4], segmentMagic) {
+		return nil, errors.Wrap(ErrInvalidSegment, "DecodeSegment, bad header")
+	}
+	if data[4] != segmentVersion {
+		return nil, errors.Wrapf(ErrInvalidSegment, "DecodeSegment, unsupported version %d", data[4])
+	}
+	if data[5] != codecIds[CodecGzip] {
+		return nil, errors.Wrapf(ErrInvalidSegment, "DecodeSegment, unsupported codec %d", data[5])
+	}
+	start := binary.BigEndian.Uint64(data[6:14])
+	count := uint64(binary.BigEndian.Uint32(data[14:18]))
+
+	bodyStart := segmentHeadSize + count*indexEntrySize
+	if uint64(len(data)) < bodyStart {
+		return nil, errors.Wrap(ErrInvalidSegment, "DecodeSegment, truncated index")
+	}
+
+	zr, err := gzip.NewReader(bytes.NewReader(data[bodyStart:]))
+	if err != nil {
+		return nil, errors.Wrap(err, "DecodeSegment, decompress")
+	}
+	body, err := io.ReadAll(zr)
+	if err != nil {
+		return nil, errors.Wrap(err, "DecodeSegment, decompress")
+	}
+
+	offsets := make(map[uint64][2]uint64, count)
+	for i := uint64(0); i < count; i++ {
+		entry := data[segmentHeadSize+i*indexEntrySize:]
+		height := binary.BigEndian.Uint64(entry[0:8])
+		offset := binary.BigEndian.Uint64(entry[8:16])
+		length := binary.BigEndian.Uint64(entry[16:24])
+		if offset+length > uint64(len(body)) {
+			return nil, errors.Wrapf(ErrInvalidSegment, "DecodeSegment, record of height %d is out of range", height)
+		}
+		offsets[height] = [2]uint64{offset, length}
+	}
+
+	return &Segment{Start: start, body: body, offsets: offsets}, nil
+}
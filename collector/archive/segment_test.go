@@ -0,0 +1,48 @@
+package archive
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeDecodeSegment(t *testing.T) {
+	records := map[uint64][]byte{
+		102: []byte(`{"height":102}`),
+		100: []byte(`{"height":100}`),
+		105: {},
+	}
+	data, err := EncodeSegment(CodecGzip, 100, records)
+	require.NoError(t, err)
+
+	segment, err := DecodeSegment(data)
+	require.NoError(t, err)
+	require.Equal(t, uint64(100), segment.Start)
+	require.Equal(t, []uint64{100, 102, 105}, segment.Heights())
+	for height, expected := range records {
+		actual, ok := segment.Get(height)
+		require.True(t, ok)
+		require.Equal(t, expected, actual)
+	}
+	_, ok := segment.Get(101)
+	require.False(t, ok)
+}
+
+func TestEncodeSegment_Invalid(t *testing.T) {
+	_, err := EncodeSegment(Codec("lz4"), 0, nil)
+	require.Error(t, err)
+
+	_, err = EncodeSegment(CodecGzip, 100, map[uint64][]byte{99: nil})
+	require.Error(t, err)
+}
+
+func TestDecodeSegment_Invalid(t *testing.T) {
+	data, err := EncodeSegment(CodecGzip, 0, map[uint64][]byte{1: []byte("a")})
+	require.NoError(t, err)
+
+	_, err = DecodeSegment([]byte("nope"))
+	require.ErrorIs(t, err, ErrInvalidSegment)
+
+	_, err = DecodeSegment(data[:segmentHeadSize+indexEntrySize-1])
+	require.ErrorIs(t, err, ErrInvalidSegment)
+}
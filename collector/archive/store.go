@@ -0,0 +1,271 @@
+package archive
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/dezswap/cosmwasm-etl/pkg/s3client"
+	"github.com/pkg/errors"
+)
+
+const (
+	ARCHIVE_SUFFIX         = "archive"
+	COLLECTOR_BLOCK_SUFFIX = "collector_block"
+
+	manifestFilename = "manifest.json"
+)
+
+// GetArchiveFolderPath returns the folder holding the segments of one kind of
+// per-height data, e.g. <chainId>/archive/block.
+func GetArchiveFolderPath(chainId string, kind string) []string {
+	return []string{chainId, ARCHIVE_SUFFIX, kind}
+}
+
+// Manifest describes the segments stored in an archive folder.
+type Manifest struct {
+	SegmentSize  uint64 `json:"segment_size"`
+	Codec        Codec  `json:"codec"`
+	LatestHeight uint64 `json:"latest_height"`
+}
+
+// Store reads and writes segments under one archive folder. The most recently
+// decoded segment is cached since readers usually walk heights in order.
+type Store struct {
+	client s3client.S3ClientInterface
+	folder []string
+
+	mu       sync.Mutex
+	manifest *Manifest
+	cached   *Segment
+}
+
+func NewStore(client s3client.S3ClientInterface, folder ...string) *Store {
+	return &Store{client: client, folder: folder}
+}
+
+// Manifest re-reads the manifest and returns ErrNotArchived when nothing has
+// been archived yet.
+func (s *Store) Manifest() (Manifest, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.manifest = nil
+	return s.loadManifest()
+}
+
+// Get returns the record archived for height, or ErrNotArchived.
+func (s *Store) Get(height uint64) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	manifest, err := s.loadManifest()
+	if err != nil {
+		return nil, err
+	}
+	if height > manifest.LatestHeight {
+		// the archive may have grown since the manifest was cached
+		s.manifest = nil
+		if manifest, err = s.loadManifest(); err != nil {
+			return nil, err
+		}
+		if height > manifest.LatestHeight {
+			return nil, ErrNotArchived
+		}
+		s.cached = nil
+	}
+
+	segment, err := s.loadSegment(segmentStart(height, manifest.SegmentSize))
+	if err != nil {
+		return nil, err
+	}
+	record, ok := segment.Get(height)
+	if !ok {
+		return nil, ErrNotArchived
+	}
+	return record, nil
+}
+
+func (s *Store) loadManifest() (Manifest, error) {
+	if s.manifest != nil {
+		return *s.manifest, nil
+	}
+	data, err := s.client.GetFileFromS3(append(s.folder, manifestFilename)...)
+	if IsNotFound(err) {
+		return Manifest{}, ErrNotArchived
+	} else if err != nil {
+		return Manifest{}, errors.Wrap(err, "archive.Store.loadManifest")
+	}
+
+	manifest := Manifest{}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return Manifest{}, errors.Wrap(err, "archive.Store.loadManifest")
+	}
+	if manifest.SegmentSize == 0 {
+		return Manifest{}, errors.New("archive.Store.loadManifest, segment size is zero")
+	}
+	s.manifest = &manifest
+	return manifest, nil
+}
+
+func (s *Store) saveManifest(manifest Manifest) error {
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		return errors.Wrap(err, "archive.Store.saveManifest")
+	}
+	if err := s.client.UploadFileToS3(data, append(s.folder, manifestFilename)...); err != nil {
+		return errors.Wrap(err, "archive.Store.saveManifest")
+	}
+	s.manifest = &manifest
+	return nil
+}
+
+func (s *Store) loadSegment(start uint64) (*Segment, error) {
+	if s.cached != nil && s.cached.Start == start {
+		return s.cached, nil
+	}
+	data, err := s.client.GetFileFromS3(s.segmentPath(start)...)
+	if IsNotFound(err) {
+		return nil, ErrNotArchived
+	} else if err != nil {
+		return nil, errors.Wrap(err, "archive.Store.loadSegment")
+	}
+	segment, err := DecodeSegment(data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "archive.Store.loadSegment(%d)", start)
+	}
+	s.cached = segment
+	return segment, nil
+}
+
+func (s *Store) saveSegment(codec Codec, start uint64, records map[uint64][]byte) error {
+	data, err := EncodeSegment(codec, start, records)
+	if err != nil {
+		return err
+	}
+	if err := s.client.UploadFileToS3(data, s.segmentPath(start)...); err != nil {
+		return errors.Wrap(err, "archive.Store.saveSegment")
+	}
+	if s.cached != nil && s.cached.Start == start {
+		s.cached = nil
+	}
+	return nil
+}
+
+func (s *Store) segmentPath(start uint64) []string {
+	return append(append([]string{}, s.folder...), fmt.Sprintf("%d.seg", start))
+}
+
+// Writer buffers records of consecutive heights and flushes each segment once
+// a height beyond it is written. Records already archived in a segment that is
+// reopened are kept, so a conversion can resume from any height.
+type Writer struct {
+	store    *Store
+	manifest Manifest
+
+	start   uint64
+	records map[uint64][]byte
+}
+
+// NewWriter fails when the folder was archived with a different segment size.
+func (s *Store) NewWriter(segmentSize uint64, codec Codec) (*Writer, error) {
+	if segmentSize == 0 {
+		return nil, errors.New("archive.NewWriter, segment size must be greater than 0")
+	}
+	if _, ok := codecIds[codec]; !ok {
+		return nil, errors.Errorf("archive.NewWriter, unsupported codec %q", codec)
+	}
+
+	manifest, err := s.Manifest()
+	if errors.Is(err, ErrNotArchived) {
+		manifest = Manifest{SegmentSize: segmentSize, Codec: codec}
+	} else if err != nil {
+		return nil, err
+	} else if manifest.SegmentSize != segmentSize {
+		return nil, errors.Errorf("archive.NewWriter, folder uses segment size %d, not %d", manifest.SegmentSize, segmentSize)
+	}
+	manifest.Codec = codec
+
+	return &Writer{store: s, manifest: manifest}, nil
+}
+
+// Put adds the record of height. Heights must be written in ascending order.
+func (w *Writer) Put(height uint64, record []byte) error {
+	start := segmentStart(height, w.manifest.SegmentSize)
+	if w.records != nil && start != w.start {
+		if start < w.start {
+			return errors.Errorf("archive.Writer.Put, height %d is before the current segment %d", height, w.start)
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+	}
+
+	if w.records == nil {
+		records, err := w.existingRecords(start)
+		if err != nil {
+			return err
+		}
+		w.start = start
+		w.records = records
+	}
+	w.records[height] = record
+	return nil
+}
+
+// Flush writes the buffered segment and advances the manifest.
+func (w *Writer) Flush() error {
+	if w.records == nil {
+		return nil
+	}
+
+	w.store.mu.Lock()
+	defer w.store.mu.Unlock()
+
+	if err := w.store.saveSegment(w.manifest.Codec, w.start, w.records); err != nil {
+		return err
+	}
+	manifest := w.manifest
+	for height := range w.records {
+		if height > manifest.LatestHeight {
+			manifest.LatestHeight = height
+		}
+	}
+	if err := w.store.saveManifest(manifest); err != nil {
+		return err
+	}
+	w.manifest = manifest
+	w.records = nil
+	return nil
+}
+
+func (w *Writer) existingRecords(start uint64) (map[uint64][]byte, error) {
+	w.store.mu.Lock()
+	defer w.store.mu.Unlock()
+
+	records := map[uint64][]byte{}
+	segment, err := w.store.loadSegment(start)
+	if errors.Is(err, ErrNotArchived) {
+		return records, nil
+	} else if err != nil {
+		return nil, err
+	}
+	for _, height := range segment.Heights() {
+		record, _ := segment.Get(height)
+		records[height] = record
+	}
+	return records, nil
+}
+
+func segmentStart(height uint64, segmentSize uint64) uint64 {
+	return height / segmentSize * segmentSize
+}
+
+// IsNotFound matches the local client and S3 missing-key errors.
+func IsNotFound(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := err.Error()
+	return strings.Contains(msg, "not found") || strings.Contains(msg, "NoSuchKey")
+}
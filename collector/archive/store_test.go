@@ -0,0 +1,154 @@
+package archive
+
+import (
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/dezswap/cosmwasm-etl/collector/datastore"
+	collectorrepo "github.com/dezswap/cosmwasm-etl/collector/repo"
+	"github.com/dezswap/cosmwasm-etl/parser"
+	"github.com/dezswap/cosmwasm-etl/parser/dex"
+	"github.com/dezswap/cosmwasm-etl/pkg/s3client"
+	"github.com/stretchr/testify/require"
+)
+
+func newLocalClient(t *testing.T) s3client.S3ClientInterface {
+	client, err := s3client.NewLocalClient(t.TempDir())
+	require.NoError(t, err)
+	return client
+}
+
+func TestWriter_ResumeKeepsArchivedRecords(t *testing.T) {
+	client := newLocalClient(t)
+	folder := GetArchiveFolderPath("test", datastore.BLOCK_SUFFIX)
+
+	writer, err := NewStore(client, folder...).NewWriter(10, CodecGzip)
+	require.NoError(t, err)
+	for height := uint64(5); height <= 12; height++ {
+		require.NoError(t, writer.Put(height, []byte(fmt.Sprint(height))))
+	}
+	require.NoError(t, writer.Flush())
+
+	reader := NewStore(client, folder...)
+	manifest, err := reader.Manifest()
+	require.NoError(t, err)
+	require.Equal(t, Manifest{SegmentSize: 10, Codec: CodecGzip, LatestHeight: 12}, manifest)
+	_, err = reader.Get(13)
+	require.ErrorIs(t, err, ErrNotArchived)
+
+	_, err = NewStore(client, folder...).NewWriter(20, CodecGzip)
+	require.Error(t, err, "segment size must not change")
+
+	writer, err = NewStore(client, folder...).NewWriter(10, CodecGzip)
+	require.NoError(t, err)
+	for height := uint64(13); height <= 21; height++ {
+		require.NoError(t, writer.Put(height, []byte(fmt.Sprint(height))))
+	}
+	require.Error(t, writer.Put(3, nil), "heights must ascend")
+	require.NoError(t, writer.Flush())
+
+	for height := uint64(5); height <= 21; height++ {
+		record, err := reader.Get(height)
+		require.NoError(t, err)
+		require.Equal(t, []byte(fmt.Sprint(height)), record)
+	}
+	_, err = reader.Get(4)
+	require.ErrorIs(t, err, ErrNotArchived)
+}
+
+func TestReadStore(t *testing.T) {
+	const chainId = "test"
+	client := newLocalClient(t)
+
+	block, err := os.ReadFile("../datastore/block_1000033.json")
+	require.NoError(t, err)
+	pairs := []byte(`{"pairs":{"terra1pair":{"lp_addr":"terra1lp"}}}`)
+
+	for kind, record := range map[string][]byte{datastore.BLOCK_SUFFIX: block, datastore.PAIR_SUFFIX: pairs} {
+		writer, err := NewStore(client, GetArchiveFolderPath(chainId, kind)...).NewWriter(100, CodecGzip)
+		require.NoError(t, err)
+		require.NoError(t, writer.Put(1000033, record))
+		require.NoError(t, writer.Flush())
+	}
+
+	base := &baseReadStoreMock{latest: 1000040}
+	store := NewReadStore(chainId, client, base)
+	height, err := store.GetLatestHeight()
+	require.NoError(t, err)
+	require.Equal(t, uint64(1000040), height, "heights above the manifest are read from base")
+
+	blockTxs, err := store.GetBlockByHeight(1000033)
+	require.NoError(t, err)
+	require.Equal(t, int64(1000033), blockTxs.BlockId)
+	require.NotEmpty(t, blockTxs.Txs)
+
+	poolInfos, err := store.GetPoolStatusOfAllPairsByHeight(1000033)
+	require.NoError(t, err)
+	require.Contains(t, poolInfos.Pairs, "terra1pair")
+
+	blockTxs, err = store.GetBlockByHeight(1000034)
+	require.NoError(t, err)
+	require.Equal(t, int64(1000034), blockTxs.BlockId)
+	_, err = store.GetPoolStatusOfAllPairsByHeight(1000034)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{1000034, 1000034}, base.calls)
+}
+
+type baseReadStoreMock struct {
+	latest uint64
+	calls  []uint64
+}
+
+func (m *baseReadStoreMock) GetLatestHeight() (uint64, error) {
+	return m.latest, nil
+}
+
+func (m *baseReadStoreMock) GetBlockByHeight(height uint64) (*datastore.BlockTxsDTO, error) {
+	m.calls = append(m.calls, height)
+	return &datastore.BlockTxsDTO{BlockId: int64(height)}, nil
+}
+
+func (m *baseReadStoreMock) GetPoolStatusOfAllPairsByHeight(height uint64) (*datastore.PoolInfoList, error) {
+	m.calls = append(m.calls, height)
+	return &datastore.PoolInfoList{}, nil
+}
+
+type baseRepoMock struct {
+	collectorrepo.Repository
+	calls []uint64
+}
+
+func (m *baseRepoMock) GetBlockTxs(_ string, height uint64) (parser.RawTxs, time.Time, error) {
+	m.calls = append(m.calls, height)
+	return parser.RawTxs{{Hash: "db"}}, time.Unix(int64(height), 0), nil
+}
+
+func TestRepository_FallsBackToBase(t *testing.T) {
+	client := newLocalClient(t)
+	blockTime := time.Unix(1700000000, 0).UTC()
+	record := []byte(fmt.Sprintf(`{"block_time":%q,"block_hash":"H","parent_hash":"P","txs":[{"hash":"archived"}]}`, blockTime.Format(time.RFC3339)))
+
+	writer, err := NewStore(client, GetArchiveFolderPath("test", COLLECTOR_BLOCK_SUFFIX)...).NewWriter(10, CodecGzip)
+	require.NoError(t, err)
+	require.NoError(t, writer.Put(7, record))
+	require.NoError(t, writer.Flush())
+
+	base := &baseRepoMock{}
+	repo := NewRepository(client, base)
+
+	txs, actualTime, err := repo.GetBlockTxs("test", 7)
+	require.NoError(t, err)
+	require.Equal(t, "archived", txs[0].Hash)
+	require.True(t, blockTime.Equal(actualTime))
+
+	header, err := repo.GetBlockHeader("test", 7)
+	require.NoError(t, err)
+	require.Equal(t, dex.BlockHeader{Height: 7, Hash: "H", ParentHash: "P"}, header)
+
+	txs, _, err = repo.GetBlockTxs("test", 8)
+	require.NoError(t, err)
+	require.Equal(t, "db", txs[0].Hash)
+	require.Equal(t, []uint64{8}, base.calls)
+}
@@ -1,7 +1,9 @@
 package collector
 
 import (
+	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	collectorrepo "github.com/dezswap/cosmwasm-etl/collector/repo"
@@ -16,10 +18,34 @@ type heightCollector interface {
 	CollectHeight(height uint64) error
 }
 
+// windowedHeightCollector splits CollectHeight into a fetch step that may run
+// concurrently for several heights and a save step that the runner calls
+// strictly in height order.
+type windowedHeightCollector interface {
+	heightCollector
+	FetchHeight(height uint64) (collectedHeight, error)
+	SaveCollectedHeight(collected collectedHeight) error
+}
+
+// sourceHeightWaiter lets a collector wake the runner as soon as the source
+// reports a new height instead of sleeping a full poll interval.
+type sourceHeightWaiter interface {
+	WaitSourceHeight(after uint64, timeout time.Duration)
+}
+
+// ParserRewinder removes the parser rows above a fork height, so the parser
+// parses the re-collected blocks again. The parser rows may live in another
+// database than the collector rows.
+type ParserRewinder interface {
+	RewindAbove(height uint64) error
+}
+
 type heightCollectorConfig struct {
 	StartHeight  uint64
 	UntilHeight  uint64
 	PollInterval time.Duration
+	Concurrency  uint
+	WindowSize   uint
 }
 
 // DoCollect persists normalized parser source data into the collector DB.
@@ -27,18 +53,24 @@ type heightCollectorConfig struct {
 // It consumes any dex SourceDataStore implementation and stores per-height txs,
 // optional pool snapshots, and synced height in PostgreSQL. That keeps the loop
 // reusable for future DEX apps as long as they expose the same parser source
-// interface.
-func DoCollect(repo collectorrepo.Repository, source dex.SourceDataStore, collectorConfig configs.CollectorConfig, logger logging.Logger) error {
+// interface. A nil txFilter stores every tx of a block, and a nil
+// parserRewinder leaves the parser rows alone on a fork.
+func DoCollect(repo collectorrepo.Repository, source dex.SourceDataStore, txFilter TxFilter, parserRewinder ParserRewinder, collectorConfig configs.CollectorConfig, logger logging.Logger) error {
 	return collectHeights(&sourceHeightCollector{
 		repo:                 repo,
 		source:               source,
 		chainID:              collectorConfig.ChainId,
 		startHeight:          collectorConfig.StartHeight,
 		poolSnapshotInterval: collectorConfig.PoolSnapshotInterval,
+		rollbackOnFork:       collectorConfig.RollbackOnFork,
+		parserRewinder:       parserRewinder,
+		txFilter:             txFilter,
 	}, heightCollectorConfig{
 		StartHeight:  collectorConfig.StartHeight,
 		UntilHeight:  collectorConfig.UntilHeight,
 		PollInterval: time.Duration(collectorConfig.PollIntervalSec) * time.Second,
+		Concurrency:  collectorConfig.Concurrency,
+		WindowSize:   collectorConfig.WindowSize,
 	}, logger)
 }
 
@@ -71,7 +103,7 @@ func collectHeights(collector heightCollector, config heightCollectorConfig, log
 				return nil
 			}
 			logger.Infof("no new collector source height: local=%d source=%d", localHeight, srcHeight)
-			time.Sleep(pollInterval)
+			waitSourceHeight(collector, srcHeight, pollInterval)
 			continue
 		}
 
@@ -81,17 +113,127 @@ func collectHeights(collector heightCollector, config heightCollectorConfig, log
 		}
 		if nextHeight > targetHeight {
 			logger.Infof("no collectible height yet: local=%d source=%d start=%d", localHeight, srcHeight, startHeight)
-			time.Sleep(pollInterval)
+			waitSourceHeight(collector, srcHeight, pollInterval)
 			continue
 		}
 
-		for height := nextHeight; height <= targetHeight; height++ {
+		if err := collectRange(collector, nextHeight, targetHeight, config, logger); err != nil {
+			if errors.Is(err, errChainRewound) {
+				logger.Warnf("%s", err)
+				continue
+			}
+			return err
+		}
+	}
+}
+
+// collectRange collects [from, to] one height at a time unless the collector
+// supports split fetch/save and more than one worker is configured.
+func collectRange(collector heightCollector, from, to uint64, config heightCollectorConfig, logger logging.Logger) error {
+	windowed, ok := collector.(windowedHeightCollector)
+	if !ok || config.Concurrency <= 1 {
+		for height := from; height <= to; height++ {
 			if err := collector.CollectHeight(height); err != nil {
 				return err
 			}
 			logger.Infof("collected source height %d", height)
 		}
+		return nil
+	}
+
+	windowSize := config.WindowSize
+	if windowSize < config.Concurrency {
+		windowSize = config.Concurrency
+	}
+	return collectWindowed(windowed, from, to, config.Concurrency, windowSize, logger)
+}
+
+type fetchResult struct {
+	height    uint64
+	collected collectedHeight
+	err       error
+}
+
+// collectWindowed fetches heights with a pool of workers while keeping at most
+// windowSize heights ahead of the commit cursor. Fetched heights are buffered
+// and saved in ascending order, so the synced height never skips a gap.
+func collectWindowed(collector windowedHeightCollector, from, to uint64, concurrency, windowSize uint, logger logging.Logger) error {
+	heights := make(chan uint64)
+	results := make(chan fetchResult, windowSize)
+	slots := make(chan struct{}, windowSize)
+	done := make(chan struct{})
+	wg := sync.WaitGroup{}
+
+	defer func() {
+		close(done)
+		wg.Wait()
+	}()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer close(heights)
+		for height := from; height <= to; height++ {
+			select {
+			case slots <- struct{}{}:
+			case <-done:
+				return
+			}
+			select {
+			case heights <- height:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	for i := uint(0); i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for height := range heights {
+				collected, err := collector.FetchHeight(height)
+				select {
+				case results <- fetchResult{height: height, collected: collected, err: err}:
+				case <-done:
+					return
+				}
+			}
+		}()
+	}
+
+	pending := make(map[uint64]collectedHeight, windowSize)
+	for next := from; next <= to; {
+		result := <-results
+		if result.err != nil {
+			return result.err
+		}
+		pending[result.height] = result.collected
+
+		for {
+			collected, ok := pending[next]
+			if !ok || next > to {
+				break
+			}
+			if err := collector.SaveCollectedHeight(collected); err != nil {
+				return err
+			}
+			delete(pending, next)
+			<-slots
+			logger.Infof("collected source height %d", next)
+			next++
+		}
+	}
+
+	return nil
+}
+
+func waitSourceHeight(collector heightCollector, after uint64, pollInterval time.Duration) {
+	if waiter, ok := collector.(sourceHeightWaiter); ok {
+		waiter.WaitSourceHeight(after, pollInterval)
+		return
 	}
+	time.Sleep(pollInterval)
 }
 
 func boundedTargetHeight(sourceHeight, untilHeight uint64) uint64 {
@@ -2,6 +2,7 @@ package collector
 
 import (
 	"errors"
+	"sync"
 	"testing"
 	"time"
 
@@ -18,12 +19,18 @@ type sourceRepoMock struct {
 	syncedErr    error
 	saved        []savedHeight
 	saveErr      error
+	saveErrs     map[uint64]error
+	headers      map[uint64]dex.BlockHeader
+	rolledBackTo []uint64
+	snapshots    map[uint64][]dex.PoolInfo
 }
 
 type savedHeight struct {
 	chainID          string
 	height           uint64
+	header           dex.BlockHeader
 	txs              parser.RawTxs
+	filterVersion    uint
 	poolInfos        []dex.PoolInfo
 	savePoolSnapshot bool
 }
@@ -43,14 +50,87 @@ func (m *sourceRepoMock) GetPoolInfos(string, uint64) ([]dex.PoolInfo, error) {
 	return nil, nil
 }
 
-func (m *sourceRepoMock) SaveHeight(chainID string, height uint64, _ time.Time, txs parser.RawTxs, poolInfos []dex.PoolInfo, savePoolSnapshot bool) error {
+func (m *sourceRepoMock) GetBlockHeader(_ string, height uint64) (dex.BlockHeader, error) {
+	header, ok := m.headers[height]
+	if !ok {
+		return dex.BlockHeader{}, repo.ErrNotFound
+	}
+	return header, nil
+}
+
+func (m *sourceRepoMock) GetBlockHeights(_ string, from, to uint64) ([]uint64, error) {
+	heights := []uint64{}
+	for _, saved := range m.saved {
+		if saved.height >= from && saved.height <= to {
+			heights = append(heights, saved.height)
+		}
+	}
+	return heights, nil
+}
+
+func (m *sourceRepoMock) GetPoolSnapshotHeights(_ string, from, to uint64) ([]uint64, error) {
+	heights := []uint64{}
+	for _, saved := range m.saved {
+		if saved.savePoolSnapshot && saved.height >= from && saved.height <= to {
+			heights = append(heights, saved.height)
+		}
+	}
+	return heights, nil
+}
+
+func (m *sourceRepoMock) GetFilterMismatchHeights(_ string, from, to uint64, filterVersion uint) ([]uint64, error) {
+	heights := []uint64{}
+	for _, saved := range m.saved {
+		if saved.filterVersion != filterVersion && saved.height >= from && saved.height <= to {
+			heights = append(heights, saved.height)
+		}
+	}
+	return heights, nil
+}
+
+func (m *sourceRepoMock) SavePoolSnapshot(_ string, height uint64, poolInfos []dex.PoolInfo) error {
+	if m.snapshots == nil {
+		m.snapshots = map[uint64][]dex.PoolInfo{}
+	}
+	m.snapshots[height] = poolInfos
+	return nil
+}
+
+func (m *sourceRepoMock) Rollback(_ string, height uint64) error {
+	m.rolledBackTo = append(m.rolledBackTo, height)
+	for h := range m.headers {
+		if h > height {
+			delete(m.headers, h)
+		}
+	}
+	kept := m.saved[:0]
+	for _, saved := range m.saved {
+		if saved.height <= height {
+			kept = append(kept, saved)
+		}
+	}
+	m.saved = kept
+	m.syncedHeight = height
+	return nil
+}
+
+func (m *sourceRepoMock) SaveHeight(chainID string, height uint64, _ time.Time, header dex.BlockHeader, txs parser.RawTxs, filterVersion uint, poolInfos []dex.PoolInfo, savePoolSnapshot bool) error {
 	if m.saveErr != nil {
 		return m.saveErr
 	}
+	if err, ok := m.saveErrs[height]; ok {
+		delete(m.saveErrs, height)
+		return err
+	}
+	if m.headers != nil {
+		m.headers[height] = header
+	}
 	m.saved = append(m.saved, savedHeight{
 		chainID:          chainID,
 		height:           height,
+		header:           header,
 		txs:              txs,
+		filterVersion:    filterVersion,
 		poolInfos:        poolInfos,
 		savePoolSnapshot: savePoolSnapshot,
 	})
@@ -101,6 +181,8 @@ func TestDoCollectSourceCollectsFromStartHeightToUntilHeight(t *testing.T) {
 	err := DoCollect(
 		repo,
 		source,
+		nil,
+		nil,
 		configs.CollectorConfig{ChainId: "chain", StartHeight: 5, UntilHeight: 6, PoolSnapshotInterval: 2},
 		logging.Discard,
 	)
@@ -132,6 +214,8 @@ func TestDoCollectSourceUsesConfiguredChainAndSnapshotInterval(t *testing.T) {
 	err := DoCollect(
 		repo,
 		source,
+		nil,
+		nil,
 		configs.CollectorConfig{ChainId: "chain", StartHeight: 1, UntilHeight: 3, PoolSnapshotInterval: 2},
 		logging.Discard,
 	)
@@ -152,6 +236,8 @@ func TestDoCollectSourceReturnsSourceTxError(t *testing.T) {
 	err := DoCollect(
 		repo,
 		source,
+		nil,
+		nil,
 		configs.CollectorConfig{ChainId: "chain", StartHeight: 1, UntilHeight: 1},
 		logging.Discard,
 	)
@@ -172,6 +258,8 @@ func TestDoCollectSourceReturnsPoolInfoError(t *testing.T) {
 	err := DoCollect(
 		repo,
 		source,
+		nil,
+		nil,
 		configs.CollectorConfig{ChainId: "chain", StartHeight: 1, UntilHeight: 1, PoolSnapshotInterval: 1},
 		logging.Discard,
 	)
@@ -187,6 +275,8 @@ func TestDoCollectReturnsRepositoryHeightError(t *testing.T) {
 	err := DoCollect(
 		repo,
 		&sourceStoreMock{syncedHeight: 1},
+		nil,
+		nil,
 		configs.CollectorConfig{ChainId: "chain", StartHeight: 1, UntilHeight: 1},
 		logging.Discard,
 	)
@@ -202,6 +292,8 @@ func TestDoCollectReturnsSourceHeightError(t *testing.T) {
 	err := DoCollect(
 		repo,
 		&sourceStoreMock{syncedErr: expected},
+		nil,
+		nil,
 		configs.CollectorConfig{ChainId: "chain", StartHeight: 1, UntilHeight: 1},
 		logging.Discard,
 	)
@@ -221,6 +313,8 @@ func TestDoCollectReturnsSaveHeightError(t *testing.T) {
 	err := DoCollect(
 		repo,
 		source,
+		nil,
+		nil,
 		configs.CollectorConfig{ChainId: "chain", StartHeight: 1, UntilHeight: 1},
 		logging.Discard,
 	)
@@ -376,6 +470,132 @@ func TestCollectHeightsReturnsCollectError(t *testing.T) {
 	require.Empty(t, collector.collected)
 }
 
+type windowedCollectorMock struct {
+	mu          sync.Mutex
+	delays      map[uint64]time.Duration
+	fetchErrs   map[uint64]error
+	inFlight    int
+	maxInFlight int
+	saved       []uint64
+	localHeight uint64
+}
+
+func (m *windowedCollectorMock) LocalHeight() (uint64, error) {
+	return m.localHeight, nil
+}
+
+func (m *windowedCollectorMock) SourceHeight() (uint64, error) {
+	return 100, nil
+}
+
+func (m *windowedCollectorMock) CollectHeight(height uint64) error {
+	collected, err := m.FetchHeight(height)
+	if err != nil {
+		return err
+	}
+	return m.SaveCollectedHeight(collected)
+}
+
+func (m *windowedCollectorMock) FetchHeight(height uint64) (collectedHeight, error) {
+	m.mu.Lock()
+	m.inFlight++
+	if m.inFlight > m.maxInFlight {
+		m.maxInFlight = m.inFlight
+	}
+	m.mu.Unlock()
+
+	time.Sleep(m.delays[height])
+
+	m.mu.Lock()
+	m.inFlight--
+	m.mu.Unlock()
+
+	if err := m.fetchErrs[height]; err != nil {
+		return collectedHeight{}, err
+	}
+	return collectedHeight{height: height}, nil
+}
+
+func (m *windowedCollectorMock) SaveCollectedHeight(collected collectedHeight) error {
+	m.saved = append(m.saved, collected.height)
+	m.localHeight = collected.height
+	return nil
+}
+
+func TestCollectHeightsSavesConcurrentFetchesInOrder(t *testing.T) {
+	collector := &windowedCollectorMock{
+		delays: map[uint64]time.Duration{
+			1: 30 * time.Millisecond,
+			2: 10 * time.Millisecond,
+			4: 20 * time.Millisecond,
+		},
+	}
+
+	err := collectHeights(collector, heightCollectorConfig{
+		StartHeight: 1,
+		UntilHeight: 8,
+		Concurrency: 3,
+		WindowSize:  4,
+	}, logging.Discard)
+
+	require.NoError(t, err)
+	require.Equal(t, []uint64{1, 2, 3, 4, 5, 6, 7, 8}, collector.saved)
+	require.LessOrEqual(t, collector.maxInFlight, 3)
+	require.Greater(t, collector.maxInFlight, 1)
+}
+
+func TestCollectHeightsWindowedReturnsFetchErrorWithoutSavingGap(t *testing.T) {
+	expected := errors.New("fetch failed")
+	collector := &windowedCollectorMock{
+		fetchErrs: map[uint64]error{3: expected},
+	}
+
+	err := collectHeights(collector, heightCollectorConfig{
+		StartHeight: 1,
+		UntilHeight: 6,
+		Concurrency: 2,
+	}, logging.Discard)
+
+	require.ErrorIs(t, err, expected)
+	for i, height := range collector.saved {
+		require.Equal(t, uint64(i+1), height)
+	}
+	require.NotContains(t, collector.saved, uint64(3))
+}
+
+func TestDoCollectSourceCollectsConcurrently(t *testing.T) {
+	repo := &sourceRepoMock{syncedErr: repo.ErrNotFound}
+	source := &sourceStoreMock{
+		syncedHeight: 6,
+		txs: map[uint64]parser.RawTxs{
+			1: {{Hash: "tx1"}},
+			4: {{Hash: "tx4"}},
+		},
+		poolInfos: map[uint64][]dex.PoolInfo{
+			3: {{ContractAddr: "pair3"}},
+			6: {{ContractAddr: "pair6"}},
+		},
+	}
+
+	err := DoCollect(
+		repo,
+		source,
+		nil,
+		nil,
+		configs.CollectorConfig{ChainId: "chain", StartHeight: 1, UntilHeight: 6, PoolSnapshotInterval: 3, Concurrency: 3, WindowSize: 4},
+		logging.Discard,
+	)
+
+	require.NoError(t, err)
+	require.Len(t, repo.saved, 6)
+	for i, saved := range repo.saved {
+		require.Equal(t, uint64(i+1), saved.height)
+	}
+	require.Equal(t, parser.RawTxs{{Hash: "tx4"}}, repo.saved[3].txs)
+	require.True(t, repo.saved[2].savePoolSnapshot)
+	require.Equal(t, []dex.PoolInfo{{ContractAddr: "pair6"}}, repo.saved[5].poolInfos)
+}
+
 func TestBoundedTargetHeight(t *testing.T) {
 	require.Equal(t, uint64(7), boundedTargetHeight(10, 7))
 	require.Equal(t, uint64(10), boundedTargetHeight(10, 0))
@@ -387,3 +607,39 @@ func TestReachedUntilHeight(t *testing.T) {
 	require.False(t, reachedUntilHeight(6, 7))
 	require.False(t, reachedUntilHeight(7, 0))
 }
+
+func TestDoCollectRecollectsAfterForkRollback(t *testing.T) {
+	repository := &sourceRepoMock{
+		syncedHeight: 3,
+		headers: map[uint64]dex.BlockHeader{
+			2: {Height: 2, Hash: "h2"},
+			3: {Height: 3, Hash: "stale3"},
+		},
+		saveErrs: map[uint64]error{4: repo.ErrBlockHashMismatch},
+	}
+	source := &headerSourceMock{
+		sourceStoreMock: sourceStoreMock{syncedHeight: 4},
+		headers: map[uint64]dex.BlockHeader{
+			2: {Height: 2, Hash: "h2"},
+			3: {Height: 3, Hash: "h3", ParentHash: "h2"},
+			4: {Height: 4, Hash: "h4", ParentHash: "h3"},
+		},
+	}
+
+	parserRewinder := &parserRewinderMock{}
+
+	err := DoCollect(
+		repository,
+		source,
+		nil,
+		parserRewinder,
+		configs.CollectorConfig{ChainId: "chain", StartHeight: 1, UntilHeight: 4, RollbackOnFork: true},
+		logging.Discard,
+	)
+
+	require.NoError(t, err)
+	require.Equal(t, []uint64{2}, parserRewinder.rewoundAbove)
+	require.Equal(t, []uint64{2}, repository.rolledBackTo)
+	require.Equal(t, "h3", repository.headers[3].Hash)
+	require.Equal(t, "h4", repository.headers[4].Hash)
+}
@@ -3,12 +3,15 @@ package datastore
 import (
 	"encoding/json"
 	"fmt"
+	"os"
 	"testing"
 
 	"github.com/dezswap/cosmwasm-etl/pkg/faker"
+	"github.com/dezswap/cosmwasm-etl/pkg/s3client"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -110,3 +113,28 @@ func Test_readStore(t *testing.T) {
 	faker.CustomGenerator()
 	suite.Run(t, new(readStoreTestSuite))
 }
+
+func Test_ReadStore_LocalDir(t *testing.T) {
+	const chainId = "test"
+	root := t.TempDir()
+	s3Client, err := s3client.NewLocalClient(root)
+	require.NoError(t, err)
+
+	data, err := os.ReadFile("block_1000033.json")
+	require.NoError(t, err)
+	require.NoError(t, s3Client.UploadBlockBinary(1000033, data, GetBlockFolderPath(chainId)...))
+	require.NoError(t, s3Client.ChangeLatestBlock(1000033, GetBlockFolderPath(chainId)...))
+
+	store := NewReadStore(chainId, s3Client)
+	height, err := store.GetLatestHeight()
+	require.NoError(t, err)
+	require.Equal(t, uint64(1000033), height)
+
+	block, err := store.GetBlockByHeight(1000033)
+	require.NoError(t, err)
+	require.Equal(t, int64(1000033), block.BlockId)
+	require.NotEmpty(t, block.Txs)
+
+	_, err = store.GetPoolStatusOfAllPairsByHeight(1000033)
+	require.Error(t, err)
+}
@@ -0,0 +1,133 @@
+package collector
+
+import (
+	"sort"
+
+	collectorrepo "github.com/dezswap/cosmwasm-etl/collector/repo"
+	"github.com/dezswap/cosmwasm-etl/configs"
+	"github.com/dezswap/cosmwasm-etl/parser/dex"
+	"github.com/dezswap/cosmwasm-etl/pkg/logging"
+)
+
+// gapScanChunk bounds the number of heights listed per repository query.
+const gapScanChunk = uint64(100_000)
+
+// HeightGaps lists the heights of a scanned range that have no collector_blocks
+// row, and the scheduled snapshot heights that have no collector_pool_snapshots row.
+type HeightGaps struct {
+	ChainID              string   `json:"chain_id"`
+	From                 uint64   `json:"from_height"`
+	To                   uint64   `json:"to_height"`
+	PoolSnapshotInterval uint     `json:"pool_snapshot_interval"`
+	MissingBlocks        []uint64 `json:"missing_blocks"`
+	MissingPoolSnapshots []uint64 `json:"missing_pool_snapshots"`
+	// FilterVersion is the tx filter version expected of stored blocks, and
+	// FilterMismatches the stored heights saved with another version.
+	FilterVersion    uint     `json:"filter_version"`
+	FilterMismatches []uint64 `json:"filter_mismatches"`
+}
+
+// Heights returns every height that has to be collected again, in ascending order.
+func (g HeightGaps) Heights() []uint64 {
+	seen := map[uint64]bool{}
+	heights := []uint64{}
+	for _, list := range [][]uint64{g.MissingBlocks, g.MissingPoolSnapshots, g.FilterMismatches} {
+		for _, height := range list {
+			if !seen[height] {
+				seen[height] = true
+				heights = append(heights, height)
+			}
+		}
+	}
+	sort.Slice(heights, func(i, j int) bool { return heights[i] < heights[j] })
+	return heights
+}
+
+// FindGaps scans [from, to] for heights missing from the collector tables and
+// blocks stored with a filter version other than filterVersion. Snapshot
+// heights are the multiples of poolSnapshotInterval, and none are expected
+// when the interval is 0.
+func FindGaps(repo collectorrepo.Repository, chainID string, from, to uint64, poolSnapshotInterval uint, filterVersion uint) (HeightGaps, error) {
+	gaps := HeightGaps{
+		ChainID:              chainID,
+		From:                 from,
+		To:                   to,
+		PoolSnapshotInterval: poolSnapshotInterval,
+		MissingBlocks:        []uint64{},
+		MissingPoolSnapshots: []uint64{},
+		FilterVersion:        filterVersion,
+		FilterMismatches:     []uint64{},
+	}
+
+	for chunkFrom := from; chunkFrom <= to; chunkFrom += gapScanChunk {
+		chunkTo := chunkFrom + gapScanChunk - 1
+		if chunkTo > to || chunkTo < chunkFrom {
+			chunkTo = to
+		}
+
+		blocks, err := repo.GetBlockHeights(chainID, chunkFrom, chunkTo)
+		if err != nil {
+			return gaps, err
+		}
+		gaps.MissingBlocks = append(gaps.MissingBlocks, missingHeights(blocks, chunkFrom, chunkTo, 1)...)
+
+		mismatches, err := repo.GetFilterMismatchHeights(chainID, chunkFrom, chunkTo, filterVersion)
+		if err != nil {
+			return gaps, err
+		}
+		gaps.FilterMismatches = append(gaps.FilterMismatches, mismatches...)
+
+		if poolSnapshotInterval > 0 {
+			snapshots, err := repo.GetPoolSnapshotHeights(chainID, chunkFrom, chunkTo)
+			if err != nil {
+				return gaps, err
+			}
+			gaps.MissingPoolSnapshots = append(gaps.MissingPoolSnapshots, missingHeights(snapshots, chunkFrom, chunkTo, uint64(poolSnapshotInterval))...)
+		}
+
+		if chunkTo == to {
+			break
+		}
+	}
+	return gaps, nil
+}
+
+// BackfillGaps collects the heights of gaps again through the regular
+// per-height collector, filtering txs with txFilter when it is not nil. Fork
+// rollback stays disabled since backfilled heights lie below the synced height.
+func BackfillGaps(repo collectorrepo.Repository, source dex.SourceDataStore, txFilter TxFilter, collectorConfig configs.CollectorConfig, gaps HeightGaps, logger logging.Logger) error {
+	collector := &sourceHeightCollector{
+		repo:                 repo,
+		source:               source,
+		chainID:              collectorConfig.ChainId,
+		startHeight:          collectorConfig.StartHeight,
+		poolSnapshotInterval: gaps.PoolSnapshotInterval,
+		txFilter:             txFilter,
+	}
+
+	heights := gaps.Heights()
+	for idx, height := range heights {
+		if err := collector.CollectHeight(height); err != nil {
+			return err
+		}
+		logger.Infof("backfilled collector height %d (%d/%d)", height, idx+1, len(heights))
+	}
+	return nil
+}
+
+// missingHeights returns the multiples of step in [from, to] absent from the
+// ascending stored heights.
+func missingHeights(stored []uint64, from, to uint64, step uint64) []uint64 {
+	missing := []uint64{}
+	first := (from + step - 1) / step * step
+	idx := 0
+	for height := first; height <= to && height >= first; height += step {
+		for idx < len(stored) && stored[idx] < height {
+			idx++
+		}
+		if idx >= len(stored) || stored[idx] != height {
+			missing = append(missing, height)
+		}
+	}
+	return missing
+}
@@ -0,0 +1,80 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/dezswap/cosmwasm-etl/configs"
+	"github.com/dezswap/cosmwasm-etl/parser"
+	"github.com/dezswap/cosmwasm-etl/parser/dex"
+	"github.com/dezswap/cosmwasm-etl/pkg/logging"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindGapsReportsMissingBlocksAndSnapshots(t *testing.T) {
+	repo := &sourceRepoMock{saved: []savedHeight{
+		{height: 1}, {height: 2, savePoolSnapshot: true}, {height: 4, savePoolSnapshot: true}, {height: 5}, {height: 7},
+	}}
+
+	gaps, err := FindGaps(repo, "chain", 1, 8, 2, UnfilteredVersion)
+
+	require.NoError(t, err)
+	require.Equal(t, []uint64{3, 6, 8}, gaps.MissingBlocks)
+	require.Equal(t, []uint64{6, 8}, gaps.MissingPoolSnapshots)
+	require.Equal(t, []uint64{3, 6, 8}, gaps.Heights())
+}
+
+func TestFindGapsWithoutSnapshotInterval(t *testing.T) {
+	repo := &sourceRepoMock{saved: []savedHeight{{height: 10}, {height: 12}}}
+
+	gaps, err := FindGaps(repo, "chain", 10, 12, 0, UnfilteredVersion)
+
+	require.NoError(t, err)
+	require.Equal(t, []uint64{11}, gaps.MissingBlocks)
+	require.Empty(t, gaps.MissingPoolSnapshots)
+}
+
+func TestFindGapsReportsFilterMismatches(t *testing.T) {
+	repo := &sourceRepoMock{saved: []savedHeight{
+		{height: 1, filterVersion: AddressFilterVersion}, {height: 2}, {height: 3, filterVersion: AddressFilterVersion},
+	}}
+
+	gaps, err := FindGaps(repo, "chain", 1, 4, 0, AddressFilterVersion)
+
+	require.NoError(t, err)
+	require.Equal(t, []uint64{4}, gaps.MissingBlocks)
+	require.Equal(t, []uint64{2}, gaps.FilterMismatches)
+	require.Equal(t, []uint64{2, 4}, gaps.Heights())
+}
+
+func TestMissingHeightsAlignsToStep(t *testing.T) {
+	require.Equal(t, []uint64{1000, 3000}, missingHeights([]uint64{2000}, 999, 3500, 1000))
+	require.Empty(t, missingHeights(nil, 1001, 1999, 1000))
+}
+
+func TestBackfillGapsCollectsOnlyMissingHeights(t *testing.T) {
+	repo := &sourceRepoMock{syncedHeight: 8}
+	source := &sourceStoreMock{
+		txs: map[uint64]parser.RawTxs{
+			3: {{Hash: "tx3"}},
+			6: {{Hash: "tx6"}},
+		},
+		poolInfos: map[uint64][]dex.PoolInfo{
+			6: {{ContractAddr: "pair6"}},
+		},
+	}
+	gaps := HeightGaps{
+		PoolSnapshotInterval: 2,
+		MissingBlocks:        []uint64{3},
+		MissingPoolSnapshots: []uint64{6},
+	}
+
+	err := BackfillGaps(repo, source, nil, configs.CollectorConfig{ChainId: "chain"}, gaps, logging.Discard)
+
+	require.NoError(t, err)
+	require.Len(t, repo.saved, 2)
+	require.Equal(t, uint64(3), repo.saved[0].height)
+	require.False(t, repo.saved[0].savePoolSnapshot)
+	require.Equal(t, uint64(6), repo.saved[1].height)
+	require.True(t, repo.saved[1].savePoolSnapshot)
+	require.Equal(t, []dex.PoolInfo{{ContractAddr: "pair6"}}, repo.saved[1].poolInfos)
+}
@@ -2,20 +2,32 @@ package collector
 
 import (
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/dezswap/cosmwasm-etl/collector/repo"
+	"github.com/dezswap/cosmwasm-etl/parser"
 	"github.com/dezswap/cosmwasm-etl/parser/dex"
 )
 
+// errChainRewound tells the runner that stored heights were rolled back to a
+// fork point and the loop must restart from the new local height.
+var errChainRewound = errors.New("collector rewound to fork point")
+
 type sourceHeightCollector struct {
 	repo                 repo.Repository
 	source               dex.SourceDataStore
 	chainID              string
 	startHeight          uint64
 	poolSnapshotInterval uint
+	rollbackOnFork       bool
+	parserRewinder       ParserRewinder
+	txFilter             TxFilter
 }
 
+var _ windowedHeightCollector = (*sourceHeightCollector)(nil)
+var _ sourceHeightWaiter = (*sourceHeightCollector)(nil)
+
 func (c *sourceHeightCollector) LocalHeight() (uint64, error) {
 	localHeight, err := c.repo.GetSyncedHeight(c.chainID)
 	if err == nil {
@@ -34,11 +46,41 @@ func (c *sourceHeightCollector) SourceHeight() (uint64, error) {
 	return c.source.GetSourceSyncedHeight()
 }
 
+// collectedHeight is the source data of one height fetched ahead of saving.
+type collectedHeight struct {
+	height           uint64
+	blockTime        time.Time
+	header           dex.BlockHeader
+	txs              parser.RawTxs
+	poolInfos        []dex.PoolInfo
+	savePoolSnapshot bool
+}
+
+// WaitSourceHeight returns as soon as a subscribed source reports a height
+// above after, and falls back to sleeping timeout for polling-only sources.
+func (c *sourceHeightCollector) WaitSourceHeight(after uint64, timeout time.Duration) {
+	if notifier, ok := c.source.(dex.HeightNotifier); ok {
+		notifier.WaitForHeight(after, timeout)
+		return
+	}
+	time.Sleep(timeout)
+}
+
 func (c *sourceHeightCollector) CollectHeight(height uint64) error {
-	txs, err := c.source.GetSourceTxs(height)
+	collected, err := c.FetchHeight(height)
 	if err != nil {
 		return err
 	}
+	return c.SaveCollectedHeight(collected)
+}
+
+// FetchHeight reads block txs and the scheduled pool snapshot of a height
+// without touching the repository, so it is safe to call concurrently.
+func (c *sourceHeightCollector) FetchHeight(height uint64) (collectedHeight, error) {
+	header, txs, err := c.fetchBlock(height)
+	if err != nil {
+		return collectedHeight{}, err
+	}
 
 	blockTime := time.Time{}
 	if len(txs) > 0 {
@@ -50,9 +92,110 @@ func (c *sourceHeightCollector) CollectHeight(height uint64) error {
 	if savePoolSnapshot {
 		poolInfos, err = c.source.GetPoolInfos(height)
 		if err != nil {
+			return collectedHeight{}, err
+		}
+	}
+
+	return collectedHeight{
+		height:           height,
+		blockTime:        blockTime,
+		header:           header,
+		txs:              txs,
+		poolInfos:        poolInfos,
+		savePoolSnapshot: savePoolSnapshot,
+	}, nil
+}
+
+// fetchBlock reads the header and txs of height with one block request when
+// the source is a dex.BlockSource. Sources without block headers are
+// collected without hashes.
+func (c *sourceHeightCollector) fetchBlock(height uint64) (dex.BlockHeader, parser.RawTxs, error) {
+	if blocks, ok := c.source.(dex.BlockSource); ok {
+		return blocks.GetBlock(height)
+	}
+	txs, err := c.source.GetSourceTxs(height)
+	if err != nil {
+		return dex.BlockHeader{}, nil, err
+	}
+	header := dex.BlockHeader{Height: height}
+	if headers, ok := c.source.(dex.BlockHeaderSource); ok {
+		if header, err = headers.GetBlockHeader(height); err != nil {
+			return dex.BlockHeader{}, nil, err
+		}
+	}
+	return header, txs, nil
+}
+
+// SaveCollectedHeight applies the tx filter here rather than in FetchHeight
+// because the filter learns new pairs from create_pair txs in height order.
+func (c *sourceHeightCollector) SaveCollectedHeight(collected collectedHeight) error {
+	txs, filterVersion := collected.txs, UnfilteredVersion
+	if c.txFilter != nil {
+		var err error
+		if txs, err = c.txFilter.Filter(collected.height, collected.txs); err != nil {
 			return err
 		}
+		filterVersion = c.txFilter.Version()
 	}
 
-	return c.repo.SaveHeight(c.chainID, height, blockTime, txs, poolInfos, savePoolSnapshot)
+	err := c.repo.SaveHeight(c.chainID, collected.height, collected.blockTime, collected.header, txs, filterVersion, collected.poolInfos, collected.savePoolSnapshot)
+	if !errors.Is(err, repo.ErrBlockHashMismatch) || !c.rollbackOnFork {
+		return err
+	}
+
+	forkHeight, findErr := c.findForkHeight(collected.height)
+	if findErr != nil {
+		return findErr
+	}
+	if forkHeight+1 >= collected.height {
+		// the stored parent matches the source, so the source itself disagrees
+		// with its own previous block; rewinding would not make progress.
+		return err
+	}
+	// the parser rows go first, so a failed rewind leaves the fork in the
+	// collector rows to be found again on the next attempt
+	if c.parserRewinder != nil {
+		if err := c.parserRewinder.RewindAbove(forkHeight); err != nil {
+			return fmt.Errorf("rewind parser rows above %d: %w", forkHeight, err)
+		}
+	}
+	if err := c.repo.Rollback(c.chainID, forkHeight); err != nil {
+		return err
+	}
+	return fmt.Errorf("%w: fork at height %d, rolled back to %d", errChainRewound, collected.height, forkHeight)
+}
+
+// findForkHeight walks stored blocks down from height - 1 and returns the
+// highest height whose stored hash still matches the source.
+func (c *sourceHeightCollector) findForkHeight(height uint64) (uint64, error) {
+	headers, ok := c.source.(dex.BlockHeaderSource)
+	if !ok {
+		return 0, errors.New("collector source does not report block headers")
+	}
+
+	floor := uint64(0)
+	if c.startHeight > 0 {
+		floor = c.startHeight - 1
+	}
+	for h := height - 1; h > floor; h-- {
+		stored, err := c.repo.GetBlockHeader(c.chainID, h)
+		if errors.Is(err, repo.ErrNotFound) {
+			return h, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+		if stored.Hash == "" {
+			return h, nil
+		}
+
+		source, err := headers.GetBlockHeader(h)
+		if err != nil {
+			return 0, err
+		}
+		if source.Hash == stored.Hash {
+			return h, nil
+		}
+	}
+	return floor, nil
 }
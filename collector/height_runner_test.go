@@ -63,3 +63,156 @@ func TestSourceHeightCollectorCollectHeightSavesScheduledPoolSnapshot(t *testing
 	require.Equal(t, []dex.PoolInfo{{ContractAddr: "pair"}}, repository.saved[0].poolInfos)
 	require.Equal(t, parser.RawTxs{{Hash: "tx", Timestamp: time.Date(2026, 5, 26, 0, 0, 0, 0, time.UTC)}}, repository.saved[0].txs)
 }
+
+type headerSourceMock struct {
+	sourceStoreMock
+	headers map[uint64]dex.BlockHeader
+}
+
+func (m *headerSourceMock) GetBlockHeader(height uint64) (dex.BlockHeader, error) {
+	return m.headers[height], nil
+}
+
+func TestSourceHeightCollectorCollectHeightSavesBlockHeader(t *testing.T) {
+	repository := &sourceRepoMock{}
+	source := &headerSourceMock{
+		headers: map[uint64]dex.BlockHeader{10: {Height: 10, Hash: "h10", ParentHash: "h9"}},
+	}
+	collector := &sourceHeightCollector{repo: repository, source: source, chainID: "chain"}
+
+	err := collector.CollectHeight(10)
+
+	require.NoError(t, err)
+	require.Len(t, repository.saved, 1)
+	require.Equal(t, dex.BlockHeader{Height: 10, Hash: "h10", ParentHash: "h9"}, repository.saved[0].header)
+}
+
+type blockSourceMock struct {
+	headerSourceMock
+}
+
+func (m *blockSourceMock) GetBlock(height uint64) (dex.BlockHeader, parser.RawTxs, error) {
+	return m.headers[height], m.txs[height], nil
+}
+
+func (m *blockSourceMock) GetBlockHeader(uint64) (dex.BlockHeader, error) {
+	return dex.BlockHeader{}, errors.New("the header must come with the block")
+}
+
+func TestSourceHeightCollectorFetchesHeaderWithTxs(t *testing.T) {
+	source := &blockSourceMock{headerSourceMock{
+		sourceStoreMock: sourceStoreMock{txs: map[uint64]parser.RawTxs{10: {{Hash: "tx"}}}},
+		headers:         map[uint64]dex.BlockHeader{10: {Height: 10, Hash: "h10", ParentHash: "h9"}},
+	}}
+	collector := &sourceHeightCollector{repo: &sourceRepoMock{}, source: source, chainID: "chain"}
+
+	collected, err := collector.FetchHeight(10)
+
+	require.NoError(t, err)
+	require.Equal(t, dex.BlockHeader{Height: 10, Hash: "h10", ParentHash: "h9"}, collected.header)
+	require.Equal(t, parser.RawTxs{{Hash: "tx"}}, collected.txs)
+}
+
+func TestSourceHeightCollectorReturnsHashMismatchWithoutRollbackOnFork(t *testing.T) {
+	repository := &sourceRepoMock{saveErrs: map[uint64]error{10: repo.ErrBlockHashMismatch}}
+	source := &headerSourceMock{headers: map[uint64]dex.BlockHeader{10: {Height: 10, Hash: "h10", ParentHash: "h9"}}}
+	collector := &sourceHeightCollector{repo: repository, source: source, chainID: "chain"}
+
+	err := collector.CollectHeight(10)
+
+	require.ErrorIs(t, err, repo.ErrBlockHashMismatch)
+	require.Empty(t, repository.rolledBackTo)
+}
+
+func TestSourceHeightCollectorRollsBackToForkPoint(t *testing.T) {
+	repository := &sourceRepoMock{
+		headers: map[uint64]dex.BlockHeader{
+			7: {Height: 7, Hash: "h7"},
+			8: {Height: 8, Hash: "stale8"},
+			9: {Height: 9, Hash: "stale9"},
+		},
+		saveErrs: map[uint64]error{10: repo.ErrBlockHashMismatch},
+	}
+	source := &headerSourceMock{headers: map[uint64]dex.BlockHeader{
+		7:  {Height: 7, Hash: "h7"},
+		8:  {Height: 8, Hash: "h8", ParentHash: "h7"},
+		9:  {Height: 9, Hash: "h9", ParentHash: "h8"},
+		10: {Height: 10, Hash: "h10", ParentHash: "h9"},
+	}}
+	collector := &sourceHeightCollector{repo: repository, source: source, chainID: "chain", startHeight: 1, rollbackOnFork: true}
+
+	err := collector.CollectHeight(10)
+
+	require.ErrorIs(t, err, errChainRewound)
+	require.Equal(t, []uint64{7}, repository.rolledBackTo)
+	require.Equal(t, uint64(7), repository.syncedHeight)
+}
+
+func TestSourceHeightCollectorKeepsCollectorRowsWhenParserRewindFails(t *testing.T) {
+	repository := &sourceRepoMock{
+		headers:  map[uint64]dex.BlockHeader{8: {Height: 8, Hash: "h8"}, 9: {Height: 9, Hash: "stale9"}},
+		saveErrs: map[uint64]error{10: repo.ErrBlockHashMismatch},
+	}
+	source := &headerSourceMock{headers: map[uint64]dex.BlockHeader{
+		8:  {Height: 8, Hash: "h8"},
+		9:  {Height: 9, Hash: "h9", ParentHash: "h8"},
+		10: {Height: 10, Hash: "h10", ParentHash: "h9"},
+	}}
+	expected := errors.New("parser db down")
+	collector := &sourceHeightCollector{repo: repository, source: source, chainID: "chain", startHeight: 1, rollbackOnFork: true,
+		parserRewinder: &parserRewinderMock{err: expected}}
+
+	err := collector.CollectHeight(10)
+
+	require.ErrorIs(t, err, expected)
+	require.Empty(t, repository.rolledBackTo)
+}
+
+func TestSourceHeightCollectorDoesNotRollbackWhenParentStillMatches(t *testing.T) {
+	repository := &sourceRepoMock{
+		headers:  map[uint64]dex.BlockHeader{9: {Height: 9, Hash: "h9"}},
+		saveErrs: map[uint64]error{10: repo.ErrBlockHashMismatch},
+	}
+	source := &headerSourceMock{headers: map[uint64]dex.BlockHeader{
+		9:  {Height: 9, Hash: "h9"},
+		10: {Height: 10, Hash: "h10", ParentHash: "other"},
+	}}
+	collector := &sourceHeightCollector{repo: repository, source: source, chainID: "chain", startHeight: 1, rollbackOnFork: true}
+
+	err := collector.CollectHeight(10)
+
+	require.ErrorIs(t, err, repo.ErrBlockHashMismatch)
+	require.Empty(t, repository.rolledBackTo)
+}
+
+type parserRewinderMock struct {
+	rewoundAbove []uint64
+	err          error
+}
+
+func (m *parserRewinderMock) RewindAbove(height uint64) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.rewoundAbove = append(m.rewoundAbove, height)
+	return nil
+}
+
+type notifyingSourceMock struct {
+	sourceStoreMock
+	waits []uint64
+}
+
+func (m *notifyingSourceMock) WaitForHeight(after uint64, _ time.Duration) bool {
+	m.waits = append(m.waits, after)
+	return true
+}
+
+func TestSourceHeightCollectorWaitSourceHeightUsesNotifier(t *testing.T) {
+	source := &notifyingSourceMock{}
+	collector := &sourceHeightCollector{source: source}
+
+	collector.WaitSourceHeight(10, time.Hour)
+
+	require.Equal(t, []uint64{10}, source.waits)
+}
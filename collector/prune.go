@@ -0,0 +1,70 @@
+package collector
+
+import (
+	"fmt"
+
+	collectorrepo "github.com/dezswap/cosmwasm-etl/collector/repo"
+	"github.com/dezswap/cosmwasm-etl/pkg/logging"
+)
+
+// PrunePlan describes which collector rows of a chain a prune removes. Rows
+// at or above BlocksBelow and PoolSnapshotsBelow are always kept.
+type PrunePlan struct {
+	ChainID         string `json:"chain_id"`
+	RequestedHeight uint64 `json:"requested_height"`
+	collectorrepo.ParserCursors
+	// BlocksBelow never exceeds the lowest parser synced height, and
+	// PoolSnapshotsBelow additionally never exceeds the validation cursor.
+	BlocksBelow        uint64                    `json:"blocks_below"`
+	PoolSnapshotsBelow uint64                    `json:"pool_snapshots_below"`
+	Rows               collectorrepo.PruneCounts `json:"rows"`
+	DryRun             bool                      `json:"dry_run"`
+}
+
+// PlanPrune bounds the requested prune height by the parser cursors and
+// counts the rows below the resulting heights.
+func PlanPrune(pruner collectorrepo.Pruner, chainID string, requestedHeight uint64) (PrunePlan, error) {
+	cursors, err := pruner.GetParserCursors()
+	if err != nil {
+		return PrunePlan{}, fmt.Errorf("parser cursors unavailable, refusing to prune: %w", err)
+	}
+
+	plan := PrunePlan{
+		ChainID:         chainID,
+		RequestedHeight: requestedHeight,
+		ParserCursors:   cursors,
+		BlocksBelow:     min(requestedHeight, cursors.MinSyncedHeight),
+	}
+	plan.PoolSnapshotsBelow = plan.BlocksBelow
+	if cursors.ValidationHeight > 0 {
+		plan.PoolSnapshotsBelow = min(plan.PoolSnapshotsBelow, cursors.ValidationHeight)
+	}
+
+	if plan.Rows, err = pruner.CountPrunable(chainID, plan.BlocksBelow, plan.PoolSnapshotsBelow); err != nil {
+		return plan, err
+	}
+	return plan, nil
+}
+
+// Prune plans and, unless dryRun is set, deletes the collector rows of a
+// chain below requestedHeight. The returned plan holds the deleted row counts,
+// or the counts that would be deleted on a dry run.
+func Prune(pruner collectorrepo.Pruner, chainID string, requestedHeight uint64, dryRun bool, logger logging.Logger) (PrunePlan, error) {
+	plan, err := PlanPrune(pruner, chainID, requestedHeight)
+	if err != nil {
+		return plan, err
+	}
+	plan.DryRun = dryRun
+	if dryRun {
+		logger.Infof("dry run: would prune %d blocks below %d and %d pool snapshots below %d",
+			plan.Rows.Blocks, plan.BlocksBelow, plan.Rows.PoolSnapshots, plan.PoolSnapshotsBelow)
+		return plan, nil
+	}
+
+	if plan.Rows, err = pruner.Prune(chainID, plan.BlocksBelow, plan.PoolSnapshotsBelow); err != nil {
+		return plan, err
+	}
+	logger.Infof("pruned %d blocks below %d and %d pool snapshots below %d",
+		plan.Rows.Blocks, plan.BlocksBelow, plan.Rows.PoolSnapshots, plan.PoolSnapshotsBelow)
+	return plan, nil
+}
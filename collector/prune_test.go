@@ -0,0 +1,79 @@
+package collector
+
+import (
+	"testing"
+
+	collectorrepo "github.com/dezswap/cosmwasm-etl/collector/repo"
+	"github.com/dezswap/cosmwasm-etl/pkg/logging"
+	"github.com/stretchr/testify/require"
+)
+
+type prunerMock struct {
+	cursors    collectorrepo.ParserCursors
+	cursorsErr error
+	counted    [][2]uint64
+	pruned     [][2]uint64
+}
+
+func (m *prunerMock) GetParserCursors() (collectorrepo.ParserCursors, error) {
+	return m.cursors, m.cursorsErr
+}
+
+func (m *prunerMock) CountPrunable(_ string, blocksBelow, snapshotsBelow uint64) (collectorrepo.PruneCounts, error) {
+	m.counted = append(m.counted, [2]uint64{blocksBelow, snapshotsBelow})
+	return collectorrepo.PruneCounts{Blocks: int64(blocksBelow), PoolSnapshots: int64(snapshotsBelow)}, nil
+}
+
+func (m *prunerMock) Prune(_ string, blocksBelow, snapshotsBelow uint64) (collectorrepo.PruneCounts, error) {
+	m.pruned = append(m.pruned, [2]uint64{blocksBelow, snapshotsBelow})
+	return collectorrepo.PruneCounts{Blocks: 1, PoolSnapshots: 2}, nil
+}
+
+func TestPruneBoundsByParserCursors(t *testing.T) {
+	testCases := []struct {
+		name              string
+		requested         uint64
+		cursors           collectorrepo.ParserCursors
+		expectedBlocks    uint64
+		expectedSnapshots uint64
+	}{
+		{name: "requested below cursors", requested: 100, cursors: collectorrepo.ParserCursors{MinSyncedHeight: 500, ValidationHeight: 300}, expectedBlocks: 100, expectedSnapshots: 100},
+		{name: "capped by synced height", requested: 1000, cursors: collectorrepo.ParserCursors{MinSyncedHeight: 500}, expectedBlocks: 500, expectedSnapshots: 500},
+		{name: "snapshots capped by validation", requested: 1000, cursors: collectorrepo.ParserCursors{MinSyncedHeight: 500, ValidationHeight: 300}, expectedBlocks: 500, expectedSnapshots: 300},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			pruner := &prunerMock{cursors: testCase.cursors}
+
+			plan, err := Prune(pruner, "chain", testCase.requested, false, logging.Discard)
+
+			require.NoError(t, err)
+			require.Equal(t, testCase.expectedBlocks, plan.BlocksBelow)
+			require.Equal(t, testCase.expectedSnapshots, plan.PoolSnapshotsBelow)
+			require.Equal(t, [][2]uint64{{testCase.expectedBlocks, testCase.expectedSnapshots}}, pruner.pruned)
+			require.Equal(t, collectorrepo.PruneCounts{Blocks: 1, PoolSnapshots: 2}, plan.Rows)
+		})
+	}
+}
+
+func TestPruneDryRunOnlyCounts(t *testing.T) {
+	pruner := &prunerMock{cursors: collectorrepo.ParserCursors{MinSyncedHeight: 50}}
+
+	plan, err := Prune(pruner, "chain", 80, true, logging.Discard)
+
+	require.NoError(t, err)
+	require.True(t, plan.DryRun)
+	require.Empty(t, pruner.pruned)
+	require.Equal(t, collectorrepo.PruneCounts{Blocks: 50, PoolSnapshots: 50}, plan.Rows)
+}
+
+func TestPruneRefusesWithoutParserCursors(t *testing.T) {
+	pruner := &prunerMock{cursorsErr: collectorrepo.ErrUnavailable}
+
+	_, err := Prune(pruner, "chain", 80, false, logging.Discard)
+
+	require.ErrorIs(t, err, collectorrepo.ErrUnavailable)
+	require.Empty(t, pruner.counted)
+	require.Empty(t, pruner.pruned)
+}
@@ -0,0 +1,113 @@
+package repo
+
+import (
+	"github.com/dezswap/cosmwasm-etl/configs"
+	"github.com/dezswap/cosmwasm-etl/pkg/db"
+	"github.com/dezswap/cosmwasm-etl/pkg/db/schemas"
+	pkgerrors "github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+// ParserCursors are the parser positions that bound how far collector rows
+// may be pruned. ValidationHeight is 0 when no parser has a pending
+// validation cursor.
+type ParserCursors struct {
+	MinSyncedHeight  uint64 `json:"min_parser_synced_height"`
+	ValidationHeight uint64 `json:"min_validation_height"`
+}
+
+// PruneCounts are the collector rows below the prune heights of one chain.
+type PruneCounts struct {
+	Blocks        int64 `json:"blocks"`
+	PoolSnapshots int64 `json:"pool_snapshots"`
+}
+
+// Pruner removes collector rows the parser no longer reads.
+type Pruner interface {
+	// GetParserCursors returns ErrUnavailable when the parser synced_height
+	// table is missing and ErrNotFound when it has no rows, since collector
+	// rows cannot be pruned safely without it.
+	GetParserCursors() (ParserCursors, error)
+	// CountPrunable and Prune cover blocks below blocksBelow and pool
+	// snapshots below snapshotsBelow.
+	CountPrunable(chainID string, blocksBelow, snapshotsBelow uint64) (PruneCounts, error)
+	Prune(chainID string, blocksBelow, snapshotsBelow uint64) (PruneCounts, error)
+}
+
+// pruner deletes collector rows in db bounded by the parser cursors read
+// from parserDb, which may be another database.
+type pruner struct {
+	db       *gorm.DB
+	parserDb *gorm.DB
+}
+
+var _ Pruner = (*pruner)(nil)
+
+func NewPruner(dbConfig configs.RdbConfig, parserDbConfig configs.RdbConfig) Pruner {
+	gormDB, err := db.OpenGormPostgres(dbConfig)
+	if err != nil {
+		panic(err)
+	}
+	if parserDbConfig == dbConfig {
+		return NewPrunerWithDB(gormDB, gormDB)
+	}
+	parserDB, err := db.OpenGormPostgres(parserDbConfig)
+	if err != nil {
+		panic(err)
+	}
+
+	return NewPrunerWithDB(gormDB, parserDB)
+}
+
+func NewPrunerWithDB(db *gorm.DB, parserDb *gorm.DB) Pruner {
+	return &pruner{db: db, parserDb: parserDb}
+}
+
+func (r *pruner) GetParserCursors() (ParserCursors, error) {
+	row := struct {
+		RowCount         int64
+		MinSyncedHeight  uint64
+		ValidationHeight uint64
+	}{}
+	if err := r.parserDb.Model(&schemas.SyncedHeight{}).
+		Select("COUNT(*) AS row_count, COALESCE(MIN(height), 0) AS min_synced_height, COALESCE(MIN(validation_height), 0) AS validation_height").
+		Scan(&row).Error; err != nil {
+		return ParserCursors{}, classifyReadErr(err)
+	}
+	if row.RowCount == 0 {
+		return ParserCursors{}, ErrNotFound
+	}
+	return ParserCursors{MinSyncedHeight: row.MinSyncedHeight, ValidationHeight: row.ValidationHeight}, nil
+}
+
+func (r *pruner) CountPrunable(chainID string, blocksBelow, snapshotsBelow uint64) (PruneCounts, error) {
+	counts := PruneCounts{}
+	if err := r.db.Model(&schemas.CollectorBlock{}).
+		Where("chain_id = ? AND height < ?", chainID, blocksBelow).
+		Count(&counts.Blocks).Error; err != nil {
+		return PruneCounts{}, classifyReadErr(err)
+	}
+	if err := r.db.Model(&schemas.CollectorPoolSnapshot{}).
+		Where("chain_id = ? AND height < ?", chainID, snapshotsBelow).
+		Count(&counts.PoolSnapshots).Error; err != nil {
+		return PruneCounts{}, classifyReadErr(err)
+	}
+	return counts, nil
+}
+
+func (r *pruner) Prune(chainID string, blocksBelow, snapshotsBelow uint64) (PruneCounts, error) {
+	counts := PruneCounts{}
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		blocks := tx.Where("chain_id = ? AND height < ?", chainID, blocksBelow).Delete(&schemas.CollectorBlock{})
+		if blocks.Error != nil {
+			return pkgerrors.Wrap(blocks.Error, "collector repo prune blocks")
+		}
+		snapshots := tx.Where("chain_id = ? AND height < ?", chainID, snapshotsBelow).Delete(&schemas.CollectorPoolSnapshot{})
+		if snapshots.Error != nil {
+			return pkgerrors.Wrap(snapshots.Error, "collector repo prune pool snapshots")
+		}
+		counts = PruneCounts{Blocks: blocks.RowsAffected, PoolSnapshots: snapshots.RowsAffected}
+		return nil
+	})
+	return counts, err
+}
@@ -0,0 +1,78 @@
+package repo
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+func newMockPruner(t *testing.T) (Pruner, sqlmock.Sqlmock) {
+	repo, mock := newMockRepo(t)
+	return NewPrunerWithDB(repo.db, repo.db), mock
+}
+
+func TestGetParserCursors(t *testing.T) {
+	repo, mock := newMockPruner(t)
+	query := regexp.QuoteMeta(`SELECT COUNT(*) AS row_count, COALESCE(MIN(height), 0) AS min_synced_height, COALESCE(MIN(validation_height), 0) AS validation_height FROM "synced_height"`)
+	mock.ExpectQuery(query).
+		WillReturnRows(sqlmock.NewRows([]string{"row_count", "min_synced_height", "validation_height"}).AddRow(2, 120, 100))
+	mock.ExpectQuery(query).
+		WillReturnRows(sqlmock.NewRows([]string{"row_count", "min_synced_height", "validation_height"}).AddRow(0, 0, 0))
+	mock.ExpectQuery(query).
+		WillReturnError(&pq.Error{Code: "42P01"})
+
+	cursors, err := repo.GetParserCursors()
+	require.NoError(t, err)
+	require.Equal(t, ParserCursors{MinSyncedHeight: 120, ValidationHeight: 100}, cursors)
+
+	_, err = repo.GetParserCursors()
+	require.ErrorIs(t, err, ErrNotFound)
+
+	_, err = repo.GetParserCursors()
+	require.ErrorIs(t, err, ErrUnavailable)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetParserCursorsReadsParserDb(t *testing.T) {
+	collectorRepo, collectorMock := newMockRepo(t)
+	parserRepo, parserMock := newMockRepo(t)
+	pruner := NewPrunerWithDB(collectorRepo.db, parserRepo.db)
+	parserMock.ExpectQuery(regexp.QuoteMeta(`FROM "synced_height"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"row_count", "min_synced_height", "validation_height"}).AddRow(1, 120, 0))
+
+	cursors, err := pruner.GetParserCursors()
+
+	require.NoError(t, err)
+	require.Equal(t, ParserCursors{MinSyncedHeight: 120}, cursors)
+	require.NoError(t, parserMock.ExpectationsWereMet())
+	require.NoError(t, collectorMock.ExpectationsWereMet())
+}
+
+func TestCountPrunableAndPrune(t *testing.T) {
+	repo, mock := newMockPruner(t)
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "collector_blocks" WHERE chain_id = $1 AND height < $2`)).
+		WithArgs("phoenix-1", uint64(100)).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(99))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "collector_pool_snapshots" WHERE chain_id = $1 AND height < $2`)).
+		WithArgs("phoenix-1", uint64(80)).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+	counts, err := repo.CountPrunable("phoenix-1", 100, 80)
+	require.NoError(t, err)
+	require.Equal(t, PruneCounts{Blocks: 99, PoolSnapshots: 3}, counts)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "collector_blocks" WHERE chain_id = $1 AND height < $2`)).
+		WithArgs("phoenix-1", uint64(100)).WillReturnResult(sqlmock.NewResult(0, 99))
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "collector_pool_snapshots" WHERE chain_id = $1 AND height < $2`)).
+		WithArgs("phoenix-1", uint64(80)).WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectCommit()
+
+	counts, err = repo.Prune("phoenix-1", 100, 80)
+	require.NoError(t, err)
+	require.Equal(t, PruneCounts{Blocks: 99, PoolSnapshots: 3}, counts)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
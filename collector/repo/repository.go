@@ -18,8 +18,9 @@ import (
 )
 
 var (
-	ErrNotFound    = errors.New("collector source data not found")
-	ErrUnavailable = errors.New("collector source table unavailable")
+	ErrNotFound          = errors.New("collector source data not found")
+	ErrUnavailable       = errors.New("collector source table unavailable")
+	ErrBlockHashMismatch = errors.New("collector block parent hash mismatch")
 )
 
 // Repository stores parser-ready source data collected by DoCollectSource.
@@ -29,7 +30,24 @@ type Repository interface {
 	GetSyncedHeight(chainID string) (uint64, error)
 	GetBlockTxs(chainID string, height uint64) (parser.RawTxs, time.Time, error)
 	GetPoolInfos(chainID string, height uint64) ([]dex.PoolInfo, error)
-	SaveHeight(chainID string, height uint64, blockTime time.Time, txs parser.RawTxs, poolInfos []dex.PoolInfo, savePoolSnapshot bool) error
+	GetBlockHeader(chainID string, height uint64) (dex.BlockHeader, error)
+	// GetBlockHeights and GetPoolSnapshotHeights list the stored heights in
+	// [from, to] in ascending order.
+	GetBlockHeights(chainID string, from, to uint64) ([]uint64, error)
+	GetPoolSnapshotHeights(chainID string, from, to uint64) ([]uint64, error)
+	// GetFilterMismatchHeights lists the stored heights in [from, to] whose
+	// txs were saved with a filter version other than filterVersion.
+	GetFilterMismatchHeights(chainID string, from, to uint64, filterVersion uint) ([]uint64, error)
+	// SaveHeight returns ErrBlockHashMismatch when header.ParentHash does not
+	// match the hash stored for height - 1. filterVersion records the tx filter
+	// applied to txs, 0 when txs hold the whole block.
+	SaveHeight(chainID string, height uint64, blockTime time.Time, header dex.BlockHeader, txs parser.RawTxs, filterVersion uint, poolInfos []dex.PoolInfo, savePoolSnapshot bool) error
+	// SavePoolSnapshot stores the pools of a height that is already collected
+	// or lies outside the snapshot interval, leaving the synced height as is.
+	SavePoolSnapshot(chainID string, height uint64, poolInfos []dex.PoolInfo) error
+	// Rollback deletes the collector rows above height and rewinds the
+	// collector synced height to it. Parser rows are left to the parser repo.
+	Rollback(chainID string, height uint64) error
 }
 
 type repository struct {
@@ -85,7 +103,46 @@ func (r *repository) GetPoolInfos(chainID string, height uint64) ([]dex.PoolInfo
 	return poolInfos, nil
 }
 
-func (r *repository) SaveHeight(chainID string, height uint64, blockTime time.Time, txs parser.RawTxs, poolInfos []dex.PoolInfo, savePoolSnapshot bool) error {
+func (r *repository) GetBlockHeader(chainID string, height uint64) (dex.BlockHeader, error) {
+	row := schemas.CollectorBlock{}
+	if err := r.db.Select("chain_id", "height", "block_hash", "parent_hash").
+		Where("chain_id = ? AND height = ?", chainID, height).First(&row).Error; err != nil {
+		return dex.BlockHeader{}, classifyReadErr(err)
+	}
+	return dex.BlockHeader{Height: row.Height, Hash: row.BlockHash, ParentHash: row.ParentHash}, nil
+}
+
+func (r *repository) GetBlockHeights(chainID string, from, to uint64) ([]uint64, error) {
+	heights := []uint64{}
+	if err := r.db.Model(&schemas.CollectorBlock{}).
+		Where("chain_id = ? AND height BETWEEN ? AND ?", chainID, from, to).
+		Order("height ASC").Pluck("height", &heights).Error; err != nil {
+		return nil, classifyReadErr(err)
+	}
+	return heights, nil
+}
+
+func (r *repository) GetPoolSnapshotHeights(chainID string, from, to uint64) ([]uint64, error) {
+	heights := []uint64{}
+	if err := r.db.Model(&schemas.CollectorPoolSnapshot{}).
+		Where("chain_id = ? AND height BETWEEN ? AND ?", chainID, from, to).
+		Order("height ASC").Pluck("height", &heights).Error; err != nil {
+		return nil, classifyReadErr(err)
+	}
+	return heights, nil
+}
+
+func (r *repository) GetFilterMismatchHeights(chainID string, from, to uint64, filterVersion uint) ([]uint64, error) {
+	heights := []uint64{}
+	if err := r.db.Model(&schemas.CollectorBlock{}).
+		Where("chain_id = ? AND height BETWEEN ? AND ? AND filter_version <> ?", chainID, from, to, filterVersion).
+		Order("height ASC").Pluck("height", &heights).Error; err != nil {
+		return nil, classifyReadErr(err)
+	}
+	return heights, nil
+}
+
+func (r *repository) SaveHeight(chainID string, height uint64, blockTime time.Time, header dex.BlockHeader, txs parser.RawTxs, filterVersion uint, poolInfos []dex.PoolInfo, savePoolSnapshot bool) error {
 	txBytes, err := json.Marshal(txs)
 	if err != nil {
 		return pkgerrors.Wrap(err, "collector repo marshal block txs")
@@ -100,13 +157,20 @@ func (r *repository) SaveHeight(chainID string, height uint64, blockTime time.Ti
 	}
 
 	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := verifyParentHash(tx, chainID, height, header.ParentHash); err != nil {
+			return err
+		}
+
 		block := schemas.CollectorBlock{
-			ChainId:   chainID,
-			Height:    height,
-			BlockTime: blockTime.UTC(),
-			Txs:       schemas.CollectorJSON(txBytes),
+			ChainId:       chainID,
+			Height:        height,
+			BlockTime:     blockTime.UTC(),
+			BlockHash:     header.Hash,
+			ParentHash:    header.ParentHash,
+			Txs:           schemas.CollectorJSON(txBytes),
+			FilterVersion: filterVersion,
 		}
-		if err := upsert(tx, block, []string{"chain_id", "height"}, []string{"block_time", "txs", "updated_at"}); err != nil {
+		if err := upsert(tx, block, []string{"chain_id", "height"}, []string{"block_time", "block_hash", "parent_hash", "txs", "filter_version", "updated_at"}); err != nil {
 			return pkgerrors.Wrap(err, "collector repo save block")
 		}
 
@@ -138,6 +202,62 @@ func (r *repository) SaveHeight(chainID string, height uint64, blockTime time.Ti
 	})
 }
 
+func (r *repository) SavePoolSnapshot(chainID string, height uint64, poolInfos []dex.PoolInfo) error {
+	poolBytes, err := json.Marshal(poolInfos)
+	if err != nil {
+		return pkgerrors.Wrap(err, "collector repo marshal pool infos")
+	}
+
+	pool := schemas.CollectorPoolSnapshot{
+		ChainId:   chainID,
+		Height:    height,
+		PoolInfos: schemas.CollectorJSON(poolBytes),
+	}
+	if err := upsert(r.db, pool, []string{"chain_id", "height"}, []string{"pool_infos", "updated_at"}); err != nil {
+		return pkgerrors.Wrap(err, "collector repo save pool snapshot")
+	}
+	return nil
+}
+
+func (r *repository) Rollback(chainID string, height uint64) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("chain_id = ? AND height > ?", chainID, height).Delete(&schemas.CollectorBlock{}).Error; err != nil {
+			return pkgerrors.Wrap(err, "collector repo rollback blocks")
+		}
+		if err := tx.Where("chain_id = ? AND height > ?", chainID, height).Delete(&schemas.CollectorPoolSnapshot{}).Error; err != nil {
+			return pkgerrors.Wrap(err, "collector repo rollback pool snapshots")
+		}
+		if err := tx.Model(&schemas.CollectorSyncedHeight{}).
+			Where("chain_id = ? AND height > ?", chainID, height).
+			Update("height", height).Error; err != nil {
+			return pkgerrors.Wrap(err, "collector repo rollback synced height")
+		}
+
+		return nil
+	})
+}
+
+// verifyParentHash checks parentHash against the stored hash of height - 1.
+// Heights without a reported parent hash, or whose previous row has no hash,
+// are accepted so rows collected before hashes were recorded stay valid.
+func verifyParentHash(tx *gorm.DB, chainID string, height uint64, parentHash string) error {
+	if parentHash == "" || height == 0 {
+		return nil
+	}
+
+	prev := schemas.CollectorBlock{}
+	err := tx.Select("chain_id", "height", "block_hash").
+		Where("chain_id = ? AND height = ?", chainID, height-1).
+		Limit(1).Find(&prev).Error
+	if err != nil {
+		return pkgerrors.Wrap(err, "collector repo load parent block")
+	}
+	if prev.BlockHash == "" || prev.BlockHash == parentHash {
+		return nil
+	}
+	return pkgerrors.Wrapf(ErrBlockHashMismatch, "height %d parent %s, stored %s", height, parentHash, prev.BlockHash)
+}
+
 func upsert[T any](db *gorm.DB, value T, keyColumns []string, updateColumns []string) error {
 	columns := make([]clause.Column, 0, len(keyColumns))
 	for _, column := range keyColumns {
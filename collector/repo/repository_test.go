@@ -159,7 +159,7 @@ func TestSaveHeightUpsertsBlockPoolAndSyncedHeight(t *testing.T) {
 	ts := time.Date(2026, 5, 19, 1, 2, 3, 0, time.UTC)
 
 	mock.ExpectBegin()
-	expectUpsert(mock, `collector_blocks`, `"chain_id","height","block_time","txs"`)
+	expectUpsert(mock, `collector_blocks`, `"chain_id","height","block_time","block_hash","parent_hash","txs","filter_version"`)
 	expectUpsert(mock, `collector_pool_snapshots`, `"chain_id","height","pool_infos"`)
 	expectSyncedHeightInsert(mock)
 	mock.ExpectCommit()
@@ -168,7 +168,9 @@ func TestSaveHeightUpsertsBlockPoolAndSyncedHeight(t *testing.T) {
 		"phoenix-1",
 		10,
 		ts,
+		dex.BlockHeader{Height: 10},
 		parser.RawTxs{{Hash: "hash", Timestamp: ts}},
+		0,
 		[]dex.PoolInfo{{ContractAddr: "pair"}},
 		true,
 	)
@@ -182,7 +184,7 @@ func TestSaveHeightWithoutPoolSnapshotSkipsPoolUpsert(t *testing.T) {
 	ts := time.Date(2026, 5, 19, 1, 2, 3, 0, time.UTC)
 
 	mock.ExpectBegin()
-	expectUpsert(mock, `collector_blocks`, `"chain_id","height","block_time","txs"`)
+	expectUpsert(mock, `collector_blocks`, `"chain_id","height","block_time","block_hash","parent_hash","txs","filter_version"`)
 	expectSyncedHeightInsert(mock)
 	mock.ExpectCommit()
 
@@ -190,7 +192,9 @@ func TestSaveHeightWithoutPoolSnapshotSkipsPoolUpsert(t *testing.T) {
 		"phoenix-1",
 		10,
 		ts,
+		dex.BlockHeader{Height: 10},
 		parser.RawTxs{{Hash: "hash", Timestamp: ts}},
+		1,
 		nil,
 		false,
 	)
@@ -208,7 +212,7 @@ func TestSaveHeightRollsBackWhenBlockUpsertFails(t *testing.T) {
 		WillReturnError(expected)
 	mock.ExpectRollback()
 
-	err := repo.SaveHeight("phoenix-1", 10, time.Now(), parser.RawTxs{}, nil, false)
+	err := repo.SaveHeight("phoenix-1", 10, time.Now(), dex.BlockHeader{}, parser.RawTxs{}, 0, nil, false)
 
 	require.ErrorIs(t, err, expected)
 	require.NoError(t, mock.ExpectationsWereMet())
@@ -219,12 +223,12 @@ func TestSaveHeightRollsBackWhenPoolUpsertFails(t *testing.T) {
 	expected := errors.New("pool insert failed")
 
 	mock.ExpectBegin()
-	expectUpsert(mock, `collector_blocks`, `"chain_id","height","block_time","txs"`)
+	expectUpsert(mock, `collector_blocks`, `"chain_id","height","block_time","block_hash","parent_hash","txs","filter_version"`)
 	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "collector_pool_snapshots"`)).
 		WillReturnError(expected)
 	mock.ExpectRollback()
 
-	err := repo.SaveHeight("phoenix-1", 10, time.Now(), parser.RawTxs{}, []dex.PoolInfo{}, true)
+	err := repo.SaveHeight("phoenix-1", 10, time.Now(), dex.BlockHeader{}, parser.RawTxs{}, 0, []dex.PoolInfo{}, true)
 
 	require.ErrorIs(t, err, expected)
 	require.NoError(t, mock.ExpectationsWereMet())
@@ -235,17 +239,120 @@ func TestSaveHeightRollsBackWhenSyncedHeightUpsertFails(t *testing.T) {
 	expected := errors.New("synced insert failed")
 
 	mock.ExpectBegin()
-	expectUpsert(mock, `collector_blocks`, `"chain_id","height","block_time","txs"`)
+	expectUpsert(mock, `collector_blocks`, `"chain_id","height","block_time","block_hash","parent_hash","txs","filter_version"`)
 	mock.ExpectExec(syncedHeightInsertPattern()).
 		WillReturnError(expected)
 	mock.ExpectRollback()
 
-	err := repo.SaveHeight("phoenix-1", 10, time.Now(), parser.RawTxs{}, nil, false)
+	err := repo.SaveHeight("phoenix-1", 10, time.Now(), dex.BlockHeader{}, parser.RawTxs{}, 0, nil, false)
 
 	require.ErrorIs(t, err, expected)
 	require.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestSaveHeightAcceptsMatchingParentHash(t *testing.T) {
+	repo, mock := newMockRepo(t)
+	ts := time.Date(2026, 5, 19, 1, 2, 3, 0, time.UTC)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "chain_id","height","block_hash" FROM "collector_blocks"`)).
+		WithArgs("phoenix-1", uint64(9), 1).
+		WillReturnRows(sqlmock.NewRows([]string{"chain_id", "height", "block_hash"}).AddRow("phoenix-1", 9, "h9"))
+	expectUpsert(mock, `collector_blocks`, `"chain_id","height","block_time","block_hash","parent_hash","txs","filter_version"`)
+	expectSyncedHeightInsert(mock)
+	mock.ExpectCommit()
+
+	err := repo.SaveHeight("phoenix-1", 10, ts, dex.BlockHeader{Height: 10, Hash: "h10", ParentHash: "h9"}, parser.RawTxs{}, 0, nil, false)
+
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSaveHeightRejectsParentHashMismatch(t *testing.T) {
+	repo, mock := newMockRepo(t)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "chain_id","height","block_hash" FROM "collector_blocks"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"chain_id", "height", "block_hash"}).AddRow("phoenix-1", 9, "forked9"))
+	mock.ExpectRollback()
+
+	err := repo.SaveHeight("phoenix-1", 10, time.Now(), dex.BlockHeader{Height: 10, Hash: "h10", ParentHash: "h9"}, parser.RawTxs{}, 0, nil, false)
+
+	require.ErrorIs(t, err, ErrBlockHashMismatch)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetBlockHeader(t *testing.T) {
+	repo, mock := newMockRepo(t)
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "chain_id","height","block_hash","parent_hash" FROM "collector_blocks"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"chain_id", "height", "block_hash", "parent_hash"}).AddRow("phoenix-1", 10, "h10", "h9"))
+
+	header, err := repo.GetBlockHeader("phoenix-1", 10)
+
+	require.NoError(t, err)
+	require.Equal(t, dex.BlockHeader{Height: 10, Hash: "h10", ParentHash: "h9"}, header)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetBlockAndPoolSnapshotHeights(t *testing.T) {
+	repo, mock := newMockRepo(t)
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "height" FROM "collector_blocks" WHERE chain_id = $1 AND height BETWEEN $2 AND $3 ORDER BY height ASC`)).
+		WithArgs("phoenix-1", uint64(1), uint64(5)).
+		WillReturnRows(sqlmock.NewRows([]string{"height"}).AddRow(1).AddRow(3))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "height" FROM "collector_pool_snapshots" WHERE chain_id = $1 AND height BETWEEN $2 AND $3 ORDER BY height ASC`)).
+		WithArgs("phoenix-1", uint64(1), uint64(5)).
+		WillReturnError(&pq.Error{Code: "42P01"})
+
+	blocks, err := repo.GetBlockHeights("phoenix-1", 1, 5)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{1, 3}, blocks)
+
+	_, err = repo.GetPoolSnapshotHeights("phoenix-1", 1, 5)
+	require.ErrorIs(t, err, ErrUnavailable)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSavePoolSnapshotKeepsSyncedHeight(t *testing.T) {
+	repo, mock := newMockRepo(t)
+	mock.ExpectBegin()
+	expectUpsert(mock, `collector_pool_snapshots`, `"chain_id","height","pool_infos"`)
+	mock.ExpectCommit()
+
+	err := repo.SavePoolSnapshot("phoenix-1", 15, []dex.PoolInfo{{ContractAddr: "pair"}})
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetFilterMismatchHeights(t *testing.T) {
+	repo, mock := newMockRepo(t)
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "height" FROM "collector_blocks" WHERE chain_id = $1 AND height BETWEEN $2 AND $3 AND filter_version <> $4 ORDER BY height ASC`)).
+		WithArgs("phoenix-1", uint64(1), uint64(5), uint(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"height"}).AddRow(2))
+
+	heights, err := repo.GetFilterMismatchHeights("phoenix-1", 1, 5, 1)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{2}, heights)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRollbackDeletesCollectorRowsAboveFork(t *testing.T) {
+	repo, mock := newMockRepo(t)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "collector_blocks" WHERE chain_id = $1 AND height > $2`)).
+		WithArgs("phoenix-1", uint64(7)).WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "collector_pool_snapshots" WHERE chain_id = $1 AND height > $2`)).
+		WithArgs("phoenix-1", uint64(7)).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "collector_synced_heights" SET "height"=$1`)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := repo.Rollback("phoenix-1", 7)
+
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestIsUndefinedTableHandlesDriverAndSQLStateErrors(t *testing.T) {
 	require.True(t, isUndefinedTable(&pq.Error{Code: "42P01"}))
 	require.True(t, isUndefinedTable(errors.New(`relation "collector_blocks" does not exist (SQLSTATE 42P01)`)))
@@ -0,0 +1,26 @@
+package collector
+
+import (
+	collectorrepo "github.com/dezswap/cosmwasm-etl/collector/repo"
+	"github.com/dezswap/cosmwasm-etl/parser/dex"
+	"github.com/dezswap/cosmwasm-etl/pkg/logging"
+	"github.com/pkg/errors"
+)
+
+// SnapshotHeights stores the pools of each given height in
+// collector_pool_snapshots, whether or not the height is a multiple of the
+// snapshot interval or already collected. Blocks and the synced height are
+// left untouched.
+func SnapshotHeights(repo collectorrepo.Repository, source dex.SourceDataStore, chainID string, heights []uint64, logger logging.Logger) error {
+	for idx, height := range heights {
+		poolInfos, err := source.GetPoolInfos(height)
+		if err != nil {
+			return errors.Wrapf(err, "SnapshotHeights(%d)", height)
+		}
+		if err := repo.SavePoolSnapshot(chainID, height, poolInfos); err != nil {
+			return err
+		}
+		logger.Infof("saved pool snapshot of height %d with %d pools (%d/%d)", height, len(poolInfos), idx+1, len(heights))
+	}
+	return nil
+}
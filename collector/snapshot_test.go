@@ -0,0 +1,26 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/dezswap/cosmwasm-etl/parser/dex"
+	"github.com/dezswap/cosmwasm-etl/pkg/logging"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshotHeightsSavesOnlyPools(t *testing.T) {
+	repo := &sourceRepoMock{syncedHeight: 100}
+	source := &sourceStoreMock{poolInfos: map[uint64][]dex.PoolInfo{
+		7:  {{ContractAddr: "pair7"}},
+		42: {{ContractAddr: "pair42"}},
+	}}
+
+	err := SnapshotHeights(repo, source, "chain", []uint64{7, 42}, logging.Discard)
+
+	require.NoError(t, err)
+	require.Empty(t, repo.saved)
+	require.Equal(t, map[uint64][]dex.PoolInfo{
+		7:  {{ContractAddr: "pair7"}},
+		42: {{ContractAddr: "pair42"}},
+	}, repo.snapshots)
+}
@@ -0,0 +1,90 @@
+package fcd
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dezswap/cosmwasm-etl/configs"
+	"github.com/dezswap/cosmwasm-etl/pkg/logging"
+	"github.com/pkg/errors"
+)
+
+const defaultRetryWait = 3 * time.Second
+
+// TargetAddresses returns the addresses selected by c.TargetSource, reading
+// the parser pair table of chainID for FcdTargetSourcePair.
+func TargetAddresses(store permanentStore, chainID string, c configs.FcdConfig) ([]string, error) {
+	switch c.TargetSource {
+	case configs.FcdTargetSourceConfig:
+		return c.TargetAddresses, nil
+	case configs.FcdTargetSourcePair:
+		addrs, err := store.PairAddresses(chainID)
+		if err != nil {
+			return nil, errors.Wrap(err, "TargetAddresses")
+		}
+		return addrs, nil
+	default:
+		return nil, errors.Errorf("TargetAddresses: unknown target source %q", c.TargetSource)
+	}
+}
+
+// CollectAddresses collects the history of addrs up to untilHeight with
+// c.Concurrency workers. An address that fails is retried c.MaxRetries times
+// with exponential backoff, each attempt resuming where the previous one
+// stopped. Addresses that still fail are skipped and reported in the error.
+func CollectAddresses(collector AddressCollector, addrs []string, untilHeight uint32, c configs.FcdConfig, logger logging.Logger) error {
+	return collectAddresses(collector, addrs, untilHeight, c, defaultRetryWait, logger)
+}
+
+func collectAddresses(collector AddressCollector, addrs []string, untilHeight uint32, c configs.FcdConfig, retryWait time.Duration, logger logging.Logger) error {
+	targets := make(chan string)
+	mu := sync.Mutex{}
+	failed := []string{}
+	wg := sync.WaitGroup{}
+
+	for i := uint(0); i < max(c.Concurrency, 1); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for addr := range targets {
+				if err := collectWithRetry(collector, addr, untilHeight, c.MaxRetries, retryWait, logger); err != nil {
+					logger.Errorf("giving up addr(%s): %s", addr, err)
+					mu.Lock()
+					failed = append(failed, addr)
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+
+	for _, addr := range addrs {
+		targets <- addr
+	}
+	close(targets)
+	wg.Wait()
+
+	if len(failed) > 0 {
+		sort.Strings(failed)
+		return errors.Errorf("CollectAddresses: %d of %d addresses failed: %s", len(failed), len(addrs), strings.Join(failed, ","))
+	}
+	return nil
+}
+
+func collectWithRetry(collector AddressCollector, addr string, untilHeight uint32, maxRetries uint, retryWait time.Duration, logger logging.Logger) error {
+	logger.Infof("start collecting addr(%s)", addr)
+	var err error
+	for attempt := uint(0); attempt <= maxRetries; attempt++ {
+		if attempt > 0 {
+			logger.Errorf("addr(%s) attempt %d/%d failed: %s", addr, attempt, maxRetries+1, err)
+			time.Sleep(retryWait * time.Duration(math.Pow(2, float64(attempt))))
+		}
+		if err = collector.Collect(addr, untilHeight); err == nil {
+			logger.Infof("finished collecting addr(%s)", addr)
+			return nil
+		}
+	}
+	return errors.Wrapf(err, "collect addr(%s)", addr)
+}
@@ -0,0 +1,72 @@
+package fcd
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/dezswap/cosmwasm-etl/configs"
+	"github.com/dezswap/cosmwasm-etl/pkg/logging"
+	"github.com/stretchr/testify/require"
+)
+
+type addressCollectorMock struct {
+	AddressCollector
+	failures map[string]int
+
+	mu       sync.Mutex
+	attempts map[string]int
+	heights  map[string]uint32
+}
+
+func (m *addressCollectorMock) Collect(addr string, height uint32) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.attempts[addr]++
+	m.heights[addr] = height
+	if m.attempts[addr] <= m.failures[addr] {
+		return errors.New("fcd unavailable")
+	}
+	return nil
+}
+
+type pairStoreMock struct {
+	permanentStore
+	pairs map[string][]string
+}
+
+func (m *pairStoreMock) PairAddresses(chainID string) ([]string, error) {
+	return m.pairs[chainID], nil
+}
+
+func TestCollectAddressesRetriesAndReportsFailures(t *testing.T) {
+	collector := &addressCollectorMock{
+		failures: map[string]int{"retried": 1, "broken": 10},
+		attempts: map[string]int{},
+		heights:  map[string]uint32{},
+	}
+	addrs := []string{"ok", "retried", "broken", "other"}
+
+	err := collectAddresses(collector, addrs, 100, configs.FcdConfig{Concurrency: 3, MaxRetries: 2}, 0, logging.Discard)
+
+	require.ErrorContains(t, err, "1 of 4 addresses failed: broken")
+	require.Equal(t, map[string]int{"ok": 1, "retried": 2, "broken": 3, "other": 1}, collector.attempts)
+	for _, addr := range addrs {
+		require.Equal(t, uint32(100), collector.heights[addr])
+	}
+}
+
+func TestTargetAddresses(t *testing.T) {
+	store := &pairStoreMock{pairs: map[string][]string{"columbus-5": {"pair1", "pair2"}}}
+
+	addrs, err := TargetAddresses(store, "columbus-5", configs.FcdConfig{TargetSource: configs.FcdTargetSourcePair, TargetAddresses: []string{"ignored"}})
+	require.NoError(t, err)
+	require.Equal(t, []string{"pair1", "pair2"}, addrs)
+
+	addrs, err = TargetAddresses(store, "columbus-5", configs.FcdConfig{TargetSource: configs.FcdTargetSourceConfig, TargetAddresses: []string{"addr"}})
+	require.NoError(t, err)
+	require.Equal(t, []string{"addr"}, addrs)
+
+	_, err = TargetAddresses(store, "columbus-5", configs.FcdConfig{TargetSource: "unknown"})
+	require.Error(t, err)
+}
@@ -12,11 +12,16 @@ type fcdRepo interface {
 
 type permanentStore interface {
 	FirstTxOf(addr string) (schemas.FcdTxLog, error)
+	PairAddresses(chainID string) ([]string, error)
 	Inserts(txLogs []schemas.FcdTxLog) error
-	TxLogsByHeight(height int) ([]schemas.FcdTxLog, error)
+	StartAddress(chainID string, address string) error
+	CompleteAddress(chainID string, address string, untilHeight uint32) error
+	TxLogsByHeight(chainID string, height int) ([]schemas.FcdTxLog, error)
+	CollectedHeight(chainID string) (uint64, error)
 }
 
-type ColumbusCollector interface {
+// AddressCollector stores the FCD tx history of an address in fcd_tx_log.
+type AddressCollector interface {
 	Collect(addr string, height uint32) error
 
 	storeTxs(txs Txs) error
@@ -26,19 +31,28 @@ type ColumbusCollector interface {
 	permanentStore
 }
 
-type columbusFcdCollector struct {
+const defaultPageSize = uint32(1000)
+
+type addressFcdCollector struct {
 	fcdRepo
 	permanentStore
+	chainID  string
+	pageSize uint32
 }
 
-func New(repo fcdRepo, store permanentStore) ColumbusCollector {
-	return &columbusFcdCollector{repo, store}
+// New returns a collector of the chainID addresses inserting pageSize txs at
+// a time, or 1000 when pageSize is 0.
+func New(chainID string, repo fcdRepo, store permanentStore, pageSize uint32) AddressCollector {
+	if pageSize == 0 {
+		pageSize = defaultPageSize
+	}
+	return &addressFcdCollector{repo, store, chainID, pageSize}
 }
 
 // hasCollected checks the absence of the previous transaction by examining the transactions collected from the FCD server
 //
 // NOTE: fcd returns transactions in reverse order of height
-func (c *columbusFcdCollector) hasCollected(addr string) (bool, error) {
+func (c *addressFcdCollector) hasCollected(addr string) (bool, error) {
 	tx, err := c.FirstTxOf(addr)
 	if err != nil {
 		return false, errors.Wrap(err, "col4permanentStore.HasCollected")
@@ -55,7 +69,7 @@ func (c *columbusFcdCollector) hasCollected(addr string) (bool, error) {
 }
 
 // storeTxs implements permanentStore.
-func (c *columbusFcdCollector) storeTxs(txs []schemas.FcdTxLog) error {
+func (c *addressFcdCollector) storeTxs(txs []schemas.FcdTxLog) error {
 	err := c.Inserts(txs)
 	if err != nil {
 		return errors.Wrap(err, "col4permanentStore.StoreTxs")
@@ -64,30 +78,37 @@ func (c *columbusFcdCollector) storeTxs(txs []schemas.FcdTxLog) error {
 	return nil
 }
 
-// Collect implements ColumbusCollector.
-func (c *columbusFcdCollector) Collect(address string, height uint32) error {
+// Collect implements AddressCollector. It resumes below the lowest offset
+// already stored for address, since FCD lists txs from the newest, and marks
+// the address complete once its first tx is stored.
+func (c *addressFcdCollector) Collect(address string, height uint32) error {
+	if err := c.StartAddress(c.chainID, address); err != nil {
+		return errors.Wrap(err, "addressFcdCollector.Collect")
+	}
 	for {
 		collected, err := c.hasCollected(address)
 		if err != nil {
-			return errors.Wrap(err, "columbusFcdCollector.Collect")
+			return errors.Wrap(err, "addressFcdCollector.Collect")
 		}
 		if collected {
+			if err := c.CompleteAddress(c.chainID, address, height); err != nil {
+				return errors.Wrap(err, "addressFcdCollector.Collect")
+			}
 			return nil
 		}
 
 		tx, err := c.FirstTxOf(address)
 		if err != nil {
-			return errors.Wrap(err, "columbusFcdCollector.Collect")
+			return errors.Wrap(err, "addressFcdCollector.Collect")
 		}
 
-		// TODO: make configurable limit
-		txs, err := c.TxsOf(address, tx.FcdOffset, height, 1000)
+		txs, err := c.TxsOf(address, tx.FcdOffset, height, c.pageSize)
 		if err != nil {
-			return errors.Wrap(err, "columbusFcdCollector.Collect")
+			return errors.Wrap(err, "addressFcdCollector.Collect")
 		}
 
 		if err := c.storeTxs(txs); err != nil {
-			return errors.Wrap(err, "columbusFcdCollector.Collect")
+			return errors.Wrap(err, "addressFcdCollector.Collect")
 		}
 	}
 }
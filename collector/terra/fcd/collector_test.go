@@ -0,0 +1,69 @@
+package fcd
+
+import (
+	"testing"
+
+	"github.com/dezswap/cosmwasm-etl/pkg/db/schemas"
+	"github.com/stretchr/testify/require"
+)
+
+// fcdPagesMock serves the txs of an address newest first, page by page, and
+// has no tx below the last page.
+type fcdPagesMock struct {
+	pages []Txs
+	calls int
+}
+
+func (m *fcdPagesMock) TxsOf(_ string, _, _, _ uint32) (Txs, error) {
+	page := m.pages[m.calls]
+	m.calls++
+	return page, nil
+}
+
+func (m *fcdPagesMock) HasMoreTx(_ string, _ uint32) (bool, error) {
+	return m.calls < len(m.pages), nil
+}
+
+type progressStoreMock struct {
+	permanentStore
+	txs       Txs
+	started   []string
+	completed map[string]uint32
+}
+
+func (m *progressStoreMock) FirstTxOf(_ string) (schemas.FcdTxLog, error) {
+	if len(m.txs) == 0 {
+		return schemas.FcdTxLog{}, nil
+	}
+	return m.txs[len(m.txs)-1], nil
+}
+
+func (m *progressStoreMock) Inserts(txLogs []schemas.FcdTxLog) error {
+	m.txs = append(m.txs, txLogs...)
+	return nil
+}
+
+func (m *progressStoreMock) StartAddress(chainID string, address string) error {
+	m.started = append(m.started, chainID+"/"+address)
+	return nil
+}
+
+func (m *progressStoreMock) CompleteAddress(chainID string, address string, untilHeight uint32) error {
+	m.completed[chainID+"/"+address] = untilHeight
+	return nil
+}
+
+func TestCollectMarksAddressCompleteAfterFirstTx(t *testing.T) {
+	repo := &fcdPagesMock{pages: []Txs{
+		{{FcdOffset: 30, Height: 300}, {FcdOffset: 20, Height: 200}},
+		{{FcdOffset: 10, Height: 100}},
+	}}
+	store := &progressStoreMock{completed: map[string]uint32{}}
+
+	err := New("columbus-4", repo, store, 2).Collect("pair", 500)
+
+	require.NoError(t, err)
+	require.Equal(t, []string{"columbus-4/pair"}, store.started)
+	require.Equal(t, map[string]uint32{"columbus-4/pair": 500}, store.completed)
+	require.Len(t, store.txs, 3)
+}
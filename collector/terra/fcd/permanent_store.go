@@ -1,9 +1,12 @@
 package fcd
 
 import (
+	"math"
+
 	"github.com/dezswap/cosmwasm-etl/pkg/db/schemas"
 	"github.com/pkg/errors"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type permanentStoreImpl struct {
@@ -12,6 +15,8 @@ type permanentStoreImpl struct {
 
 var _ permanentStore = (*permanentStoreImpl)(nil)
 
+// NewPermanentStore returns the fcd_tx_log store, which also serves as the
+// tx log reader of the parser FCD source.
 func NewPermanentStore(db *gorm.DB) permanentStore {
 	return &permanentStoreImpl{db}
 }
@@ -44,11 +49,72 @@ func (p *permanentStoreImpl) Inserts(txLogs []schemas.FcdTxLog) error {
 	return nil
 }
 
-// TxLogsByHeight implements Columbus4Repository.
-func (p *permanentStoreImpl) TxLogsByHeight(height int) ([]schemas.FcdTxLog, error) {
+// PairAddresses implements permanentStore. It lists the pair contracts the
+// parser stored for chainID.
+func (p *permanentStoreImpl) PairAddresses(chainID string) ([]string, error) {
+	addrs := []string{}
+	if err := p.db.Model(&schemas.Pair{}).Where("chain_id = ?", chainID).Order("id").Pluck("contract", &addrs).Error; err != nil {
+		return nil, errors.Wrap(err, "permanentStoreImpl.PairAddresses")
+	}
+	return addrs, nil
+}
+
+// StartAddress implements permanentStore. It registers address as a target
+// of chainID, leaving the progress of an already registered one as is.
+func (p *permanentStoreImpl) StartAddress(chainID string, address string) error {
+	progress := schemas.FcdAddressProgress{ChainId: chainID, Address: address}
+	if err := p.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&progress).Error; err != nil {
+		return errors.Wrap(err, "permanentStoreImpl.StartAddress")
+	}
+	return nil
+}
+
+// CompleteAddress implements permanentStore. The history of address reaches
+// up to untilHeight, or up to its newest stored tx when untilHeight is
+// math.MaxUint32, i.e. unbounded.
+func (p *permanentStoreImpl) CompleteAddress(chainID string, address string, untilHeight uint32) error {
+	height := uint64(untilHeight)
+	if untilHeight == math.MaxUint32 {
+		if err := p.db.Model(&schemas.FcdTxLog{}).Where("address = ?", address).Select("COALESCE(MAX(height), 0)").Scan(&height).Error; err != nil {
+			return errors.Wrap(err, "permanentStoreImpl.CompleteAddress")
+		}
+	}
+	err := p.db.Model(&schemas.FcdAddressProgress{}).
+		Where("chain_id = ? AND address = ? AND NOT completed", chainID, address).
+		Updates(map[string]interface{}{
+			"completed":        true,
+			"collected_height": height,
+			"updated_at":       gorm.Expr("FLOOR(EXTRACT(EPOCH FROM now()))::int8"),
+		}).Error
+	if err != nil {
+		return errors.Wrap(err, "permanentStoreImpl.CompleteAddress")
+	}
+	return nil
+}
+
+// TxLogsByHeight implements permanentStore. It returns the tx logs of the
+// addresses collected for chainID only.
+func (p *permanentStoreImpl) TxLogsByHeight(chainID string, height int) ([]schemas.FcdTxLog, error) {
 	txs := []schemas.FcdTxLog{}
-	if err := p.db.Where("height = ?", height).Find(&txs).Error; err != nil {
-		return nil, errors.Wrap(err, "col4RepoImpl.TxLogsByHeight")
+	addrs := p.db.Model(&schemas.FcdAddressProgress{}).Select("address").Where("chain_id = ?", chainID)
+	if err := p.db.Where("height = ? AND address IN (?)", height, addrs).Find(&txs).Error; err != nil {
+		return nil, errors.Wrap(err, "permanentStoreImpl.TxLogsByHeight")
 	}
 	return txs, nil
 }
+
+// CollectedHeight implements permanentStore. It returns the lowest height the
+// histories of all addresses of chainID reach up to, or 0 while any of them is
+// still being collected down from the newest tx. Completed addresses without
+// a tx do not bound the height.
+func (p *permanentStoreImpl) CollectedHeight(chainID string) (uint64, error) {
+	height := uint64(0)
+	err := p.db.Model(&schemas.FcdAddressProgress{}).
+		Where("chain_id = ?", chainID).
+		Select("CASE WHEN BOOL_AND(completed) THEN COALESCE(MIN(NULLIF(collected_height, 0)), 0) ELSE 0 END").
+		Scan(&height).Error
+	if err != nil {
+		return 0, errors.Wrap(err, "permanentStoreImpl.CollectedHeight")
+	}
+	return height, nil
+}
@@ -0,0 +1,112 @@
+package collector
+
+import (
+	"sync"
+
+	"github.com/dezswap/cosmwasm-etl/parser"
+	"github.com/dezswap/cosmwasm-etl/parser/dex"
+	"github.com/pkg/errors"
+)
+
+// UnfilteredVersion is the filter version of blocks stored with every tx.
+const UnfilteredVersion = uint(0)
+
+// AddressFilterVersion identifies the rules of the address filter. Bump it
+// when the rules change so stale rows can be found and re-collected.
+const AddressFilterVersion = uint(1)
+
+// TxFilter drops txs the parser never reads before a height is stored.
+// Filter is called in height order.
+type TxFilter interface {
+	Version() uint
+	Filter(height uint64, txs parser.RawTxs) (parser.RawTxs, error)
+}
+
+// addressFilter keeps txs whose logs mention the factory, a pair, an LP token
+// or a CW20 asset of a pair. The watched set is loaded from the factory at the
+// first filtered height and reloaded whenever a create_pair action appears or
+// heights are not contiguous, as on backfills and fork rollbacks.
+type addressFilter struct {
+	factoryAddress string
+	pairs          dex.PairSource
+
+	mu         sync.Mutex
+	watched    map[string]bool
+	lastHeight uint64
+}
+
+var _ TxFilter = (*addressFilter)(nil)
+
+func NewAddressFilter(factoryAddress string, pairs dex.PairSource) TxFilter {
+	return &addressFilter{factoryAddress: factoryAddress, pairs: pairs}
+}
+
+func (f *addressFilter) Version() uint {
+	return AddressFilterVersion
+}
+
+func (f *addressFilter) Filter(height uint64, txs parser.RawTxs) (parser.RawTxs, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.watched == nil || height != f.lastHeight+1 || f.hasCreatePair(txs) {
+		if err := f.load(height); err != nil {
+			return nil, err
+		}
+	}
+	f.lastHeight = height
+
+	filtered := parser.RawTxs{}
+	for _, tx := range txs {
+		if f.mentionsWatched(tx) {
+			filtered = append(filtered, tx)
+		}
+	}
+	return filtered, nil
+}
+
+func (f *addressFilter) load(height uint64) error {
+	pairs, err := f.pairs.GetPairs(height)
+	if err != nil {
+		return errors.Wrapf(err, "addressFilter.load(%d)", height)
+	}
+
+	watched := map[string]bool{f.factoryAddress: true}
+	for _, pair := range pairs {
+		watched[pair.ContractAddr] = true
+		watched[pair.LpAddr] = true
+		for _, asset := range pair.Assets {
+			watched[asset] = true
+		}
+	}
+	delete(watched, "")
+	f.watched = watched
+	return nil
+}
+
+func (f *addressFilter) hasCreatePair(txs parser.RawTxs) bool {
+	for _, tx := range txs {
+		factoryCalled, createPair := false, false
+		for _, log := range tx.LogResults {
+			for _, attr := range log.Attributes {
+				factoryCalled = factoryCalled || attr.Value == f.factoryAddress
+				createPair = createPair || (attr.Key == "action" && attr.Value == "create_pair")
+			}
+		}
+		if factoryCalled && createPair {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *addressFilter) mentionsWatched(tx parser.RawTx) bool {
+	for _, log := range tx.LogResults {
+		for _, attr := range log.Attributes {
+			if f.watched[attr.Value] {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,74 @@
+package collector
+
+import (
+	"testing"
+
+	"github.com/dezswap/cosmwasm-etl/parser"
+	"github.com/dezswap/cosmwasm-etl/parser/dex"
+	"github.com/dezswap/cosmwasm-etl/pkg/eventlog"
+	"github.com/stretchr/testify/require"
+)
+
+type pairSourceMock struct {
+	pairs   []dex.Pair
+	heights []uint64
+}
+
+func (m *pairSourceMock) GetPairs(height uint64) ([]dex.Pair, error) {
+	m.heights = append(m.heights, height)
+	return m.pairs, nil
+}
+
+func txWithAttrs(hash string, attrs ...eventlog.Attribute) parser.RawTx {
+	return parser.RawTx{
+		Hash:       hash,
+		LogResults: eventlog.LogResults{{Type: eventlog.WasmType, Attributes: attrs}},
+	}
+}
+
+func TestAddressFilterKeepsWatchedTxs(t *testing.T) {
+	pairs := &pairSourceMock{pairs: []dex.Pair{{ContractAddr: "pair", LpAddr: "lp", Assets: []string{"token", "uluna"}}}}
+	filter := NewAddressFilter("factory", pairs)
+
+	filtered, err := filter.Filter(10, parser.RawTxs{
+		txWithAttrs("swap", eventlog.Attribute{Key: "_contract_address", Value: "pair"}),
+		txWithAttrs("lp", eventlog.Attribute{Key: "_contract_address", Value: "lp"}),
+		txWithAttrs("other", eventlog.Attribute{Key: "_contract_address", Value: "other"}),
+		txWithAttrs("factory", eventlog.Attribute{Key: "_contract_address", Value: "factory"}),
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, AddressFilterVersion, filter.Version())
+	require.Len(t, filtered, 3)
+	require.Equal(t, "swap", filtered[0].Hash)
+	require.Equal(t, "lp", filtered[1].Hash)
+	require.Equal(t, "factory", filtered[2].Hash)
+	require.Equal(t, []uint64{10}, pairs.heights)
+}
+
+func TestAddressFilterReloadsOnCreatePairAndHeightJump(t *testing.T) {
+	pairs := &pairSourceMock{}
+	filter := NewAddressFilter("factory", pairs)
+
+	_, err := filter.Filter(10, parser.RawTxs{})
+	require.NoError(t, err)
+	_, err = filter.Filter(11, parser.RawTxs{})
+	require.NoError(t, err)
+	require.Equal(t, []uint64{10}, pairs.heights)
+
+	pairs.pairs = []dex.Pair{{ContractAddr: "new_pair", LpAddr: "new_lp"}}
+	filtered, err := filter.Filter(12, parser.RawTxs{
+		txWithAttrs("create",
+			eventlog.Attribute{Key: "_contract_address", Value: "factory"},
+			eventlog.Attribute{Key: "action", Value: "create_pair"},
+		),
+		txWithAttrs("provide", eventlog.Attribute{Key: "_contract_address", Value: "new_pair"}),
+	})
+	require.NoError(t, err)
+	require.Len(t, filtered, 2)
+	require.Equal(t, []uint64{10, 12}, pairs.heights)
+
+	_, err = filter.Filter(20, parser.RawTxs{})
+	require.NoError(t, err)
+	require.Equal(t, []uint64{10, 12, 20}, pairs.heights)
+}
@@ -8,6 +8,18 @@ const (
 	defaultCollectorStartHeight          = 1
 	defaultCollectorPollInterval         = 5
 	defaultCollectorPoolSnapshotInterval = 1000
+	defaultCollectorConcurrency          = 1
+	defaultPoolSnapshotBatchSize         = 50
+	defaultPoolSnapshotMaxRetries        = 3
+	defaultPoolSnapshotRetryIntervalMs   = 500
+	defaultFcdConcurrency                = 1
+	defaultFcdMaxRetries                 = 3
+	defaultFcdPageSize                   = 1000
+)
+
+const (
+	FcdTargetSourceConfig = "config"
+	FcdTargetSourcePair   = "pair"
 )
 
 type CollectorConfig struct {
@@ -19,11 +31,86 @@ type CollectorConfig struct {
 	UntilHeight                uint64     `mapstructure:"until_height"`
 	PollIntervalSec            uint64     `mapstructure:"poll_interval_sec"`
 	PoolSnapshotInterval       uint       `mapstructure:"pool_snapshot_interval"`
+	// Concurrency is the number of heights fetched from the source at once.
+	// WindowSize bounds how far fetching may run ahead of the last committed
+	// height; zero means the window equals Concurrency.
+	Concurrency uint `mapstructure:"concurrency"`
+	WindowSize  uint `mapstructure:"window_size"`
+	// RollbackOnFork rewinds collector and parser rows to the last common
+	// block when a parent hash mismatch is detected instead of stopping.
+	RollbackOnFork bool `mapstructure:"rollback_on_fork"`
+	// ParserDb is the database of the parser rows rewound on a fork and of
+	// the parser cursors that bound prune. An empty host means the parser
+	// shares rdb with the collector.
+	ParserDb RdbConfig `mapstructure:"parser_db"`
+	// GrpcServerPort serves collected rows to remote parsers over gRPC when
+	// greater than 0.
+	GrpcServerPort int `mapstructure:"grpc_server_port"`
+	// TxFilter stores only txs whose logs mention the factory, its pairs, LP
+	// tokens or CW20 assets instead of every tx of a block.
+	TxFilter bool `mapstructure:"tx_filter"`
+	// PoolSnapshot spreads the per-pair pool queries of a snapshot height
+	// over several workers.
+	PoolSnapshot PoolSnapshotConfig `mapstructure:"pool_snapshot"`
+	// Retention bounds the rows the prune command removes.
+	Retention RetentionConfig `mapstructure:"retention"`
+}
+
+type PoolSnapshotConfig struct {
+	// Concurrency is the number of pair batches queried at once. Zero keeps
+	// the sequential GetPoolInfos of the source.
+	Concurrency uint `mapstructure:"concurrency"`
+	// BatchSize is the number of pairs a worker takes at a time, queried in
+	// one request when the node source supports it.
+	BatchSize uint `mapstructure:"batch_size"`
+	// MaxRetries is the number of extra attempts per pair before the
+	// snapshot height fails.
+	MaxRetries      uint   `mapstructure:"max_retries"`
+	RetryIntervalMs uint64 `mapstructure:"retry_interval_ms"`
+}
+
+type RetentionConfig struct {
+	// PruneBelowHeight is the height below which collector rows may be
+	// removed. The parser cursors lower it further; zero prunes nothing.
+	PruneBelowHeight uint64 `mapstructure:"prune_below_height"`
+	// ArchivedOnly keeps blocks that are not yet in the collector_block
+	// archive.
+	ArchivedOnly bool `mapstructure:"archived_only"`
 }
 
 type FcdConfig struct {
 	Url             string   `mapstructure:"url"`
 	TargetAddresses []string `mapstructure:"target_addresses"`
+	// TargetSource selects the addresses whose history is collected: "config"
+	// reads TargetAddresses and "pair" reads the parser pair table of
+	// collector.chainid.
+	TargetSource string `mapstructure:"target_source"`
+	// Concurrency is the number of addresses collected at once, and
+	// RequestsPerSec caps the FCD requests of all of them; zero is unlimited.
+	Concurrency    uint `mapstructure:"concurrency"`
+	RequestsPerSec uint `mapstructure:"requests_per_sec"`
+	// MaxRetries is the number of extra attempts per address.
+	MaxRetries uint `mapstructure:"max_retries"`
+	// PageSize is the number of txs fetched before each insert.
+	PageSize uint32 `mapstructure:"page_size"`
+}
+
+func (c FcdConfig) Validate() error {
+	if c.Url == "" {
+		return fmt.Errorf("missing FCD url: set collector.fcd.url")
+	}
+
+	switch c.TargetSource {
+	case FcdTargetSourceConfig, FcdTargetSourcePair:
+	default:
+		return fmt.Errorf("invalid FCD target source %q: set collector.fcd.target_source to %s or %s", c.TargetSource, FcdTargetSourceConfig, FcdTargetSourcePair)
+	}
+
+	if c.Concurrency == 0 {
+		return fmt.Errorf("invalid FCD concurrency: set collector.fcd.concurrency to a value greater than 0")
+	}
+
+	return nil
 }
 
 func defaultCollectorConfig() CollectorConfig {
@@ -31,10 +118,30 @@ func defaultCollectorConfig() CollectorConfig {
 		NodeConfig: NodeConfig{
 			HttpClientConfig: defaultHttpClientConfig,
 		},
+		FcdConfig: FcdConfig{
+			TargetSource: FcdTargetSourceConfig,
+			Concurrency:  defaultFcdConcurrency,
+			MaxRetries:   defaultFcdMaxRetries,
+			PageSize:     defaultFcdPageSize,
+		},
 		StartHeight:          defaultCollectorStartHeight,
 		PollIntervalSec:      defaultCollectorPollInterval,
 		PoolSnapshotInterval: defaultCollectorPoolSnapshotInterval,
+		Concurrency:          defaultCollectorConcurrency,
+		PoolSnapshot: PoolSnapshotConfig{
+			BatchSize:       defaultPoolSnapshotBatchSize,
+			MaxRetries:      defaultPoolSnapshotMaxRetries,
+			RetryIntervalMs: defaultPoolSnapshotRetryIntervalMs,
+		},
+	}
+}
+
+// ParserRdb returns ParserDb, or rdb when the parser shares it.
+func (c CollectorConfig) ParserRdb(rdb RdbConfig) RdbConfig {
+	if c.ParserDb.Host == "" {
+		return rdb
 	}
+	return c.ParserDb
 }
 
 func (c CollectorConfig) Validate() error {
@@ -62,5 +169,17 @@ func (c CollectorConfig) Validate() error {
 		return fmt.Errorf("invalid poll interval: set collector.poll_interval_sec to a value greater than 0")
 	}
 
+	if c.Concurrency == 0 {
+		return fmt.Errorf("invalid concurrency: set collector.concurrency to a value greater than 0")
+	}
+
+	if c.WindowSize > 0 && c.WindowSize < c.Concurrency {
+		return fmt.Errorf("invalid window size: set collector.window_size to a value not less than collector.concurrency")
+	}
+
+	if c.PoolSnapshot.Concurrency > 0 && c.PoolSnapshot.BatchSize == 0 {
+		return fmt.Errorf("invalid pool snapshot batch size: set collector.pool_snapshot.batch_size to a value greater than 0")
+	}
+
 	return nil
 }
@@ -124,6 +124,7 @@ func (c Config) Redacted() Config {
 
 	// Collector
 	cp.S3.Secret = "***"
+	cp.Collector.ParserDb.Password = "***"
 
 	// Parser
 	cp.Rdb.Password = "***"
@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/dezswap/cosmwasm-etl/pkg/dex"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/require"
 )
@@ -240,6 +241,8 @@ func Test_CollectorConfig_EnvVars(t *testing.T) {
 	t.Setenv("APP_COLLECTOR_UNTIL_HEIGHT", "1000")
 	t.Setenv("APP_COLLECTOR_POLL_INTERVAL_SEC", "7")
 	t.Setenv("APP_COLLECTOR_POOL_SNAPSHOT_INTERVAL", "50")
+	t.Setenv("APP_COLLECTOR_CONCURRENCY", "8")
+	t.Setenv("APP_COLLECTOR_WINDOW_SIZE", "32")
 
 	tmp := t.TempDir()
 	defer withTestBasepath(t, tmp)()
@@ -258,6 +261,8 @@ func Test_CollectorConfig_EnvVars(t *testing.T) {
 	require.Equal(t, uint64(1000), col.UntilHeight)
 	require.Equal(t, uint64(7), col.PollIntervalSec)
 	require.Equal(t, uint(50), col.PoolSnapshotInterval)
+	require.Equal(t, uint(8), col.Concurrency)
+	require.Equal(t, uint(32), col.WindowSize)
 }
 
 func Test_CollectorConfig_Defaults(t *testing.T) {
@@ -271,6 +276,15 @@ func Test_CollectorConfig_Defaults(t *testing.T) {
 	require.Equal(t, uint64(defaultCollectorStartHeight), col.StartHeight)
 	require.Equal(t, uint64(defaultCollectorPollInterval), col.PollIntervalSec)
 	require.Equal(t, uint(defaultCollectorPoolSnapshotInterval), col.PoolSnapshotInterval)
+	require.Equal(t, uint(defaultCollectorConcurrency), col.Concurrency)
+	require.Zero(t, col.WindowSize)
+	require.Zero(t, col.PoolSnapshot.Concurrency)
+	require.Equal(t, uint(defaultPoolSnapshotBatchSize), col.PoolSnapshot.BatchSize)
+	require.Equal(t, uint(defaultPoolSnapshotMaxRetries), col.PoolSnapshot.MaxRetries)
+	require.Equal(t, FcdTargetSourceConfig, col.FcdConfig.TargetSource)
+	require.Equal(t, uint(defaultFcdConcurrency), col.FcdConfig.Concurrency)
+	require.NoError(t, FcdConfig{Url: "https://fcd.example.com", TargetSource: FcdTargetSourcePair, Concurrency: 4}.Validate())
+	require.Error(t, FcdConfig{Url: "https://fcd.example.com", TargetSource: "table", Concurrency: 4}.Validate())
 }
 
 func Test_CollectorConfig_Validate(t *testing.T) {
@@ -283,6 +297,7 @@ func Test_CollectorConfig_Validate(t *testing.T) {
 		}},
 		StartHeight:     1,
 		PollIntervalSec: 1,
+		Concurrency:     1,
 	}
 
 	testCases := []struct {
@@ -321,6 +336,22 @@ func Test_CollectorConfig_Validate(t *testing.T) {
 			config.PollIntervalSec = 0
 			return config
 		}(), expected: "invalid poll interval: set collector.poll_interval_sec to a value greater than 0"},
+		{name: "zero concurrency", config: func() CollectorConfig {
+			config := valid
+			config.Concurrency = 0
+			return config
+		}(), expected: "invalid concurrency: set collector.concurrency to a value greater than 0"},
+		{name: "window smaller than concurrency", config: func() CollectorConfig {
+			config := valid
+			config.Concurrency = 4
+			config.WindowSize = 2
+			return config
+		}(), expected: "invalid window size: set collector.window_size to a value not less than collector.concurrency"},
+		{name: "pool snapshot without batch size", config: func() CollectorConfig {
+			config := valid
+			config.PoolSnapshot.Concurrency = 4
+			return config
+		}(), expected: "invalid pool snapshot batch size: set collector.pool_snapshot.batch_size to a value greater than 0"},
 	}
 
 	for _, testCase := range testCases {
@@ -389,6 +420,45 @@ func Test_ParserConfig_QuarantineRetryMode(t *testing.T) {
 	require.EqualError(t, config.Validate(), "invalid quarantine retry mode(sometimes)")
 }
 
+func Test_ParserConfig_FcdSource(t *testing.T) {
+	config := ParserDexConfig{
+		ChainId:        "columbus-4",
+		FactoryAddress: "terra1factory",
+		TargetApp:      "terraswap",
+		FcdSource:      true,
+	}
+	require.NoError(t, config.Validate())
+
+	config.TargetApp = "dezswap"
+	require.EqualError(t, config.Validate(), "fcd source is not supported by target app(dezswap)")
+}
+
+func Test_ParserConfig_TargetApp(t *testing.T) {
+	config := ParserDexConfig{
+		ChainId:        "phoenix-1",
+		FactoryAddress: "terra1factory",
+	}
+	for _, app := range []dex.DexType{dex.Terraswap, dex.Dezswap, dex.Starfleit, dex.Astroport} {
+		config.TargetApp = app
+		require.NoError(t, config.Validate())
+	}
+
+	config.TargetApp = "uniswap"
+	require.EqualError(t, config.Validate(), "unknown target app(uniswap)")
+}
+
+func Test_ParserConfig_GenericSpecFile(t *testing.T) {
+	config := ParserDexConfig{
+		ChainId:        "phoenix-1",
+		FactoryAddress: "terra1factory",
+		TargetApp:      dex.Generic,
+	}
+	require.EqualError(t, config.Validate(), "spec file is required by target app(generic)")
+
+	config.SpecFile = "fork.yaml"
+	require.NoError(t, config.Validate())
+}
+
 func Test_ParserConfig_QuarantineRetryModeDefault(t *testing.T) {
 	t.Setenv("APP_LOG_ENV", "local")
 	t.Setenv("APP_LOG_CHAINID", "testnet-1")
@@ -30,6 +30,10 @@ type GrpcConfig struct {
 type RestClientConfig struct {
 	LcdHost string `mapstructure:"lcd"`
 	RpcHost string `mapstructure:"rpc"`
+	// WebsocketHost is the CometBFT websocket endpoint, e.g.
+	// wss://rpc.example.com/websocket. When set, new heights are taken from
+	// NewBlock events instead of polling.
+	WebsocketHost string `mapstructure:"websocket"`
 }
 
 // Duration is a wrapper type for automatic string → time.Duration unmarshalling via mapstructure.
@@ -33,12 +33,41 @@ type ParserDexConfig struct {
 	ValidationInterval   uint                `mapstructure:"validationinterval"`
 	QuarantineRetryMode  QuarantineRetryMode `mapstructure:"quarantineretrymode"`
 	NodeConfig           NodeConfig          `mapstructure:"node"`
+	// CollectorGrpc points the parser at a remote collector gRPC API instead
+	// of reading collector data directly when Host is set.
+	CollectorGrpc GrpcConfig `mapstructure:"collectorgrpc"`
+	// FcdSource replays tx logs collected from FCD (fcd_tx_log) and collector
+	// pool snapshots instead of reading a node. Terraswap only.
+	FcdSource bool `mapstructure:"fcdsource"`
+	// SpecFile is the YAML or JSON spec of a generic target app.
+	SpecFile string `mapstructure:"specfile"`
+	// RouterAddr is the router contract (the aggregator RouterConfig.RouterAddr)
+	// whose multi-hop swaps are grouped by a route id in ParsedTx.Meta.
+	RouterAddr string `mapstructure:"routeraddr"`
+	// PrefetchDepth is how many heights of source txs are fetched concurrently
+	// ahead of parsing, 0 fetches each height when it is parsed.
+	PrefetchDepth uint `mapstructure:"prefetchdepth"`
+	// CommitBatchSize is how many parsed heights are written per transaction,
+	// 0 and 1 write every height on its own.
+	CommitBatchSize uint `mapstructure:"commitbatchsize"`
+	// BisectValidationFailures narrows an asset amount mismatch of the
+	// validation worker down to the height it was introduced at and logs it.
+	BisectValidationFailures bool `mapstructure:"bisectvalidationfailures"`
 }
 
 func (c ParserDexConfig) Validate() error {
 	if c.ChainId == "" || c.FactoryAddress == "" || c.TargetApp == dex.Unknown {
 		return errors.New("required field is missing.")
 	}
+	if dex.ToDexType(string(c.TargetApp)) == dex.Unknown {
+		return errors.Errorf("unknown target app(%s)", c.TargetApp)
+	}
+	if c.TargetApp == dex.Generic && c.SpecFile == "" {
+		return errors.New("spec file is required by target app(generic)")
+	}
+	if c.FcdSource && c.TargetApp != dex.Terraswap {
+		return errors.Errorf("fcd source is not supported by target app(%s)", c.TargetApp)
+	}
 	if c.QuarantineRetryMode == "" {
 		return nil
 	}
@@ -5,4 +5,10 @@ type S3Config struct {
 	Region string `mapstructure:"region"`
 	Key    string `mapstructure:"key"`
 	Secret string `mapstructure:"secret"`
+	// LocalDir replaces the bucket with a local directory using the same
+	// object layout, for air-gapped runs and fixture-based tests.
+	LocalDir string `mapstructure:"localdir"`
+	// Archive reads blocks and pool statuses from compressed segments under
+	// <chainId>/archive instead of one file per height.
+	Archive bool `mapstructure:"archive"`
 }
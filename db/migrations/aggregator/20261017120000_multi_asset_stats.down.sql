@@ -0,0 +1,11 @@
+BEGIN;
+
+ALTER TABLE pair_stats_30m
+    DROP COLUMN IF EXISTS extra_commissions,
+    DROP COLUMN IF EXISTS extra_liquidities,
+    DROP COLUMN IF EXISTS extra_volumes;
+
+ALTER TABLE lp_history
+    DROP COLUMN IF EXISTS extra_liquidities;
+
+COMMIT;
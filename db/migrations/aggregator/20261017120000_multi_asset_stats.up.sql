@@ -0,0 +1,13 @@
+BEGIN;
+
+-- Values of the assets after asset1 of pools with more than two assets, in
+-- pool order. Two asset pools keep these columns NULL.
+ALTER TABLE lp_history
+    ADD COLUMN IF NOT EXISTS extra_liquidities numeric[];
+
+ALTER TABLE pair_stats_30m
+    ADD COLUMN IF NOT EXISTS extra_volumes numeric[],
+    ADD COLUMN IF NOT EXISTS extra_liquidities numeric[],
+    ADD COLUMN IF NOT EXISTS extra_commissions numeric[];
+
+COMMIT;
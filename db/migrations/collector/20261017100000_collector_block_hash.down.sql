@@ -0,0 +1,7 @@
+BEGIN;
+
+ALTER TABLE "public"."collector_blocks"
+    DROP COLUMN IF EXISTS "parent_hash",
+    DROP COLUMN IF EXISTS "block_hash";
+
+COMMIT;
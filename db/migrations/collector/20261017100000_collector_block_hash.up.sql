@@ -0,0 +1,10 @@
+BEGIN;
+
+ALTER TABLE "public"."collector_blocks"
+    ADD COLUMN IF NOT EXISTS "block_hash" varchar NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS "parent_hash" varchar NOT NULL DEFAULT '';
+
+COMMENT ON COLUMN collector_blocks.block_hash IS 'Hash of the collected block. Empty when the source could not report it.';
+COMMENT ON COLUMN collector_blocks.parent_hash IS 'Hash of the previous block, checked against block_hash of height - 1 on save.';
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE "public"."collector_blocks"
+    DROP COLUMN IF EXISTS "filter_version";
+
+COMMIT;
@@ -0,0 +1,8 @@
+BEGIN;
+
+ALTER TABLE "public"."collector_blocks"
+    ADD COLUMN IF NOT EXISTS "filter_version" integer NOT NULL DEFAULT 0;
+
+COMMENT ON COLUMN collector_blocks.filter_version IS 'Version of the tx filter applied to txs. 0 keeps every tx of the block.';
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+DROP TABLE IF EXISTS "public"."fcd_address_progress";
+
+COMMIT;
@@ -0,0 +1,17 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS "public"."fcd_address_progress" (
+    "chain_id" varchar NOT NULL,
+    "address" varchar NOT NULL,
+    "completed" boolean NOT NULL DEFAULT false,
+    "collected_height" int8 NOT NULL DEFAULT 0,
+    "created_at" int8 NOT NULL DEFAULT FLOOR(EXTRACT(EPOCH FROM now()))::int8,
+    "updated_at" int8 NOT NULL DEFAULT FLOOR(EXTRACT(EPOCH FROM now()))::int8,
+    PRIMARY KEY ("chain_id", "address")
+);
+
+COMMENT ON TABLE fcd_address_progress IS 'FCD collection targets of a chain. fcd_tx_log rows of a chain are those of its addresses here.';
+COMMENT ON COLUMN fcd_address_progress.completed IS 'Whether the address history has been collected down to its first tx.';
+COMMENT ON COLUMN fcd_address_progress.collected_height IS 'Height the complete history reaches up to. 0 until completed.';
+
+COMMIT;
@@ -0,0 +1,13 @@
+BEGIN;
+
+DROP INDEX IF EXISTS pair_chain_id_asset0_asset1_extra_assets_key;
+CREATE UNIQUE INDEX pair_chain_id_asset0_asset1_key ON pair ("chain_id", "asset0", "asset1");
+
+ALTER TABLE pool_info DROP COLUMN IF EXISTS extra_asset_amounts;
+ALTER TABLE parsed_tx
+  DROP COLUMN IF EXISTS extra_commission_amounts,
+  DROP COLUMN IF EXISTS extra_asset_amounts,
+  DROP COLUMN IF EXISTS extra_assets;
+ALTER TABLE pair DROP COLUMN IF EXISTS extra_assets;
+
+COMMIT;
@@ -0,0 +1,16 @@
+BEGIN;
+
+-- Assets after asset1 of pools with more than two assets, in pool order.
+-- Two asset pools keep these columns NULL.
+ALTER TABLE pair ADD COLUMN IF NOT EXISTS extra_assets VARCHAR[];
+ALTER TABLE parsed_tx
+  ADD COLUMN IF NOT EXISTS extra_assets VARCHAR[],
+  ADD COLUMN IF NOT EXISTS extra_asset_amounts DECIMAL(40)[],
+  ADD COLUMN IF NOT EXISTS extra_commission_amounts DECIMAL(40)[];
+ALTER TABLE pool_info ADD COLUMN IF NOT EXISTS extra_asset_amounts DECIMAL(40)[];
+
+-- a multi asset pool may share its first two assets with a two asset pool
+DROP INDEX IF EXISTS pair_chain_id_asset0_asset1_key;
+CREATE UNIQUE INDEX pair_chain_id_asset0_asset1_extra_assets_key ON pair ("chain_id", "asset0", "asset1", COALESCE("extra_assets", '{}'));
+
+COMMIT;
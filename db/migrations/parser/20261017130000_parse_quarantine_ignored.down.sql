@@ -0,0 +1,11 @@
+BEGIN;
+
+UPDATE parse_quarantine SET "status" = 'pending', "resolved_at" = NULL WHERE "status" = 'ignored';
+
+ALTER TABLE parse_quarantine DROP CONSTRAINT IF EXISTS parse_quarantine_status_check;
+ALTER TABLE parse_quarantine
+  ADD CONSTRAINT parse_quarantine_status_check CHECK ("status" IN ('pending', 'resolved'));
+
+ALTER TABLE parse_quarantine DROP COLUMN IF EXISTS "reason";
+
+COMMIT;
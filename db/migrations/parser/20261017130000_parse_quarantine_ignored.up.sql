@@ -0,0 +1,10 @@
+BEGIN;
+
+-- quarantines closed by hand keep the reason they were resolved or ignored
+ALTER TABLE parse_quarantine ADD COLUMN IF NOT EXISTS "reason" TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE parse_quarantine DROP CONSTRAINT IF EXISTS parse_quarantine_status_check;
+ALTER TABLE parse_quarantine
+  ADD CONSTRAINT parse_quarantine_status_check CHECK ("status" IN ('pending', 'resolved', 'ignored'));
+
+COMMIT;
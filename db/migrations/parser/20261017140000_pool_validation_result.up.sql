@@ -0,0 +1,19 @@
+BEGIN;
+
+-- the latest pool validation result of each validation height
+CREATE TABLE "pool_validation_result" (
+  "id"             BIGSERIAL NOT NULL PRIMARY KEY,
+  "chain_id"       VARCHAR NOT NULL, CHECK("chain_id" <> ''),
+  "height"         BIGINT NOT NULL, CHECK("height" > 0),
+  "status"         VARCHAR NOT NULL,
+  "mismatch_count" INTEGER NOT NULL DEFAULT 0,
+  "mismatches"     JSONB NOT NULL DEFAULT '[]',
+  "checked_at"     DOUBLE PRECISION NOT NULL DEFAULT EXTRACT(EPOCH FROM NOW()),
+  CONSTRAINT pool_validation_result_chain_id_height_key UNIQUE ("chain_id", "height"),
+  CONSTRAINT pool_validation_result_status_check CHECK ("status" IN ('passed', 'failed', 'skipped'))
+);
+
+CREATE INDEX pool_validation_result_chain_id_height_status_idx
+  ON pool_validation_result ("chain_id", "status", "height");
+
+COMMIT;
@@ -0,0 +1 @@
+ALTER TABLE synced_height DROP COLUMN IF EXISTS reparse_synced_height;
@@ -0,0 +1,2 @@
+ALTER TABLE synced_height ADD COLUMN IF NOT EXISTS reparse_synced_height BIGINT NULL DEFAULT NULL;
+COMMENT ON COLUMN synced_height.reparse_synced_height IS 'Synced height before an unfinished parser-reparse. NULL means no reparse is pending; otherwise the synced height is restored to this value once the reparse reaches it.';
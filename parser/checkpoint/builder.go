@@ -1,11 +1,13 @@
 package checkpoint
 
 import (
-	"fmt"
-	"github.com/dezswap/cosmwasm-etl/parser/dex"
-	"github.com/pkg/errors"
 	"math/big"
 	"time"
+
+	"github.com/dezswap/cosmwasm-etl/parser/dex"
+	"github.com/dezswap/cosmwasm-etl/pkg/logging"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 )
 
 type asset struct {
@@ -13,41 +15,76 @@ type asset struct {
 	Amount *big.Int
 }
 
+// Checkpoint is the data a checkpoint inserts to move the parsed pools from
+// DbHeight to the source pools of TargetHeight.
+type Checkpoint struct {
+	DbHeight     uint64         `json:"dbHeight"`
+	TargetHeight uint64         `json:"targetHeight"`
+	Txs          []dex.ParsedTx `json:"txs"`
+	Pools        []dex.PoolInfo `json:"pools"`
+	Pairs        []dex.Pair     `json:"pairs"`
+}
+
 type Builder struct {
-	repo dex.Repo
-	ds   dex.SourceDataStore
+	repo   dex.Repo
+	ds     dex.SourceDataStore
+	logger logging.Logger
 }
 
-func NewBuilder(repo dex.Repo, ds dex.SourceDataStore) *Builder {
+func NewBuilder(repo dex.Repo, ds dex.SourceDataStore, logger logging.Logger) *Builder {
 	return &Builder{
-		repo: repo,
-		ds:   ds,
+		repo:   repo,
+		ds:     ds,
+		logger: logger,
 	}
 }
 
 func (b *Builder) Build(targetHeight uint64) error {
-	dbHeight, err := b.validateAndGetDbHeight(targetHeight)
-	if err != nil {
-		return errors.Wrap(err, "failed to check heights")
-	}
-
-	txs, pools, pairs, err := b.generateCheckpointData(targetHeight)
+	cp, err := b.Preview(targetHeight)
 	if err != nil {
-		return errors.Wrap(err, "failed to generate checkpoint data")
+		return err
 	}
 
-	if len(txs) == 0 {
-		fmt.Printf("No changes detected between heights(%d - %d).\n", dbHeight, targetHeight)
+	if len(cp.Txs) == 0 {
+		b.logger.Infof("No changes detected between heights(%d - %d).", cp.DbHeight, cp.TargetHeight)
 	}
 
 	// Save checkpoint data (transactions, pools, pairs) to database
-	if err := b.repo.Insert(dbHeight, targetHeight, txs, pools, pairs, []dex.ParseQuarantine{}); err != nil {
+	if err := b.repo.Insert(cp.DbHeight, cp.TargetHeight, cp.Txs, cp.Pools, cp.Pairs, []dex.ParseQuarantine{}); err != nil {
 		return errors.Wrap(err, "failed to insert data")
 	}
+	b.logger.WithFields(logrus.Fields{
+		"db_height":     cp.DbHeight,
+		"target_height": cp.TargetHeight,
+		"tx_count":      len(cp.Txs),
+		"pair_count":    len(cp.Pairs),
+	}).Info("checkpoint saved")
 
 	return nil
 }
 
+// Preview returns the checkpoint Build would insert for targetHeight without
+// inserting it.
+func (b *Builder) Preview(targetHeight uint64) (Checkpoint, error) {
+	dbHeight, err := b.validateAndGetDbHeight(targetHeight)
+	if err != nil {
+		return Checkpoint{}, errors.Wrap(err, "failed to check heights")
+	}
+
+	txs, pools, pairs, err := b.generateCheckpointData(targetHeight)
+	if err != nil {
+		return Checkpoint{}, errors.Wrap(err, "failed to generate checkpoint data")
+	}
+
+	return Checkpoint{
+		DbHeight:     dbHeight,
+		TargetHeight: targetHeight,
+		Txs:          txs,
+		Pools:        pools,
+		Pairs:        pairs,
+	}, nil
+}
+
 func (b *Builder) validateAndGetDbHeight(targetHeight uint64) (uint64, error) {
 	dbHeight, err := b.repo.GetSyncedHeight()
 	if err != nil {
@@ -111,7 +148,7 @@ func (b *Builder) generateCheckpointData(targetHeight uint64) ([]dex.ParsedTx, [
 		if tx.Type == dex.CreatePair {
 			pairs = append(pairs, dex.Pair{
 				ContractAddr: tx.ContractAddr,
-				Assets:       []string{tx.Assets[0].Addr, tx.Assets[1].Addr},
+				Assets:       tx.AssetAddrs(),
 				LpAddr:       tx.LpAddr,
 			})
 		}
@@ -125,8 +162,8 @@ func createDiffTx(src, rdb dex.PoolInfo) (*dex.ParsedTx, error) {
 	if err != nil {
 		return nil, err
 	}
-	if len(assetDiff) != 2 {
-		return nil, errors.New("asset slice must contain exactly 2 assets")
+	if len(assetDiff) < 2 || len(assetDiff) != len(src.Assets) {
+		return nil, errors.New("asset slice must contain every pool asset")
 	}
 	isAssetDiffZero := true
 	isAssetDiffPositiveAll := true
@@ -176,17 +213,18 @@ func createDiffTx(src, rdb dex.PoolInfo) (*dex.ParsedTx, error) {
 		}
 	}
 
-	return &dex.ParsedTx{
+	tx := &dex.ParsedTx{
 		Hash:             "-",
 		Timestamp:        time.Now(),
 		Type:             txType,
 		Sender:           "-",
 		ContractAddr:     src.ContractAddr,
-		Assets:           [2]dex.Asset(ToDexAssets(assetDiff)),
 		LpAddr:           src.LpAddr,
 		LpAmount:         totalShareDiff.String(),
 		CommissionAmount: "0",
-	}, nil
+	}
+	tx.SetAssets(ToDexAssets(assetDiff))
+	return tx, nil
 }
 
 func calculateAssetDiff(src, rdb []dex.Asset) ([]asset, error) {
@@ -226,21 +264,20 @@ func ToDexAssets(assets []asset) []dex.Asset {
 }
 
 func createNewPairTxs(pi dex.PoolInfo) []dex.ParsedTx {
-	var emptyAssets [2]dex.Asset
+	emptyAssets := make([]dex.Asset, len(pi.Assets))
 	for i, a := range pi.Assets {
 		emptyAssets[i] = dex.Asset{
 			Addr: a.Addr, Amount: "0",
 		}
 	}
 
-	return []dex.ParsedTx{
+	txs := []dex.ParsedTx{
 		{
 			Hash:             "-",
 			Timestamp:        time.Now(),
 			Type:             dex.CreatePair,
 			Sender:           "-",
 			ContractAddr:     pi.ContractAddr,
-			Assets:           emptyAssets,
 			LpAddr:           pi.LpAddr,
 			LpAmount:         "0",
 			CommissionAmount: "0",
@@ -251,10 +288,12 @@ func createNewPairTxs(pi dex.PoolInfo) []dex.ParsedTx {
 			Type:             dex.InitialProvide,
 			Sender:           "-",
 			ContractAddr:     pi.ContractAddr,
-			Assets:           [2]dex.Asset(pi.Assets),
 			LpAddr:           pi.LpAddr,
 			LpAmount:         pi.TotalShare,
 			CommissionAmount: "0",
 		},
 	}
+	txs[0].SetAssets(emptyAssets)
+	txs[1].SetAssets(pi.Assets)
+	return txs
 }
@@ -7,6 +7,7 @@ import (
 
 	"github.com/dezswap/cosmwasm-etl/parser"
 	"github.com/dezswap/cosmwasm-etl/parser/dex"
+	"github.com/dezswap/cosmwasm-etl/pkg/logging"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -14,6 +15,7 @@ import (
 type MockRepo struct {
 	syncedHeight uint64
 	poolInfos    []dex.PoolInfo
+	insertedTxs  []dex.ParsedTx
 }
 
 func (m *MockRepo) GetSyncedHeight() (uint64, error) {
@@ -24,7 +26,12 @@ func (m *MockRepo) ParsedPoolsInfo(_, _ uint64) ([]dex.PoolInfo, error) {
 	return m.poolInfos, nil
 }
 
-func (m *MockRepo) Insert(_, _ uint64, _ []dex.ParsedTx, _ ...interface{}) error {
+func (m *MockRepo) Insert(_, _ uint64, txs []dex.ParsedTx, _ ...interface{}) error {
+	m.insertedTxs = append(m.insertedTxs, txs...)
+	return nil
+}
+
+func (m *MockRepo) InsertBatch(_ uint64, _ []dex.ParsedBlock) error {
 	return nil
 }
 
@@ -56,6 +63,10 @@ func (m *MockRepo) ClearValidationHeight() error {
 	return nil
 }
 
+func (m *MockRepo) SavePoolValidationResult(_ dex.PoolValidationResult) error {
+	return nil
+}
+
 func (m *MockRepo) PendingParseQuarantines() ([]dex.ParseQuarantine, error) {
 	return nil, nil
 }
@@ -128,7 +139,7 @@ func TestValidateAndGetDbHeight(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			repo := &MockRepo{syncedHeight: tt.dbHeight}
 			ds := &MockSourceDataStore{syncedHeight: tt.sourceHeight}
-			builder := NewBuilder(repo, ds)
+			builder := NewBuilder(repo, ds, logging.Discard)
 
 			height, err := builder.validateAndGetDbHeight(tt.targetHeight)
 			if tt.wantErr {
@@ -142,6 +153,39 @@ func TestValidateAndGetDbHeight(t *testing.T) {
 	}
 }
 
+func TestPreview(t *testing.T) {
+	repo := &MockRepo{
+		syncedHeight: 100,
+		poolInfos: []dex.PoolInfo{
+			{ContractAddr: "pool1", Assets: []dex.Asset{{Addr: "asset1", Amount: "50"}, {Addr: "asset2", Amount: "50"}}, TotalShare: "500", LpAddr: "lp1"},
+		},
+	}
+	ds := &MockSourceDataStore{
+		syncedHeight: 200,
+		poolInfos: []dex.PoolInfo{
+			{ContractAddr: "pool1", Assets: []dex.Asset{{Addr: "asset1", Amount: "100"}, {Addr: "asset2", Amount: "100"}}, TotalShare: "1000", LpAddr: "lp1"},
+			{ContractAddr: "pool2", Assets: []dex.Asset{{Addr: "asset1", Amount: "10"}, {Addr: "asset3", Amount: "20"}}, TotalShare: "30", LpAddr: "lp2"},
+		},
+	}
+	builder := NewBuilder(repo, ds, logging.Discard)
+
+	cp, err := builder.Preview(150)
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(100), cp.DbHeight)
+	assert.Equal(t, uint64(150), cp.TargetHeight)
+	assert.Len(t, cp.Txs, 3)
+	assert.Equal(t, dex.Provide, cp.Txs[0].Type)
+	assert.Equal(t, dex.CreatePair, cp.Txs[1].Type)
+	assert.Equal(t, dex.InitialProvide, cp.Txs[2].Type)
+	assert.Equal(t, []dex.PoolInfo{ds.poolInfos[1]}, cp.Pools)
+	assert.Equal(t, []dex.Pair{{ContractAddr: "pool2", Assets: []string{"asset1", "asset3"}, LpAddr: "lp2"}}, cp.Pairs)
+	assert.Empty(t, repo.insertedTxs)
+
+	assert.NoError(t, builder.Build(150))
+	assert.Len(t, repo.insertedTxs, 3)
+}
+
 func TestCalculateAssetDiff(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -362,6 +406,45 @@ func TestCreateDiffTx(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "Swap transaction of a three asset pool",
+			src: dex.PoolInfo{
+				ContractAddr: "pool3",
+				Assets: []dex.Asset{
+					{Addr: "asset1", Amount: "100"},
+					{Addr: "asset2", Amount: "100"},
+					{Addr: "asset3", Amount: "50"},
+				},
+				TotalShare: "1000",
+				LpAddr:     "lp3",
+			},
+			rdb: dex.PoolInfo{
+				ContractAddr: "pool3",
+				Assets: []dex.Asset{
+					{Addr: "asset1", Amount: "50"},
+					{Addr: "asset2", Amount: "100"},
+					{Addr: "asset3", Amount: "100"},
+				},
+				TotalShare: "1000",
+				LpAddr:     "lp3",
+			},
+			expected: &dex.ParsedTx{
+				Hash:         "-",
+				Timestamp:    time.Now(),
+				Type:         dex.Swap,
+				Sender:       "-",
+				ContractAddr: "pool3",
+				Assets: [2]dex.Asset{
+					{Addr: "asset1", Amount: "50"},
+					{Addr: "asset2", Amount: "0"},
+				},
+				ExtraAssets:      []dex.Asset{{Addr: "asset3", Amount: "-50"}},
+				LpAddr:           "lp3",
+				LpAmount:         "0",
+				CommissionAmount: "0",
+			},
+			wantErr: false,
+		},
 		{
 			name: "No changes",
 			src: dex.PoolInfo{
@@ -436,6 +519,7 @@ func TestCreateDiffTx(t *testing.T) {
 				assert.Equal(t, expectedAsset.Addr, result.Assets[i].Addr)
 				assert.Equal(t, expectedAsset.Amount, result.Assets[i].Amount)
 			}
+			assert.Equal(t, tt.expected.ExtraAssets, result.ExtraAssets)
 		})
 	}
 }
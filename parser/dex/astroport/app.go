@@ -0,0 +1,80 @@
+package astroport
+
+import (
+	"github.com/dezswap/cosmwasm-etl/configs"
+	"github.com/dezswap/cosmwasm-etl/parser"
+	"github.com/dezswap/cosmwasm-etl/parser/dex"
+	pdex "github.com/dezswap/cosmwasm-etl/pkg/dex"
+	"github.com/dezswap/cosmwasm-etl/pkg/dex/astroport"
+	"github.com/dezswap/cosmwasm-etl/pkg/logging"
+	"github.com/pkg/errors"
+)
+
+// runner for astroport
+type astroportApp struct {
+	dex.PairRepo
+	Parsers *dex.PairParsers
+	dex.DexMixin
+
+	// state
+	pairs         map[string]dex.Pair
+	lpPairAddrs   map[string]string
+	flaggedAssets map[string]bool
+
+	parsersVersion dex.ParsersVersion
+}
+
+var _ dex.TargetApp = &astroportApp{}
+
+func New(repo dex.PairRepo, logger logging.Logger, c configs.ParserDexConfig) (dex.TargetApp, error) {
+	finder, err := astroport.CreateCreatePairRuleFinder(c.FactoryAddress)
+	if err != nil {
+		return nil, errors.Wrap(err, "NewApp")
+	}
+
+	parsers := dex.PairParsers{
+		CreatePairParser: parser.NewParser(finder, dex.NewFactoryMapper()),
+		PairActionParser: nil,
+		InitialProvide:   nil,
+		WasmTransfer:     nil,
+		Transfer:         nil,
+	}
+
+	pairs, err := repo.GetPairs()
+	if err != nil {
+		return nil, errors.Wrap(err, "astroport.New")
+	}
+
+	lpPairAddrs := make(map[string]string)
+	for _, p := range pairs {
+		lpPairAddrs[p.LpAddr] = p.ContractAddr
+	}
+
+	return &astroportApp{repo, &parsers, dex.DexMixin{}, pairs, lpPairAddrs, make(map[string]bool), dex.ParsersVersion{}}, nil
+}
+
+func (p *astroportApp) ParseTxs(tx parser.RawTx, height uint64) ([]dex.ParsedTx, error) {
+	return p.ParsePairTxs("astroport", p.Parsers, p.pairs, p.lpPairAddrs, tx, height)
+}
+
+func (p *astroportApp) IsValidationExceptionCandidate(contractAddress string) bool {
+	return p.flaggedAssets[contractAddress]
+}
+
+func (p *astroportApp) UpdateParsers(tokenExceptions map[string]bool, height uint64) error {
+	if !p.parsersVersion.Stale(len(p.pairs), 0, tokenExceptions) {
+		return nil
+	}
+
+	if err := p.UpdatePairParsers(p.Parsers, dex.PairParserSpec{
+		PairFinder:         astroport.CreatePairCommonRulesFinder,
+		PairMapper:         &pairMapper{pairSet: p.pairs},
+		WasmTransferFinder: astroport.CreateWasmCommonTransferRuleFinder,
+		Cw20AddrKey:        pdex.WasmTransferCw20AddrKey,
+	}, p.pairs, p.flaggedAssets, tokenExceptions); err != nil {
+		return err
+	}
+
+	p.parsersVersion.Record(len(p.pairs), 0, tokenExceptions)
+	return nil
+}
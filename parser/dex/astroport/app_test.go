@@ -0,0 +1,108 @@
+package astroport
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/dezswap/cosmwasm-etl/configs"
+	"github.com/dezswap/cosmwasm-etl/parser"
+	"github.com/dezswap/cosmwasm-etl/parser/dex"
+	"github.com/dezswap/cosmwasm-etl/pkg/eventlog"
+	"github.com/dezswap/cosmwasm-etl/pkg/logging"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	factoryAddr = "terra1factory"
+	pairAddr    = "terra1pair"
+	lpAddr      = "terra1lp"
+	tokenAddr   = "terra1token"
+	userAddr    = "terra1user"
+)
+
+func newTestApp(t *testing.T, pairs map[string]dex.Pair) dex.TargetApp {
+	repo := dex.RepoMock{}
+	repo.On("GetPairs").Return(pairs, nil)
+	app, err := New(&repo, logging.Discard, configs.ParserDexConfig{FactoryAddress: factoryAddr})
+	require.NoError(t, err)
+	require.NoError(t, app.UpdateParsers(map[string]bool{}, 100))
+	return app
+}
+
+func rawTx(t *testing.T, logs string) parser.RawTx {
+	var logResults eventlog.LogResults
+	require.NoError(t, json.Unmarshal([]byte(logs), &logResults))
+	return parser.RawTx{Hash: "hash", Sender: userAddr, LogResults: logResults}
+}
+
+func Test_ParseTxs_CreatePairThenSwap(t *testing.T) {
+	app := newTestApp(t, map[string]dex.Pair{})
+
+	txs, err := app.ParseTxs(rawTx(t, `[{"type":"wasm","attributes":[
+		{"key":"_contract_address","value":"`+factoryAddr+`"},{"key":"action","value":"create_pair"},{"key":"pair","value":"uluna-`+tokenAddr+`"},
+		{"key":"_contract_address","value":"`+pairAddr+`"},{"key":"liquidity_token_addr","value":"`+lpAddr+`"},
+		{"key":"_contract_address","value":"`+factoryAddr+`"},{"key":"action","value":"register"},{"key":"pair_contract_addr","value":"`+pairAddr+`"}]}]`), 100)
+
+	require.NoError(t, err)
+	require.Len(t, txs, 1)
+	require.Equal(t, dex.CreatePair, txs[0].Type)
+	require.Equal(t, pairAddr, txs[0].ContractAddr)
+	require.Equal(t, lpAddr, txs[0].LpAddr)
+	require.Equal(t, userAddr, txs[0].Sender)
+
+	require.NoError(t, app.UpdateParsers(map[string]bool{}, 101))
+	txs, err = app.ParseTxs(rawTx(t, `[{"type":"wasm","attributes":[
+		{"key":"_contract_address","value":"`+pairAddr+`"},{"key":"action","value":"swap"},{"key":"receiver","value":"`+userAddr+`"},{"key":"sender","value":"`+userAddr+`"},
+		{"key":"offer_asset","value":"uluna"},{"key":"ask_asset","value":"`+tokenAddr+`"},{"key":"offer_amount","value":"1000"},{"key":"return_amount","value":"990"},
+		{"key":"spread_amount","value":"1"},{"key":"commission_amount","value":"3"},{"key":"maker_fee_amount","value":"1"},
+		{"key":"_contract_address","value":"`+tokenAddr+`"},{"key":"action","value":"transfer"},{"key":"from","value":"`+pairAddr+`"},{"key":"to","value":"`+userAddr+`"},{"key":"amount","value":"990"}]}]`), 101)
+
+	require.NoError(t, err)
+	require.Len(t, txs, 1)
+	require.Equal(t, dex.ParsedTx{
+		Hash:             "hash",
+		Type:             dex.Swap,
+		Sender:           userAddr,
+		ContractAddr:     pairAddr,
+		Assets:           [2]dex.Asset{{Addr: "uluna", Amount: "1000"}, {Addr: tokenAddr, Amount: "-990"}},
+		CommissionAmount: "3",
+		Meta:             map[string]interface{}{"maker_fee_amount": "1"},
+	}, txs[0])
+}
+
+func Test_ParseTxs_ThreeAssetPool(t *testing.T) {
+	const token2Addr = "terra1token2"
+	app := newTestApp(t, map[string]dex.Pair{})
+
+	txs, err := app.ParseTxs(rawTx(t, `[{"type":"wasm","attributes":[
+		{"key":"_contract_address","value":"`+factoryAddr+`"},{"key":"action","value":"create_pair"},{"key":"pair","value":"uluna-`+tokenAddr+`-`+token2Addr+`"},
+		{"key":"_contract_address","value":"`+pairAddr+`"},{"key":"liquidity_token_addr","value":"`+lpAddr+`"}]}]`), 100)
+
+	require.NoError(t, err)
+	require.Len(t, txs, 1)
+	require.Equal(t, []string{"uluna", tokenAddr, token2Addr}, txs[0].AssetAddrs())
+
+	require.NoError(t, app.UpdateParsers(map[string]bool{}, 101))
+	txs, err = app.ParseTxs(rawTx(t, `[{"type":"wasm","attributes":[
+		{"key":"_contract_address","value":"`+pairAddr+`"},{"key":"action","value":"swap"},{"key":"sender","value":"`+userAddr+`"},
+		{"key":"offer_asset","value":"`+token2Addr+`"},{"key":"ask_asset","value":"uluna"},{"key":"offer_amount","value":"1000"},{"key":"return_amount","value":"990"},
+		{"key":"commission_amount","value":"3"},
+		{"key":"_contract_address","value":"`+pairAddr+`"},{"key":"action","value":"provide_liquidity"},{"key":"sender","value":"`+userAddr+`"},
+		{"key":"assets","value":"30`+token2Addr+`, 10uluna, 20`+tokenAddr+`"},{"key":"share","value":"60"},
+		{"key":"_contract_address","value":"`+pairAddr+`"},{"key":"action","value":"withdraw_liquidity"},{"key":"sender","value":"`+userAddr+`"},
+		{"key":"withdrawn_share","value":"6"},{"key":"refund_assets","value":"1uluna, 2`+tokenAddr+`, 3`+token2Addr+`"}]}]`), 101)
+
+	require.NoError(t, err)
+	require.Len(t, txs, 3)
+	require.Equal(t, dex.Swap, txs[0].Type)
+	require.Equal(t, []dex.Asset{{Addr: "uluna", Amount: "-990"}, {Addr: tokenAddr}, {Addr: token2Addr, Amount: "1000"}}, txs[0].AllAssets())
+	require.Equal(t, dex.Provide, txs[1].Type)
+	require.Equal(t, []dex.Asset{{Addr: "uluna", Amount: "10"}, {Addr: tokenAddr, Amount: "20"}, {Addr: token2Addr, Amount: "30"}}, txs[1].AllAssets())
+	require.Equal(t, dex.Withdraw, txs[2].Type)
+	require.Equal(t, []dex.Asset{{Addr: "uluna", Amount: "-1"}, {Addr: tokenAddr, Amount: "-2"}, {Addr: token2Addr, Amount: "-3"}}, txs[2].AllAssets())
+}
+
+func Test_New_RequiresFactory(t *testing.T) {
+	_, err := New(&dex.RepoMock{}, logging.Discard, configs.ParserDexConfig{})
+	require.Error(t, err)
+}
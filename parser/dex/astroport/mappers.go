@@ -0,0 +1,155 @@
+package astroport
+
+import (
+	"fmt"
+
+	"github.com/dezswap/cosmwasm-etl/parser"
+	"github.com/dezswap/cosmwasm-etl/parser/dex"
+	pdex "github.com/dezswap/cosmwasm-etl/pkg/dex"
+	"github.com/dezswap/cosmwasm-etl/pkg/dex/astroport"
+	"github.com/dezswap/cosmwasm-etl/pkg/eventlog"
+
+	"github.com/pkg/errors"
+)
+
+var _ parser.Mapper[dex.ParsedTx] = &pairMapper{}
+
+// pairMapper reads attributes by key, since astroport pair versions emit
+// them in different orders and some only exist in later versions.
+type pairMapper struct {
+	pairSet map[string]dex.Pair
+}
+
+// match implements mapper
+func (m *pairMapper) MatchedToParsedTx(res eventlog.MatchedResult, optionals ...interface{}) ([]*dex.ParsedTx, error) {
+	pair, ok := m.pairSet[res[astroport.PairAddrIdx].Value]
+	if !ok {
+		msg := fmt.Sprintf("pairMapper.MatchedToParsedTx no pair(%s)", res[astroport.PairAddrIdx].Value)
+		return nil, errors.New(msg)
+	}
+
+	action := astroport.PairAction(res[astroport.PairActionIdx].Value)
+	switch action {
+	case astroport.SwapAction:
+		return m.swapMatchedToParsedTx(res, pair)
+	case astroport.ProvideAction:
+		return m.provideMatchedToParsedTx(res, pair)
+	case astroport.WithdrawAction:
+		return m.withdrawMatchedToParsedTx(res, pair)
+	}
+
+	msg := fmt.Sprintf("action must be (%s, %s, %s)", astroport.SwapAction, astroport.ProvideAction, astroport.WithdrawAction)
+	return nil, errors.New(msg)
+}
+
+func (m *pairMapper) swapMatchedToParsedTx(res eventlog.MatchedResult, pair dex.Pair) ([]*dex.ParsedTx, error) {
+	matchMap, err := eventlog.ResultToItemMapForKeys(
+		res,
+		astroport.PairAddrKey,
+		pdex.PairSwapOfferAssetKey,
+		pdex.PairSwapAskAssetKey,
+		pdex.PairSwapOfferAmountKey,
+		pdex.PairSwapReturnAmountKey,
+		pdex.PairSwapSenderKey,
+		pdex.PairSwapCommissionAmountKey,
+		astroport.PairSwapMakerFeeAmountKey,
+		astroport.PairSwapFeeShareAmountKey,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "pairMapper.swapMatchedToParsedTx")
+	}
+
+	assets, err := dex.SwapAssets(
+		pair,
+		matchMap[pdex.PairSwapOfferAssetKey].Value,
+		matchMap[pdex.PairSwapOfferAmountKey].Value,
+		matchMap[pdex.PairSwapAskAssetKey].Value,
+		matchMap[pdex.PairSwapReturnAmountKey].Value,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "pairMapper.swapMatchedToParsedTx")
+	}
+
+	// the maker and fee share cuts are part of the commission, kept for reference
+	var meta map[string]interface{}
+	for _, key := range []string{astroport.PairSwapMakerFeeAmountKey, astroport.PairSwapFeeShareAmountKey} {
+		if item, ok := matchMap[key]; ok {
+			if meta == nil {
+				meta = map[string]interface{}{}
+			}
+			meta[key] = item.Value
+		}
+	}
+
+	tx := &dex.ParsedTx{
+		Type:             dex.Swap,
+		ContractAddr:     matchMap[astroport.PairAddrKey].Value,
+		Sender:           matchMap[pdex.PairSwapSenderKey].Value,
+		CommissionAmount: matchMap[pdex.PairSwapCommissionAmountKey].Value,
+		Meta:             meta,
+		MsgIndex:         eventlog.MsgIndex(res),
+	}
+	tx.SetAssets(assets)
+	return []*dex.ParsedTx{tx}, nil
+}
+
+func (m *pairMapper) provideMatchedToParsedTx(res eventlog.MatchedResult, pair dex.Pair) ([]*dex.ParsedTx, error) {
+	matchMap, err := eventlog.ResultToItemMapForKeys(
+		res,
+		astroport.PairAddrKey,
+		astroport.PairProvideAssetsKey,
+		astroport.PairProvideSenderKey,
+		astroport.PairProvideShareKey,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "pairMapper.provideMatchedToParsedTx")
+	}
+
+	assets, err := dex.GetAssetsFromAssetsString(matchMap[astroport.PairProvideAssetsKey].Value)
+	if err != nil {
+		return nil, errors.Wrap(err, "pairMapper.provideMatchedToParsedTx")
+	}
+
+	tx := &dex.ParsedTx{
+		Type:         dex.Provide,
+		ContractAddr: matchMap[astroport.PairAddrKey].Value,
+		Sender:       matchMap[astroport.PairProvideSenderKey].Value,
+		LpAddr:       pair.LpAddr,
+		LpAmount:     matchMap[astroport.PairProvideShareKey].Value,
+		MsgIndex:     eventlog.MsgIndex(res),
+	}
+	tx.SetAssets(dex.SortAssetsByPair(pair, assets))
+	return []*dex.ParsedTx{tx}, nil
+}
+
+func (m *pairMapper) withdrawMatchedToParsedTx(res eventlog.MatchedResult, pair dex.Pair) ([]*dex.ParsedTx, error) {
+	matchMap, err := eventlog.ResultToItemMapForKeys(
+		res,
+		astroport.PairAddrKey,
+		astroport.PairWithdrawRefundAssetsKey,
+		astroport.PairWithdrawSenderKey,
+		astroport.PairWithdrawWithdrawShareKey,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "pairMapper.withdrawMatchedToParsedTx")
+	}
+
+	assets, err := dex.GetAssetsFromAssetsString(matchMap[astroport.PairWithdrawRefundAssetsKey].Value)
+	if err != nil {
+		return nil, errors.Wrap(err, "pairMapper.withdrawMatchedToParsedTx")
+	}
+	for idx := range assets {
+		assets[idx].Amount = fmt.Sprintf("-%s", assets[idx].Amount)
+	}
+
+	tx := &dex.ParsedTx{
+		Type:         dex.Withdraw,
+		ContractAddr: matchMap[astroport.PairAddrKey].Value,
+		Sender:       matchMap[astroport.PairWithdrawSenderKey].Value,
+		LpAddr:       pair.LpAddr,
+		LpAmount:     matchMap[astroport.PairWithdrawWithdrawShareKey].Value,
+		MsgIndex:     eventlog.MsgIndex(res),
+	}
+	tx.SetAssets(dex.SortAssetsByPair(pair, assets))
+	return []*dex.ParsedTx{tx}, nil
+}
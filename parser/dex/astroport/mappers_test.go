@@ -0,0 +1,103 @@
+package astroport
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/dezswap/cosmwasm-etl/parser"
+	"github.com/dezswap/cosmwasm-etl/parser/dex"
+	el "github.com/dezswap/cosmwasm-etl/pkg/eventlog"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_PairMapper(t *testing.T) {
+	pair := dex.Pair{ContractAddr: "Pair", LpAddr: "LiquidityToken", Assets: []string{"uluna", "terra1Asset2"}}
+	userAddr := "userAddr"
+	pairSet := map[string]dex.Pair{pair.ContractAddr: pair}
+	tcs := []struct {
+		mapper         parser.Mapper[dex.ParsedTx]
+		matchedResults el.MatchedResult
+		expectedTx     []*dex.ParsedTx
+		errMsg         string
+	}{
+		/// Swap
+		{
+			&pairMapper{pairSet: pairSet},
+			el.MatchedResult{
+				{Key: "_contract_address", Value: pair.ContractAddr}, {Key: "action", Value: "swap"},
+				{Key: "receiver", Value: userAddr}, {Key: "sender", Value: userAddr},
+				{Key: "offer_asset", Value: pair.Assets[1]}, {Key: "ask_asset", Value: pair.Assets[0]},
+				{Key: "offer_amount", Value: "100000"}, {Key: "return_amount", Value: "100583"},
+				{Key: "spread_amount", Value: "2"}, {Key: "commission_amount", Value: "302"},
+				{Key: "maker_fee_amount", Value: "151"}, {Key: "fee_share_amount", Value: "0"},
+			},
+			[]*dex.ParsedTx{{
+				Type: dex.Swap, Sender: userAddr, ContractAddr: pair.ContractAddr,
+				Assets:           [2]dex.Asset{{Addr: pair.Assets[0], Amount: "-100583"}, {Addr: pair.Assets[1], Amount: "100000"}},
+				CommissionAmount: "302",
+				Meta:             map[string]interface{}{"maker_fee_amount": "151", "fee_share_amount": "0"},
+			}},
+			"",
+		},
+		{
+			&pairMapper{pairSet: pairSet},
+			el.MatchedResult{
+				{Key: "_contract_address", Value: "unknown"}, {Key: "action", Value: "swap"},
+			},
+			nil,
+			"pairMapper.MatchedToParsedTx no pair(unknown)",
+		},
+
+		/// Provide
+		{
+			&pairMapper{pairSet: pairSet},
+			el.MatchedResult{
+				{Key: "_contract_address", Value: pair.ContractAddr}, {Key: "action", Value: "provide_liquidity"},
+				{Key: "sender", Value: userAddr}, {Key: "receiver", Value: userAddr},
+				{Key: "assets", Value: fmt.Sprintf("%s%s, %s%s", "10000", pair.Assets[1], "1000", pair.Assets[0])},
+				{Key: "share", Value: "998735"},
+			},
+			[]*dex.ParsedTx{{
+				Type: dex.Provide, Sender: userAddr, ContractAddr: pair.ContractAddr,
+				Assets: [2]dex.Asset{{Addr: pair.Assets[0], Amount: "1000"}, {Addr: pair.Assets[1], Amount: "10000"}},
+				LpAddr: pair.LpAddr, LpAmount: "998735",
+			}},
+			"",
+		},
+
+		/// Withdraw
+		{
+			&pairMapper{pairSet: pairSet},
+			el.MatchedResult{
+				{Key: "_contract_address", Value: pair.ContractAddr}, {Key: "action", Value: "withdraw_liquidity"},
+				{Key: "sender", Value: userAddr}, {Key: "withdrawn_share", Value: "1000"},
+				{Key: "refund_assets", Value: fmt.Sprintf("%s%s, %s%s", "1000", pair.Assets[0], "1000", pair.Assets[1])},
+			},
+			[]*dex.ParsedTx{{
+				Type: dex.Withdraw, Sender: userAddr, ContractAddr: pair.ContractAddr,
+				Assets: [2]dex.Asset{{Addr: pair.Assets[0], Amount: "-1000"}, {Addr: pair.Assets[1], Amount: "-1000"}},
+				LpAddr: pair.LpAddr, LpAmount: "1000",
+			}},
+			"",
+		},
+		{
+			&pairMapper{pairSet: pairSet},
+			el.MatchedResult{
+				{Key: "_contract_address", Value: pair.ContractAddr}, {Key: "action", Value: "create_pair"},
+			},
+			nil,
+			"action must be (swap, provide_liquidity, withdraw_liquidity)",
+		},
+	}
+
+	for idx, tc := range tcs {
+		errMsg := fmt.Sprintf("tc(%d)", idx)
+		txs, err := tc.mapper.MatchedToParsedTx(tc.matchedResults)
+		if tc.errMsg != "" {
+			assert.EqualError(t, err, tc.errMsg, errMsg)
+			continue
+		}
+		assert.NoError(t, err, errMsg)
+		assert.Equal(t, tc.expectedTx, txs, errMsg)
+	}
+}
@@ -0,0 +1,138 @@
+package dex
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dezswap/cosmwasm-etl/parser"
+)
+
+// ParsedPoolsReader sums the parsed pools of a height range.
+type ParsedPoolsReader interface {
+	ParsedPoolsInfo(from, to uint64) ([]PoolInfo, error)
+}
+
+// PoolDivergence is the first height at which the parsed pool of a contract
+// stops matching the source pool.
+type PoolDivergence struct {
+	Contract           string                   `json:"contract"`
+	Height             uint64                   `json:"height"`
+	LastMatchingHeight uint64                   `json:"last_matching_height"`
+	Mismatches         []PoolValidationMismatch `json:"mismatches"`
+	// RawTxs are the source txs of Height that mention Contract.
+	RawTxs parser.RawTxs `json:"raw_txs"`
+}
+
+// BisectPoolDivergence narrows a mismatch of the pool of contract at bad down
+// to the height it was introduced at. good is a height the pool matched at, 0
+// for before it existed. When good does not match either, the search widens
+// down to 0. A parsed pool is the sum of every tx before, so a divergence is
+// assumed to persist once introduced.
+func BisectPoolDivergence(source SourceDataStore, parsed ParsedPoolsReader, contract string, good, bad uint64) (PoolDivergence, error) {
+	if good >= bad {
+		return PoolDivergence{}, fmt.Errorf("bisect pool %s: good height(%d) must be below bad height(%d)", contract, good, bad)
+	}
+	mismatches, err := poolMismatchesAt(source, parsed, contract, bad)
+	if err != nil {
+		return PoolDivergence{}, err
+	}
+	if len(mismatches) == 0 {
+		return PoolDivergence{}, fmt.Errorf("bisect pool %s: pool matches at bad height %d", contract, bad)
+	}
+	if good > 0 {
+		goodMismatches, err := poolMismatchesAt(source, parsed, contract, good)
+		if err != nil {
+			return PoolDivergence{}, err
+		}
+		if len(goodMismatches) > 0 {
+			good, bad, mismatches = 0, good, goodMismatches
+		}
+	}
+
+	for bad-good > 1 {
+		mid := good + (bad-good)/2
+		midMismatches, err := poolMismatchesAt(source, parsed, contract, mid)
+		if err != nil {
+			return PoolDivergence{}, err
+		}
+		if len(midMismatches) == 0 {
+			good = mid
+		} else {
+			bad, mismatches = mid, midMismatches
+		}
+	}
+
+	txs, err := source.GetSourceTxs(bad)
+	if err != nil {
+		return PoolDivergence{}, fmt.Errorf("bisect pool %s: get source txs at height %d: %w", contract, bad, err)
+	}
+	rawTxs := parser.RawTxs{}
+	for _, tx := range txs {
+		if RawTxContainsContract(tx, contract) {
+			rawTxs = append(rawTxs, tx)
+		}
+	}
+	return PoolDivergence{
+		Contract:           contract,
+		Height:             bad,
+		LastMatchingHeight: good,
+		Mismatches:         mismatches,
+		RawTxs:             rawTxs,
+	}, nil
+}
+
+// poolMismatchesAt compares the parsed pool of contract with the source pool
+// at height. Nothing is parsed before height 1, so height 0 always matches.
+func poolMismatchesAt(source SourceDataStore, parsed ParsedPoolsReader, contract string, height uint64) ([]PoolValidationMismatch, error) {
+	if height == 0 {
+		return nil, nil
+	}
+	expectedPools, err := source.GetPoolInfos(height)
+	if err != nil {
+		return nil, fmt.Errorf("bisect pool %s: get pool infos at height %d: %w", contract, height, err)
+	}
+	actualPools, err := parsed.ParsedPoolsInfo(0, height)
+	if err != nil {
+		return nil, fmt.Errorf("bisect pool %s: parsed pools info at height %d: %w", contract, height, err)
+	}
+
+	expected, hasExpected := findPool(expectedPools, contract)
+	actual, hasActual := findPool(actualPools, contract)
+	switch {
+	case !hasExpected && !hasActual:
+		return nil, nil
+	case !hasExpected:
+		return []PoolValidationMismatch{{Type: validationMismatchUnexpectedPool, Contract: contract, Actual: actual.TotalShare}}, nil
+	case !hasActual:
+		return []PoolValidationMismatch{{Type: validationMismatchExpectedPoolMissing, Contract: contract, Expected: expected.TotalShare}}, nil
+	default:
+		return comparePools(actual, expected), nil
+	}
+}
+
+func findPool(pools []PoolInfo, contract string) (PoolInfo, bool) {
+	for _, pool := range pools {
+		if pool.ContractAddr == contract {
+			return pool, true
+		}
+	}
+	return PoolInfo{}, false
+}
+
+// RawTxContainsContract reports whether a raw transaction directly mentions the target contract.
+func RawTxContainsContract(tx parser.RawTx, contract string) bool {
+	if contract == "" {
+		return false
+	}
+	if strings.Contains(tx.Hash, contract) || strings.Contains(tx.Sender, contract) {
+		return true
+	}
+	for _, log := range tx.LogResults {
+		for _, attr := range log.Attributes {
+			if strings.Contains(attr.Value, contract) {
+				return true
+			}
+		}
+	}
+	return false
+}
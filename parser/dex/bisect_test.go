@@ -0,0 +1,158 @@
+package dex
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"testing"
+
+	"github.com/dezswap/cosmwasm-etl/parser"
+	"github.com/dezswap/cosmwasm-etl/pkg/eventlog"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// bisectSource has a pool1 of height*10 asset0 at every height from 1, and
+// the parsed pool1 gets one asset0 too many from divergedAt on.
+type bisectSource struct {
+	divergedAt uint64
+	poolCalls  []uint64
+}
+
+func (*bisectSource) GetSourceSyncedHeight() (uint64, error) {
+	return 0, nil
+}
+
+func (s *bisectSource) GetSourceTxs(height uint64) (parser.RawTxs, error) {
+	return parser.RawTxs{
+		rawTxWithContract("tx-"+strconv.FormatUint(height, 10), "pool1"),
+		rawTxWithContract("other-"+strconv.FormatUint(height, 10), "pool2"),
+	}, nil
+}
+
+func (s *bisectSource) GetPoolInfos(height uint64) ([]PoolInfo, error) {
+	s.poolCalls = append(s.poolCalls, height)
+	return []PoolInfo{bisectPool(height * 10)}, nil
+}
+
+func (s *bisectSource) ParsedPoolsInfo(_, to uint64) ([]PoolInfo, error) {
+	amount := to * 10
+	if to >= s.divergedAt {
+		amount++
+	}
+	return []PoolInfo{bisectPool(amount)}, nil
+}
+
+func bisectPool(asset0 uint64) PoolInfo {
+	return PoolInfo{
+		ContractAddr: "pool1",
+		TotalShare:   "1000",
+		Assets:       []Asset{{"token0", strconv.FormatUint(asset0, 10)}, {"token1", "500"}},
+	}
+}
+
+func rawTxWithContract(hash, contract string) parser.RawTx {
+	return parser.RawTx{
+		Hash:   hash,
+		Sender: "sender",
+		LogResults: eventlog.LogResults{{
+			Type: eventlog.WasmType,
+			Attributes: eventlog.Attributes{
+				{Key: "_contract_address", Value: contract},
+				{Key: "action", Value: "swap"},
+			},
+		}},
+	}
+}
+
+func Test_RawTxContainsContract(t *testing.T) {
+	tx := rawTxWithContract("hash", "pair1")
+
+	assert.True(t, RawTxContainsContract(tx, "pair1"))
+	assert.False(t, RawTxContainsContract(tx, "pair2"))
+	assert.False(t, RawTxContainsContract(tx, ""))
+}
+
+func Test_BisectPoolDivergence_FindsFirstDivergingHeight(t *testing.T) {
+	source := &bisectSource{divergedAt: 137}
+
+	divergence, err := BisectPoolDivergence(source, source, "pool1", 100, 200)
+
+	require.NoError(t, err)
+	assert.Equal(t, uint64(137), divergence.Height)
+	assert.Equal(t, uint64(136), divergence.LastMatchingHeight)
+	assert.Equal(t, []PoolValidationMismatch{{
+		Type:     validationMismatchAssetAmount,
+		Contract: "pool1",
+		Asset:    "token0",
+		Actual:   "1371",
+		Expected: "1370",
+	}}, divergence.Mismatches)
+	require.Len(t, divergence.RawTxs, 1)
+	assert.Equal(t, "tx-137", divergence.RawTxs[0].Hash)
+	assert.LessOrEqual(t, len(source.poolCalls), 9)
+}
+
+func Test_BisectPoolDivergence_WidensWhenGoodHeightMismatches(t *testing.T) {
+	source := &bisectSource{divergedAt: 42}
+
+	divergence, err := BisectPoolDivergence(source, source, "pool1", 100, 200)
+
+	require.NoError(t, err)
+	assert.Equal(t, uint64(42), divergence.Height)
+}
+
+func Test_BisectPoolDivergence_RejectsMatchingBadHeight(t *testing.T) {
+	source := &bisectSource{divergedAt: 300}
+
+	_, err := BisectPoolDivergence(source, source, "pool1", 100, 200)
+
+	require.ErrorContains(t, err, "pool matches at bad height 200")
+}
+
+func Test_bisectValidationFailure_LogsDivergenceOnce(t *testing.T) {
+	logBuf := &bytes.Buffer{}
+	logger := logrus.New()
+	logger.SetOutput(logBuf)
+	logger.SetFormatter(&logrus.JSONFormatter{})
+
+	source := &bisectSource{divergedAt: 150}
+	app := &dexApp{
+		Repo:                     &bisectRepo{RepoMock: &RepoMock{}, source: source},
+		SourceDataStore:          source,
+		logger:                   logger.WithField("chainId", "chain-1"),
+		chainId:                  "chain-1",
+		validationInterval:       100,
+		bisectValidationFailures: true,
+	}
+	mismatches := []PoolValidationMismatch{
+		{Type: validationMismatchAssetAmount, Contract: "pool1", Asset: "token0", Actual: "2001", Expected: "2000"},
+		{Type: validationMismatchTotalShare, Contract: "pool2", Actual: "1", Expected: "2"},
+	}
+
+	app.bisectValidationFailure(200, mismatches)
+	app.bisectValidationFailure(200, mismatches)
+
+	var logs []map[string]interface{}
+	for _, line := range bytes.Split(bytes.TrimSpace(logBuf.Bytes()), []byte("\n")) {
+		var entry map[string]interface{}
+		require.NoError(t, json.Unmarshal(line, &entry))
+		logs = append(logs, entry)
+	}
+	require.Len(t, logs, 1)
+	assert.Equal(t, "parser.pool_validation_bisected", logs[0]["event"])
+	assert.Equal(t, "pool1", logs[0]["contract"])
+	assert.Equal(t, float64(150), logs[0]["divergence_height"])
+	assert.Equal(t, []interface{}{"tx-150"}, logs[0]["tx_hashes"])
+}
+
+// bisectRepo answers ParsedPoolsInfo from a bisectSource.
+type bisectRepo struct {
+	*RepoMock
+	source *bisectSource
+}
+
+func (r *bisectRepo) ParsedPoolsInfo(from, to uint64) ([]PoolInfo, error) {
+	return r.source.ParsedPoolsInfo(from, to)
+}
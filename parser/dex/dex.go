@@ -1,6 +1,7 @@
 package dex
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strings"
@@ -41,8 +42,9 @@ type dexApp struct {
 	TargetApp
 	SourceDataStore
 	Repo
-	chainId string
-	logger  logging.Logger
+	chainId    string
+	routerAddr string
+	logger     logging.Logger
 
 	poolSnapshotInterval uint
 	validationInterval   uint
@@ -58,6 +60,17 @@ type dexApp struct {
 
 	quarantineRetryMode   configs.QuarantineRetryMode
 	startupRetryAttempted bool
+
+	// prefetchDepth heights are fetched ahead of parsing, and up to
+	// commitBatchSize parsed heights are written per transaction.
+	prefetchDepth   uint
+	commitBatchSize uint
+
+	// bisectValidationFailures narrows asset amount mismatches down to the
+	// height they were introduced at. bisectedHeight is only touched by the
+	// validation worker.
+	bisectValidationFailures bool
+	bisectedHeight           uint64
 }
 
 type DexMixin struct{}
@@ -76,11 +89,16 @@ func NewDexApp(app TargetApp, srcStore SourceDataStore, repo Repo, logger loggin
 		Repo:                 repo,
 		logger:               logger,
 		chainId:              c.ChainId,
+		routerAddr:           c.RouterAddr,
 		sameHeightTolerance:  c.SameHeightTolerance,
 		poolSnapshotInterval: c.PoolSnapshotInterval,
 		validationInterval:   c.ValidationInterval,
 		validationSignal:     make(chan struct{}, 1),
 		quarantineRetryMode:  retryMode,
+		prefetchDepth:        c.PrefetchDepth,
+		commitBatchSize:      c.CommitBatchSize,
+
+		bisectValidationFailures: c.BisectValidationFailures,
 	}
 }
 
@@ -138,10 +156,54 @@ func (app *dexApp) Run() error {
 	quarantineCount := 0
 	poolSnapshotCount := 0
 
+	stop := make(chan struct{})
+	defer close(stop)
+	nextSource := app.sourceReader(localSynced+1, srcHeight, stop)
+
+	syncedHeight := localSynced
+	pending := []ParsedBlock{}
+	commitPending := func() error {
+		if len(pending) == 0 {
+			return nil
+		}
+		if err := app.commit(syncedHeight, pending); err != nil {
+			return err
+		}
+		for _, block := range pending {
+			processedHeightCount++
+			parsedTxCount += len(block.Txs)
+			quarantineCount += len(block.Quarantines)
+			poolSnapshotSaved := app.isPoolSnapshotHeight(block.Height)
+			if poolSnapshotSaved {
+				poolSnapshotCount++
+			}
+			app.logger.WithFields(logrus.Fields{
+				"event":               "parser.height_processed",
+				"operation":           "parser.run",
+				"chain_id":            app.chainId,
+				"height":              block.Height,
+				"parsed_tx_count":     len(block.Txs),
+				"quarantine_count":    len(block.Quarantines),
+				"pool_snapshot_saved": poolSnapshotSaved,
+			}).Debug("parser height processed")
+
+			if app.isValidationHeight(block.Height) {
+				app.triggerValidation(block.Height)
+			}
+		}
+		syncedHeight = pending[len(pending)-1].Height
+		pending = []ParsedBlock{}
+		return nil
+	}
+
 	for cur := localSynced + 1; cur <= srcHeight; cur++ {
-		txs, err := app.GetSourceTxs(cur)
-		if err != nil {
-			if strings.Contains(err.Error(), fmt.Sprintf("greater than the current height %d", srcHeight-1)) {
+		src := nextSource()
+		if src.err != nil {
+			// heights parsed before a failure are kept
+			if err := commitPending(); err != nil {
+				return fmt.Errorf("app.Run: %w", err)
+			}
+			if strings.Contains(src.err.Error(), fmt.Sprintf("greater than the current height %d", srcHeight-1)) {
 				app.logger.WithFields(logrus.Fields{
 					"event":         "parser.source_indexing",
 					"operation":     "get_source_txs",
@@ -151,100 +213,28 @@ func (app *dexApp) Run() error {
 				}).Info("remote node is indexing tx_results")
 				return nil
 			}
-			return fmt.Errorf("app.Run: %w", err)
+			return fmt.Errorf("app.Run: %w", src.err)
 		}
 
-		if err := app.UpdateParsers(tokenExceptions, cur); err != nil {
-			return fmt.Errorf("app.Run: %w", err)
-		}
-
-		parsedTxs := []ParsedTx{}
-		parseQuarantines := []ParseQuarantine{}
-		for _, tx := range txs {
-			txs, err := app.ParseTxs(tx, cur)
-			if err != nil {
-				var partial *PartialParseQuarantineError
-				if errors.As(err, &partial) {
-					parseQuarantines = append(parseQuarantines, partial.Quarantine)
-					app.logger.WithFields(logrus.Fields{
-						"event":             "parse_quarantine.partial_created",
-						"operation":         "parse_txs",
-						"chain_id":          app.chainId,
-						"height":            cur,
-						"tx_hash":           tx.Hash,
-						"stage":             partial.Quarantine.Stage,
-						"contract":          partial.Quarantine.Contract,
-						"action":            partial.Quarantine.Action,
-						"quarantine_status": QuarantineStatusPending,
-						"err":               logging.NewErrorField(err),
-					}).Warn("partial parse quarantine created")
-					parsedTxs = append(parsedTxs, partial.ParsedTxs...)
-					continue
-				}
-				var ambiguity *eventlog.AmbiguousEventError
-				if errors.As(err, &ambiguity) && !RawTxContainsCreatePair(tx) {
-					// Raw transactions remain available, so parser progress does not prevent deterministic replay.
-					parseQuarantines = append(parseQuarantines, ParseQuarantine{
-						Height:   cur,
-						Hash:     tx.Hash,
-						Stage:    parseStage(err),
-						Contract: ambiguity.Contract,
-						Action:   ambiguity.Action,
-						Error:    err.Error(),
-						RawTx:    tx,
-					})
-					app.logger.WithFields(logrus.Fields{
-						"event":             "parse_quarantine.created",
-						"operation":         "parse_txs",
-						"chain_id":          app.chainId,
-						"height":            cur,
-						"tx_hash":           tx.Hash,
-						"stage":             parseStage(err),
-						"contract":          ambiguity.Contract,
-						"action":            ambiguity.Action,
-						"quarantine_status": QuarantineStatusPending,
-						"err":               logging.NewErrorField(err),
-					}).Warn("parse quarantine created")
-					continue
-				}
-				return fmt.Errorf("app.Run: %w", err)
+		block, err := app.parseHeight(tokenExceptions, cur, src)
+		if err != nil {
+			if commitErr := commitPending(); commitErr != nil {
+				return fmt.Errorf("app.Run: %w", commitErr)
 			}
-			parsedTxs = append(parsedTxs, txs...)
+			return fmt.Errorf("app.Run: %w", err)
 		}
 
-		poolInfos := []PoolInfo{}
-		poolSnapshotSaved := false
-		if (cur % uint64(app.poolSnapshotInterval)) == 0 {
-			poolInfos, err = app.GetPoolInfos(cur)
-			if err != nil {
+		// a validation height is committed right away for the validator to read it
+		pending = append(pending, block)
+		if uint(len(pending)) >= app.commitBatchSize || app.isValidationHeight(cur) {
+			if err := commitPending(); err != nil {
 				return fmt.Errorf("app.Run: %w", err)
 			}
-			poolSnapshotSaved = true
-		}
-
-		if err := app.insert(cur-1, cur, parsedTxs, poolInfos, parseQuarantines); err != nil {
-			return fmt.Errorf("app.Run: %w", err)
-		}
-		processedHeightCount++
-		parsedTxCount += len(parsedTxs)
-		quarantineCount += len(parseQuarantines)
-		if poolSnapshotSaved {
-			poolSnapshotCount++
-		}
-		app.logger.WithFields(logrus.Fields{
-			"event":               "parser.height_processed",
-			"operation":           "parser.run",
-			"chain_id":            app.chainId,
-			"height":              cur,
-			"parsed_tx_count":     len(parsedTxs),
-			"quarantine_count":    len(parseQuarantines),
-			"pool_snapshot_saved": poolSnapshotSaved,
-		}).Debug("parser height processed")
-
-		if app.isValidationHeight(cur) {
-			app.triggerValidation(cur)
 		}
 	}
+	if err := commitPending(); err != nil {
+		return fmt.Errorf("app.Run: %w", err)
+	}
 	app.lastSrcHeight = srcHeight
 	if processedHeightCount > 0 {
 		app.logger.WithFields(logrus.Fields{
@@ -266,6 +256,207 @@ func (app *dexApp) Run() error {
 	return nil
 }
 
+// sourceHeight is the source data of one height read ahead of parsing.
+type sourceHeight struct {
+	txs   parser.RawTxs
+	pools []PoolInfo
+	err   error
+}
+
+// sourceReader returns a function yielding the source data of heights
+// from..to, one height per call in order. With a prefetch depth, up to that
+// many heights are fetched concurrently ahead of the caller until stop is
+// closed. Without one, each height is fetched by the call that returns it.
+// A StreamSource sends the txs of the whole range in one request instead.
+func (app *dexApp) sourceReader(from, to uint64, stop <-chan struct{}) func() sourceHeight {
+	if source, ok := app.SourceDataStore.(StreamSource); ok {
+		return app.streamReader(source, from, to, stop)
+	}
+	if app.prefetchDepth == 0 {
+		next := from
+		return func() sourceHeight {
+			height := next
+			next++
+			return app.fetchSource(height)
+		}
+	}
+
+	futures := make(chan chan sourceHeight, app.prefetchDepth)
+	go func() {
+		defer close(futures)
+		for height := from; height <= to; height++ {
+			future := make(chan sourceHeight, 1)
+			select {
+			case futures <- future:
+			case <-stop:
+				return
+			}
+			go func(height uint64) {
+				future <- app.fetchSource(height)
+			}(height)
+		}
+	}()
+	return func() sourceHeight {
+		future, ok := <-futures
+		if !ok {
+			return sourceHeight{err: errors.New("source prefetch stopped")}
+		}
+		return <-future
+	}
+}
+
+// streamReader is sourceReader over one stream of from..to, which runs up to
+// the prefetch depth ahead of the caller until stop is closed. Heights after
+// the stream ends early are fetched one by one, so a missing height fails the
+// same way as without a stream.
+func (app *dexApp) streamReader(source StreamSource, from, to uint64, stop <-chan struct{}) func() sourceHeight {
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-stop
+		cancel()
+	}()
+
+	results := make(chan sourceHeight, app.prefetchDepth)
+	go func() {
+		defer close(results)
+		next := from
+		err := source.StreamSourceTxs(ctx, from, to, func(height uint64, txs parser.RawTxs) error {
+			if height != next {
+				return fmt.Errorf("source stream sent height %d, expected %d", height, next)
+			}
+			next++
+			src := sourceHeight{txs: txs, pools: []PoolInfo{}}
+			if app.isPoolSnapshotHeight(height) {
+				var err error
+				if src.pools, err = app.GetPoolInfos(height); err != nil {
+					return err
+				}
+			}
+			select {
+			case results <- src:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+		if err != nil {
+			select {
+			case results <- sourceHeight{err: err}:
+			case <-ctx.Done():
+			}
+		}
+	}()
+
+	next := from
+	return func() sourceHeight {
+		height := next
+		next++
+		if src, ok := <-results; ok {
+			return src
+		}
+		return app.fetchSource(height)
+	}
+}
+
+// fetchSource reads the txs of height, and its pools on a snapshot height.
+func (app *dexApp) fetchSource(height uint64) sourceHeight {
+	txs, err := app.GetSourceTxs(height)
+	if err != nil {
+		return sourceHeight{err: err}
+	}
+	src := sourceHeight{txs: txs, pools: []PoolInfo{}}
+	if app.isPoolSnapshotHeight(height) {
+		if src.pools, err = app.GetPoolInfos(height); err != nil {
+			return sourceHeight{err: err}
+		}
+	}
+	return src
+}
+
+// parseHeight parses the source txs of height in order, since target apps
+// keep pair state across heights. Ambiguous txs are quarantined instead of
+// failing the height.
+func (app *dexApp) parseHeight(tokenExceptions map[string]bool, height uint64, src sourceHeight) (ParsedBlock, error) {
+	if err := app.UpdateParsers(tokenExceptions, height); err != nil {
+		return ParsedBlock{}, err
+	}
+
+	parsedTxs := []ParsedTx{}
+	parseQuarantines := []ParseQuarantine{}
+	for _, tx := range src.txs {
+		txs, err := app.ParseTxs(tx, height)
+		if err != nil {
+			var partial *PartialParseQuarantineError
+			if errors.As(err, &partial) {
+				parseQuarantines = append(parseQuarantines, partial.Quarantine)
+				app.logger.WithFields(logrus.Fields{
+					"event":             "parse_quarantine.partial_created",
+					"operation":         "parse_txs",
+					"chain_id":          app.chainId,
+					"height":            height,
+					"tx_hash":           tx.Hash,
+					"stage":             partial.Quarantine.Stage,
+					"contract":          partial.Quarantine.Contract,
+					"action":            partial.Quarantine.Action,
+					"quarantine_status": QuarantineStatusPending,
+					"err":               logging.NewErrorField(err),
+				}).Warn("partial parse quarantine created")
+				parsedTxs = append(parsedTxs, AttributeRoutes(app.routerAddr, tx, partial.ParsedTxs)...)
+				continue
+			}
+			var ambiguity *eventlog.AmbiguousEventError
+			if errors.As(err, &ambiguity) && !RawTxContainsCreatePair(tx) {
+				// Raw transactions remain available, so parser progress does not prevent deterministic replay.
+				parseQuarantines = append(parseQuarantines, ParseQuarantine{
+					Height:   height,
+					Hash:     tx.Hash,
+					Stage:    parseStage(err),
+					Contract: ambiguity.Contract,
+					Action:   ambiguity.Action,
+					Error:    err.Error(),
+					RawTx:    tx,
+				})
+				app.logger.WithFields(logrus.Fields{
+					"event":             "parse_quarantine.created",
+					"operation":         "parse_txs",
+					"chain_id":          app.chainId,
+					"height":            height,
+					"tx_hash":           tx.Hash,
+					"stage":             parseStage(err),
+					"contract":          ambiguity.Contract,
+					"action":            ambiguity.Action,
+					"quarantine_status": QuarantineStatusPending,
+					"err":               logging.NewErrorField(err),
+				}).Warn("parse quarantine created")
+				continue
+			}
+			return ParsedBlock{}, err
+		}
+		parsedTxs = append(parsedTxs, AttributeRoutes(app.routerAddr, tx, txs)...)
+	}
+
+	return ParsedBlock{
+		Height:      height,
+		Txs:         parsedTxs,
+		Pools:       src.pools,
+		Pairs:       pairsOf(parsedTxs),
+		Quarantines: parseQuarantines,
+	}, nil
+}
+
+// commit writes blocks parsed after srcHeight. A single height keeps the
+// per-height Insert, several are written by one InsertBatch transaction.
+func (app *dexApp) commit(srcHeight uint64, blocks []ParsedBlock) error {
+	if len(blocks) == 1 {
+		block := blocks[0]
+		return app.insert(srcHeight, block.Height, block.Txs, block.Pools, block.Quarantines)
+	}
+	if err := app.InsertBatch(srcHeight, blocks); err != nil {
+		return fmt.Errorf("insert batch: %w", err)
+	}
+	return nil
+}
+
 // shouldRetryQuarantine applies the configured retry mode to each parser run.
 func (app *dexApp) shouldRetryQuarantine() bool {
 	switch app.quarantineRetryMode {
@@ -279,6 +470,7 @@ func (app *dexApp) shouldRetryQuarantine() bool {
 }
 
 // retryPendingQuarantines replays unresolved raw transactions and resolves only successful parses.
+// A resolved partial quarantine replaces the rows it inserted for its tx.
 func (app *dexApp) retryPendingQuarantines(tokenExceptions map[string]bool) error {
 	quarantines, err := app.PendingParseQuarantines()
 	if err != nil {
@@ -286,13 +478,7 @@ func (app *dexApp) retryPendingQuarantines(tokenExceptions map[string]bool) erro
 	}
 
 	for _, quarantine := range quarantines {
-		if IsPartialQuarantineStage(quarantine.Stage) {
-			continue
-		}
-		if err := app.UpdateParsers(tokenExceptions, quarantine.Height); err != nil {
-			return fmt.Errorf("update parsers for quarantine id=%d: %w", quarantine.ID, err)
-		}
-		txs, err := app.ParseTxs(quarantine.RawTx, quarantine.Height)
+		txs, err := ReplayQuarantine(app.TargetApp, app.routerAddr, tokenExceptions, quarantine)
 		if err != nil {
 			var ambiguity *eventlog.AmbiguousEventError
 			if errors.As(err, &ambiguity) {
@@ -343,24 +529,28 @@ func parseStage(err error) string {
 
 // insert implements parser
 func (app *dexApp) insert(srcHeight uint64, targetHeight uint64, txs []ParsedTx, pools []PoolInfo, quarantines []ParseQuarantine) error {
+	err := app.Insert(srcHeight, targetHeight, txs, pools, pairsOf(txs), quarantines)
+	if err != nil {
+		return fmt.Errorf("insert: %w", err)
+	}
+
+	return nil
+}
+
+// pairsOf returns the pairs created by txs.
+func pairsOf(txs []ParsedTx) []Pair {
 	pairDtos := []Pair{}
 	for _, tx := range txs {
 		if tx.Type == CreatePair {
 			pairDto := Pair{
 				ContractAddr: tx.ContractAddr,
-				Assets:       []string{tx.Assets[0].Addr, tx.Assets[1].Addr},
+				Assets:       tx.AssetAddrs(),
 				LpAddr:       tx.LpAddr,
 			}
 			pairDtos = append(pairDtos, pairDto)
 		}
 	}
-
-	err := app.Insert(srcHeight, targetHeight, txs, pools, pairDtos, quarantines)
-	if err != nil {
-		return fmt.Errorf("insert: %w", err)
-	}
-
-	return nil
+	return pairDtos
 }
 
 // checkRemoteHeight implements Dex
@@ -376,6 +566,14 @@ func (app *dexApp) checkRemoteHeight(srcHeight uint64) error {
 	return nil
 }
 
+// isPoolSnapshotHeight reports whether the pools of height are saved.
+func (app *dexApp) isPoolSnapshotHeight(height uint64) bool {
+	if app.poolSnapshotInterval == 0 {
+		return false
+	}
+	return height%uint64(app.poolSnapshotInterval) == 0
+}
+
 // isValidationHeight reports whether the given height is a configured
 // validation interval boundary.
 func (app *dexApp) isValidationHeight(height uint64) bool {
@@ -503,6 +701,8 @@ func (app *dexApp) validateAtHeight(height uint64) bool {
 			for _, mismatch := range validationErr.Mismatches {
 				app.logPoolValidationMismatch(height, mismatch)
 			}
+			app.savePoolValidationResult(PoolValidationResult{Height: height, Status: PoolValidationFailed, Mismatches: validationErr.Mismatches})
+			app.bisectValidationFailure(height, validationErr.Mismatches)
 		} else {
 			app.logger.WithFields(logrus.Fields{
 				"event":     "parser.pool_validation_failed",
@@ -514,11 +714,67 @@ func (app *dexApp) validateAtHeight(height uint64) bool {
 		}
 		return false
 	}
+	status := PoolValidationPassed
+	if len(poolInfos) == 0 {
+		status = PoolValidationSkipped
+	}
+	app.savePoolValidationResult(PoolValidationResult{Height: height, Status: status, Mismatches: []PoolValidationMismatch{}})
 	return true
 }
 
+// savePoolValidationResult records the outcome of a validation height. A
+// failed save is only logged, the validation cursor does not depend on it.
+func (app *dexApp) savePoolValidationResult(result PoolValidationResult) {
+	if err := app.SavePoolValidationResult(result); err != nil {
+		app.logger.Errorf("validator: failed to save %s result of height %d: %s", result.Status, result.Height, err)
+	}
+}
+
+// bisectValidationFailure narrows each pool with an asset amount mismatch at
+// height down to the height it diverged at and logs the txs of that height.
+// A failing height is retried on every wakeup, so it is bisected only once.
+func (app *dexApp) bisectValidationFailure(height uint64, mismatches []PoolValidationMismatch) {
+	if !app.bisectValidationFailures || app.bisectedHeight == height {
+		return
+	}
+	app.bisectedHeight = height
+
+	good := uint64(0)
+	if height > uint64(app.validationInterval) {
+		good = height - uint64(app.validationInterval)
+	}
+	bisected := map[string]bool{}
+	for _, mismatch := range mismatches {
+		if mismatch.Type != validationMismatchAssetAmount || bisected[mismatch.Contract] {
+			continue
+		}
+		bisected[mismatch.Contract] = true
+
+		divergence, err := BisectPoolDivergence(app.SourceDataStore, app.Repo, mismatch.Contract, good, height)
+		if err != nil {
+			app.logger.Errorf("validator: failed to bisect pool %s at height %d: %s", mismatch.Contract, height, err)
+			continue
+		}
+		hashes := make([]string, 0, len(divergence.RawTxs))
+		for _, tx := range divergence.RawTxs {
+			hashes = append(hashes, tx.Hash)
+		}
+		app.logger.WithFields(logrus.Fields{
+			"event":                "parser.pool_validation_bisected",
+			"operation":            "pool_validation",
+			"chain_id":             app.chainId,
+			"height":               height,
+			"contract":             divergence.Contract,
+			"divergence_height":    divergence.Height,
+			"last_matching_height": divergence.LastMatchingHeight,
+			"tx_hashes":            hashes,
+			"mismatch_count":       len(divergence.Mismatches),
+		}).Warn("pool validation failure bisected")
+	}
+}
+
 // logPoolValidationMismatch emits the agent-facing root log for one pool validation mismatch.
-func (app *dexApp) logPoolValidationMismatch(height uint64, mismatch poolValidationMismatch) {
+func (app *dexApp) logPoolValidationMismatch(height uint64, mismatch PoolValidationMismatch) {
 	app.logger.WithFields(logrus.Fields{
 		"event":         "parser.pool_validation_failed",
 		"operation":     "pool_validation",
@@ -575,7 +831,7 @@ func (app *dexApp) validate(from, to uint64, expected []PoolInfo) error {
 		}
 		exp, ok := expectedPool[pool.ContractAddr]
 		if !ok {
-			validationErr.Add(poolValidationMismatch{
+			validationErr.Add(PoolValidationMismatch{
 				Type:     validationMismatchUnexpectedPool,
 				Contract: pool.ContractAddr,
 				Actual:   pool.TotalShare,
@@ -589,7 +845,7 @@ func (app *dexApp) validate(from, to uint64, expected []PoolInfo) error {
 		delete(expectedPool, pool.ContractAddr)
 	}
 	for _, pool := range expectedPool {
-		validationErr.Add(poolValidationMismatch{
+		validationErr.Add(PoolValidationMismatch{
 			Type:     validationMismatchExpectedPoolMissing,
 			Contract: pool.ContractAddr,
 			Expected: pool.TotalShare,
@@ -601,18 +857,18 @@ func (app *dexApp) validate(from, to uint64, expected []PoolInfo) error {
 	return nil
 }
 
-// poolValidationMismatch describes one concrete difference between source pool state and parsed DB state.
-type poolValidationMismatch struct {
-	Type     string
-	Contract string
-	Asset    string
-	Actual   string
-	Expected string
+// PoolValidationMismatch describes one concrete difference between source pool state and parsed DB state.
+type PoolValidationMismatch struct {
+	Type     string `json:"type"`
+	Contract string `json:"contract"`
+	Asset    string `json:"asset,omitempty"`
+	Actual   string `json:"actual"`
+	Expected string `json:"expected"`
 }
 
 // poolValidationError aggregates every mismatch found during one validation run.
 type poolValidationError struct {
-	Mismatches []poolValidationMismatch
+	Mismatches []PoolValidationMismatch
 }
 
 // Error summarizes the aggregated validation failure without hiding per-pool mismatch details.
@@ -624,7 +880,7 @@ func (e *poolValidationError) Error() string {
 }
 
 // Add records one mismatch while allowing validation to continue collecting the rest.
-func (e *poolValidationError) Add(mismatch poolValidationMismatch) {
+func (e *poolValidationError) Add(mismatch PoolValidationMismatch) {
 	e.Mismatches = append(e.Mismatches, mismatch)
 }
 
@@ -635,33 +891,7 @@ func (e *poolValidationError) HasMismatches() bool {
 
 // collectPairValidationMismatches compares one pair and appends every asset/share mismatch.
 func (app *dexApp) collectPairValidationMismatches(actual PoolInfo, expected PoolInfo, validationErr *poolValidationError) error {
-	var mismatches []poolValidationMismatch
-
-	for idx, expAsset := range expected.Assets {
-		actualAmount := ""
-		if idx < len(actual.Assets) {
-			actualAmount = actual.Assets[idx].Amount
-		}
-		if expAsset.Amount != actualAmount {
-			mismatches = append(mismatches, poolValidationMismatch{
-				Type:     validationMismatchAssetAmount,
-				Contract: actual.ContractAddr,
-				Asset:    expAsset.Addr,
-				Actual:   actualAmount,
-				Expected: expAsset.Amount,
-			})
-		}
-	}
-
-	if expected.TotalShare != actual.TotalShare {
-		mismatches = append(mismatches, poolValidationMismatch{
-			Type:     validationMismatchTotalShare,
-			Contract: actual.ContractAddr,
-			Actual:   actual.TotalShare,
-			Expected: expected.TotalShare,
-		})
-	}
-
+	mismatches := comparePools(actual, expected)
 	if len(mismatches) == 0 {
 		return nil
 	}
@@ -688,6 +918,37 @@ func (app *dexApp) collectPairValidationMismatches(actual PoolInfo, expected Poo
 	return nil
 }
 
+// comparePools lists every asset amount and total share of expected that actual differs in.
+func comparePools(actual PoolInfo, expected PoolInfo) []PoolValidationMismatch {
+	var mismatches []PoolValidationMismatch
+
+	for idx, expAsset := range expected.Assets {
+		actualAmount := ""
+		if idx < len(actual.Assets) {
+			actualAmount = actual.Assets[idx].Amount
+		}
+		if expAsset.Amount != actualAmount {
+			mismatches = append(mismatches, PoolValidationMismatch{
+				Type:     validationMismatchAssetAmount,
+				Contract: actual.ContractAddr,
+				Asset:    expAsset.Addr,
+				Actual:   actualAmount,
+				Expected: expAsset.Amount,
+			})
+		}
+	}
+
+	if expected.TotalShare != actual.TotalShare {
+		mismatches = append(mismatches, PoolValidationMismatch{
+			Type:     validationMismatchTotalShare,
+			Contract: actual.ContractAddr,
+			Actual:   actual.TotalShare,
+			Expected: expected.TotalShare,
+		})
+	}
+	return mismatches
+}
+
 // isValidationExceptionCandidate safely delegates exception detection to the target app.
 func (app *dexApp) isValidationExceptionCandidate(contractAddress string) bool {
 	if app.TargetApp == nil {
@@ -734,7 +995,7 @@ type transferPopEntry struct {
 func (mixin *DexMixin) RemoveDuplicatedTxs(pairTxs []*ParsedTx, transferTxs []*ParsedTx) []ParsedTx {
 	popList := []transferPopEntry{}
 	for _, ptx := range pairTxs {
-		for _, asset := range ptx.Assets {
+		for _, asset := range ptx.AllAssets() {
 			if asset.Amount != "" && asset.Amount != "0" {
 				popList = append(popList, transferPopEntry{ptx.ContractAddr, asset.Addr, asset.Amount})
 			}
@@ -771,7 +1032,7 @@ func (mixin *DexMixin) matchesPairTransferEntry(entry transferPopEntry, transfer
 		return false
 	}
 
-	for _, asset := range transferTx.Assets {
+	for _, asset := range transferTx.AllAssets() {
 		if asset.Addr != entry.assetAddr || asset.Amount == "" {
 			continue
 		}
@@ -2,6 +2,7 @@ package dex
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"sync"
@@ -374,11 +375,14 @@ func Test_retryPendingQuarantines_LeavesAmbiguousReplayPending(t *testing.T) {
 	repo.AssertNotCalled(t, "ResolveParseQuarantine", mock.Anything)
 }
 
-func Test_retryPendingQuarantines_SkipsPartialQuarantine(t *testing.T) {
+func Test_retryPendingQuarantines_ResolvesPartialQuarantine(t *testing.T) {
 	rawTx := parser.RawTx{Hash: "partial"}
+	parsedTxs := []ParsedTx{
+		{Hash: rawTx.Hash, Type: Swap, ContractAddr: "pair"},
+		{Hash: rawTx.Hash, Type: Transfer, ContractAddr: "pair"},
+	}
 	target := &quarantineTargetApp{parse: func(parser.RawTx, uint64) ([]ParsedTx, error) {
-		t.Fatal("partial quarantines must not be retried")
-		return nil, nil
+		return parsedTxs, nil
 	}}
 	repo := &RepoMock{}
 	app := &dexApp{
@@ -393,6 +397,32 @@ func Test_retryPendingQuarantines_SkipsPartialQuarantine(t *testing.T) {
 		Stage:  PartialQuarantineStagePrefix + "wasm_transfer",
 		RawTx:  rawTx,
 	}}, nil)
+	repo.On("ResolveParseQuarantine", uint64(9), uint64(12), parsedTxs).Return(nil)
+
+	require.NoError(t, app.retryPendingQuarantines(map[string]bool{}))
+	repo.AssertExpectations(t)
+}
+
+func Test_retryPendingQuarantines_LeavesAmbiguousPartialReplayPending(t *testing.T) {
+	rawTx := parser.RawTx{Hash: "still-partial"}
+	target := &quarantineTargetApp{parse: func(parser.RawTx, uint64) ([]ParsedTx, error) {
+		return []ParsedTx{{Hash: rawTx.Hash, Type: Swap}}, &PartialParseQuarantineError{
+			Err: &eventlog.AmbiguousEventError{Key: "amount", Values: []string{"1", "2"}},
+		}
+	}}
+	repo := &RepoMock{}
+	app := &dexApp{
+		TargetApp: target,
+		Repo:      repo,
+		logger:    logging.Discard,
+	}
+	repo.On("PendingParseQuarantines").Return([]ParseQuarantine{{
+		ID:     10,
+		Height: 13,
+		Hash:   rawTx.Hash,
+		Stage:  PartialQuarantineStagePrefix + "wasm_transfer",
+		RawTx:  rawTx,
+	}}, nil)
 
 	require.NoError(t, app.retryPendingQuarantines(map[string]bool{}))
 	repo.AssertNumberOfCalls(t, "ResolveParseQuarantine", 0)
@@ -483,7 +513,7 @@ func Test_validate_CollectsAllPoolValidationMismatches(t *testing.T) {
 
 	var validationErr *poolValidationError
 	require.ErrorAs(t, err, &validationErr)
-	assert.ElementsMatch(t, []poolValidationMismatch{
+	assert.ElementsMatch(t, []PoolValidationMismatch{
 		{Type: validationMismatchAssetAmount, Contract: "pair1", Asset: "asset0", Actual: "400", Expected: "500"},
 		{Type: validationMismatchAssetAmount, Contract: "pair1", Asset: "asset1", Actual: "450", Expected: "500"},
 		{Type: validationMismatchTotalShare, Contract: "pair1", Actual: "900", Expected: "1000"},
@@ -550,11 +580,11 @@ func Test_validateAtHeight_LogsEveryPoolValidationMismatch(t *testing.T) {
 }
 
 var (
-	createTx   = ParsedTx{"", time.Time{}, CreatePair, "sender", "PAIR_ADDR", [2]Asset{{"Asset0", "1000"}, {"Asset1", "1000"}}, "Lp", "1000", "", 0, nil}
-	swapTx     = ParsedTx{"", time.Time{}, Swap, "sender", "PAIR_ADDR", [2]Asset{{"Asset0", "1000"}, {"Asset1", "-1000"}}, "", "", "1", 0, nil}
-	provideTx  = ParsedTx{"", time.Time{}, Provide, "sender", "PAIR_ADDR", [2]Asset{{"Asset0", "1000"}, {"Asset1", "1000"}}, "Lp", "1000", "", 0, nil}
-	withdrawTx = ParsedTx{"", time.Time{}, Withdraw, "sender", "PAIR_ADDR", [2]Asset{{"Asset0", "-1000"}, {"Asset1", "-1000"}}, "Lp", "1000", "", 0, nil}
-	transferTx = ParsedTx{"", time.Time{}, Transfer, "sender", "PAIR_ADDR", [2]Asset{{"Asset0", ""}, {"Asset1", "1000"}}, "", "", "", 0, nil}
+	createTx   = ParsedTx{"", time.Time{}, CreatePair, "sender", "PAIR_ADDR", [2]Asset{{"Asset0", "1000"}, {"Asset1", "1000"}}, nil, "Lp", "1000", "", 0, nil}
+	swapTx     = ParsedTx{"", time.Time{}, Swap, "sender", "PAIR_ADDR", [2]Asset{{"Asset0", "1000"}, {"Asset1", "-1000"}}, nil, "", "", "1", 0, nil}
+	provideTx  = ParsedTx{"", time.Time{}, Provide, "sender", "PAIR_ADDR", [2]Asset{{"Asset0", "1000"}, {"Asset1", "1000"}}, nil, "Lp", "1000", "", 0, nil}
+	withdrawTx = ParsedTx{"", time.Time{}, Withdraw, "sender", "PAIR_ADDR", [2]Asset{{"Asset0", "-1000"}, {"Asset1", "-1000"}}, nil, "Lp", "1000", "", 0, nil}
+	transferTx = ParsedTx{"", time.Time{}, Transfer, "sender", "PAIR_ADDR", [2]Asset{{"Asset0", ""}, {"Asset1", "1000"}}, nil, "", "", "", 0, nil}
 )
 
 // Test_matchesPairTransferEntry verifies that transfers are matched to pair entries
@@ -746,6 +776,14 @@ type testRepoMock struct {
 	setValidationArgs []uint64
 	setValidationErrs []error
 	clearCount        int
+	validationResults []PoolValidationResult
+}
+
+func (m *testRepoMock) SavePoolValidationResult(result PoolValidationResult) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.validationResults = append(m.validationResults, result)
+	return nil
 }
 
 func (m *testRepoMock) GetValidationHeight() (uint64, error) {
@@ -811,6 +849,10 @@ func Test_processPendingValidations_AdvancesPersistedCursor(t *testing.T) {
 
 	assert.Equal(t, []uint64{secondHeight, 300}, repo.setValidationArgs)
 	assert.Equal(t, uint64(300), repo.validationHeight)
+	assert.Equal(t, []PoolValidationResult{
+		{Height: firstHeight, Status: PoolValidationPassed, Mismatches: []PoolValidationMismatch{}},
+		{Height: secondHeight, Status: PoolValidationPassed, Mismatches: []PoolValidationMismatch{}},
+	}, repo.validationResults)
 }
 
 func Test_processPendingValidations_LeavesCursorOnValidationFailure(t *testing.T) {
@@ -838,6 +880,31 @@ func Test_processPendingValidations_LeavesCursorOnValidationFailure(t *testing.T
 
 	assert.Empty(t, repo.setValidationArgs)
 	assert.Equal(t, height, repo.validationHeight)
+	assert.Equal(t, []PoolValidationResult{{
+		Height: height,
+		Status: PoolValidationFailed,
+		Mismatches: []PoolValidationMismatch{{
+			Type:     validationMismatchExpectedPoolMissing,
+			Contract: "pool1",
+			Expected: "1000",
+		}},
+	}}, repo.validationResults)
+}
+
+func Test_validateAtHeight_RecordsSkippedWithoutSourcePools(t *testing.T) {
+	srcStore := &RawStoreMock{}
+	repo := &testRepoMock{}
+	app := &dexApp{
+		Repo:            repo,
+		SourceDataStore: srcStore,
+		logger:          logging.Discard,
+	}
+	srcStore.On("GetPoolInfos", uint64(100)).Return([]PoolInfo{}, nil)
+
+	assert.True(t, app.validateAtHeight(100))
+	assert.Equal(t, []PoolValidationResult{
+		{Height: 100, Status: PoolValidationSkipped, Mismatches: []PoolValidationMismatch{}},
+	}, repo.validationResults)
 }
 
 func Test_triggerValidation_PersistsCursorBeforeValidation(t *testing.T) {
@@ -934,3 +1001,172 @@ func Test_Run_FollowsPersistedValidationCursor(t *testing.T) {
 		return validationHeight == 300 && assert.ObjectsAreEqual([]uint64{secondHeight, 300}, setValidationArgs)
 	}, time.Second, 10*time.Millisecond)
 }
+
+func Test_Run_PrefetchesSourceAndCommitsInBatches(t *testing.T) {
+	parsedHeights := []uint64{}
+	target := &quarantineTargetApp{parse: func(tx parser.RawTx, height uint64) ([]ParsedTx, error) {
+		parsedHeights = append(parsedHeights, height)
+		return []ParsedTx{{Hash: tx.Hash, Type: Transfer}}, nil
+	}}
+	repo := &RepoMock{}
+	srcStore := &RawStoreMock{}
+	app := &dexApp{
+		TargetApp:            target,
+		Repo:                 repo,
+		SourceDataStore:      srcStore,
+		logger:               logging.Discard,
+		poolSnapshotInterval: 100,
+		sameHeightTolerance:  3,
+		quarantineRetryMode:  configs.QuarantineRetryDisabled,
+		prefetchDepth:        2,
+		commitBatchSize:      2,
+	}
+
+	repo.On("GetTokenExceptions").Return(map[string]bool{}, nil)
+	repo.On("GetSyncedHeight").Return(uint64(0), nil)
+	srcStore.On("GetSourceSyncedHeight").Return(uint64(3), nil)
+	for height := uint64(1); height <= 3; height++ {
+		srcStore.On("GetSourceTxs", height).Return(parser.RawTxs{{Hash: fmt.Sprintf("tx%d", height)}}, nil)
+	}
+	block := func(height uint64) ParsedBlock {
+		return ParsedBlock{
+			Height:      height,
+			Txs:         []ParsedTx{{Hash: fmt.Sprintf("tx%d", height), Type: Transfer}},
+			Pools:       []PoolInfo{},
+			Pairs:       []Pair{},
+			Quarantines: []ParseQuarantine{},
+		}
+	}
+	repo.On("InsertBatch", uint64(0), []ParsedBlock{block(1), block(2)}).Return(nil)
+	repo.On("Insert", uint64(2), uint64(3), block(3).Txs, []PoolInfo{}, []Pair{}, []ParseQuarantine{}).Return(nil)
+
+	require.NoError(t, app.Run())
+	require.Equal(t, []uint64{1, 2, 3}, parsedHeights)
+	repo.AssertExpectations(t)
+	srcStore.AssertExpectations(t)
+}
+
+// streamStoreMock streams the txs of the heights it has, stopping at the first
+// missing one like the collector gRPC source.
+type streamStoreMock struct {
+	*RawStoreMock
+	streamed map[uint64]parser.RawTxs
+	calls    int
+}
+
+func (s *streamStoreMock) StreamSourceTxs(ctx context.Context, from, to uint64, handle func(uint64, parser.RawTxs) error) error {
+	s.calls++
+	for height := from; height <= to; height++ {
+		txs, ok := s.streamed[height]
+		if !ok {
+			return nil
+		}
+		if err := handle(height, txs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func Test_Run_StreamsSourceAndFetchesHeightsAfterStreamEnds(t *testing.T) {
+	parsedHeights := []uint64{}
+	target := &quarantineTargetApp{parse: func(tx parser.RawTx, height uint64) ([]ParsedTx, error) {
+		parsedHeights = append(parsedHeights, height)
+		return []ParsedTx{}, nil
+	}}
+	repo := &RepoMock{}
+	srcStore := &streamStoreMock{
+		RawStoreMock: &RawStoreMock{},
+		streamed:     map[uint64]parser.RawTxs{1: {{Hash: "tx1"}}, 2: {{Hash: "tx2"}}},
+	}
+	app := &dexApp{
+		TargetApp:            target,
+		Repo:                 repo,
+		SourceDataStore:      srcStore,
+		logger:               logging.Discard,
+		poolSnapshotInterval: 100,
+		sameHeightTolerance:  3,
+		quarantineRetryMode:  configs.QuarantineRetryDisabled,
+		prefetchDepth:        2,
+		commitBatchSize:      10,
+	}
+
+	repo.On("GetTokenExceptions").Return(map[string]bool{}, nil)
+	repo.On("GetSyncedHeight").Return(uint64(0), nil)
+	srcStore.On("GetSourceSyncedHeight").Return(uint64(3), nil)
+	srcStore.On("GetSourceTxs", uint64(3)).Return(parser.RawTxs{{Hash: "tx3"}}, nil)
+	repo.On("InsertBatch", uint64(0), mock.MatchedBy(func(blocks []ParsedBlock) bool {
+		return len(blocks) == 3 && blocks[0].Height == 1 && blocks[2].Height == 3
+	})).Return(nil)
+
+	require.NoError(t, app.Run())
+	require.Equal(t, []uint64{1, 2, 3}, parsedHeights)
+	require.Equal(t, 1, srcStore.calls)
+	srcStore.AssertNotCalled(t, "GetSourceTxs", uint64(1))
+	srcStore.AssertNotCalled(t, "GetSourceTxs", uint64(2))
+	repo.AssertExpectations(t)
+	srcStore.AssertExpectations(t)
+}
+
+func Test_Run_CommitsParsedHeightsBeforeSourceFailure(t *testing.T) {
+	target := &quarantineTargetApp{parse: func(tx parser.RawTx, _ uint64) ([]ParsedTx, error) {
+		return []ParsedTx{}, nil
+	}}
+	repo := &RepoMock{}
+	srcStore := &RawStoreMock{}
+	app := &dexApp{
+		TargetApp:            target,
+		Repo:                 repo,
+		SourceDataStore:      srcStore,
+		logger:               logging.Discard,
+		poolSnapshotInterval: 100,
+		sameHeightTolerance:  3,
+		quarantineRetryMode:  configs.QuarantineRetryDisabled,
+		commitBatchSize:      10,
+	}
+
+	repo.On("GetTokenExceptions").Return(map[string]bool{}, nil)
+	repo.On("GetSyncedHeight").Return(uint64(0), nil)
+	srcStore.On("GetSourceSyncedHeight").Return(uint64(3), nil)
+	srcStore.On("GetSourceTxs", uint64(1)).Return(parser.RawTxs{}, nil)
+	srcStore.On("GetSourceTxs", uint64(2)).Return(parser.RawTxs{}, nil)
+	srcStore.On("GetSourceTxs", uint64(3)).Return(parser.RawTxs(nil), errors.New("source unavailable"))
+	repo.On("InsertBatch", uint64(0), mock.MatchedBy(func(blocks []ParsedBlock) bool {
+		return len(blocks) == 2 && blocks[0].Height == 1 && blocks[1].Height == 2
+	})).Return(nil)
+
+	require.ErrorContains(t, app.Run(), "source unavailable")
+	repo.AssertExpectations(t)
+}
+
+func Test_ParseRange_PassesEachHeightInOrder(t *testing.T) {
+	target := &quarantineTargetApp{parse: func(tx parser.RawTx, _ uint64) ([]ParsedTx, error) {
+		return []ParsedTx{{
+			Hash:         tx.Hash,
+			Type:         Transfer,
+			Sender:       "sender",
+			ContractAddr: "pair",
+			Assets:       [2]Asset{{Addr: "asset0", Amount: "1"}, {Addr: "asset1", Amount: "0"}},
+		}}, nil
+	}}
+	srcStore := &RawStoreMock{}
+	srcStore.On("GetSourceTxs", uint64(1)).Return(parser.RawTxs{{Hash: "tx1"}}, nil)
+	srcStore.On("GetSourceTxs", uint64(2)).Return(parser.RawTxs{{Hash: "tx2"}}, nil)
+	srcStore.On("GetSourceTxs", uint64(3)).Return(parser.RawTxs(nil), errors.New("source unavailable"))
+	c := configs.ParserDexConfig{ChainId: "chain-1", PoolSnapshotInterval: 100}
+
+	blocks := []ParsedBlock{}
+	err := ParseRange(target, srcStore, map[string]bool{}, c, logging.Discard, 1, 2, func(block ParsedBlock) error {
+		blocks = append(blocks, block)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, blocks, 2)
+	assert.Equal(t, uint64(1), blocks[0].Height)
+	assert.Equal(t, "tx1", blocks[0].Txs[0].Hash)
+	assert.Equal(t, uint64(2), blocks[1].Height)
+	assert.Equal(t, "tx2", blocks[1].Txs[0].Hash)
+
+	err = ParseRange(target, srcStore, map[string]bool{}, c, logging.Discard, 2, 3, func(ParsedBlock) error { return nil })
+	require.ErrorContains(t, err, "parse range at height 3")
+}
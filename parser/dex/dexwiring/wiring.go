@@ -3,15 +3,20 @@ package dexwiring
 import (
 	"fmt"
 
+	"github.com/dezswap/cosmwasm-etl/collector/archive"
 	"github.com/dezswap/cosmwasm-etl/collector/datastore"
 	collectorrepo "github.com/dezswap/cosmwasm-etl/collector/repo"
+	fcd_collector "github.com/dezswap/cosmwasm-etl/collector/terra/fcd"
 	"github.com/dezswap/cosmwasm-etl/configs"
 	p_dex "github.com/dezswap/cosmwasm-etl/parser/dex"
+	pap "github.com/dezswap/cosmwasm-etl/parser/dex/astroport"
 	pds "github.com/dezswap/cosmwasm-etl/parser/dex/dezswap"
+	pgeneric "github.com/dezswap/cosmwasm-etl/parser/dex/generic"
 	"github.com/dezswap/cosmwasm-etl/parser/dex/srcstore"
 	ts_srcstore "github.com/dezswap/cosmwasm-etl/parser/dex/srcstore/terraswap"
 	psf "github.com/dezswap/cosmwasm-etl/parser/dex/starfleit"
 	pts "github.com/dezswap/cosmwasm-etl/parser/dex/terraswap"
+	"github.com/dezswap/cosmwasm-etl/pkg/db"
 	"github.com/dezswap/cosmwasm-etl/pkg/dex"
 	"github.com/dezswap/cosmwasm-etl/pkg/grpc"
 	"github.com/dezswap/cosmwasm-etl/pkg/httpclient"
@@ -28,6 +33,10 @@ func NewTargetApp(repo p_dex.PairRepo, logger logging.Logger, c configs.ParserDe
 		return pds.New(repo, logger, c, c.ChainId)
 	case dex.Starfleit:
 		return psf.New(repo, logger, c, c.ChainId)
+	case dex.Astroport:
+		return pap.New(repo, logger, c)
+	case dex.Generic:
+		return pgeneric.New(repo, logger, c)
 	default:
 		return nil, fmt.Errorf("unknown target app: %s", c.TargetApp)
 	}
@@ -38,7 +47,7 @@ func NewTargetReadStore(c configs.Config, dc configs.ParserDexConfig) (datastore
 	switch dc.TargetApp {
 	case dex.Terraswap:
 		return nil, nil
-	case dex.Dezswap, dex.Starfleit:
+	case dex.Dezswap, dex.Starfleit, dex.Astroport, dex.Generic:
 		return NewCollectorReadStore(c, dc)
 	default:
 		return nil, fmt.Errorf("unknown target app: %s", dc.TargetApp)
@@ -74,19 +83,55 @@ func NewCollectorReadStore(c configs.Config, dc configs.ParserDexConfig) (datast
 	if err != nil {
 		return nil, err
 	}
-	return datastore.NewReadStore(dc.ChainId, s3Client), nil
+	store := datastore.NewReadStore(dc.ChainId, s3Client)
+	if c.S3.Archive {
+		return archive.NewReadStore(dc.ChainId, s3Client, store), nil
+	}
+	return store, nil
 }
 
 // NewSourceDataStore builds the raw transaction source used by parser commands.
+// When a websocket host is configured, the store is wrapped with a NewBlock
+// subscription so callers can wait for new heights instead of polling.
 func NewSourceDataStore(dc configs.ParserDexConfig, rdbc configs.RdbConfig, readStore datastore.ReadStore, logger logging.Logger) (p_dex.SourceDataStore, error) {
+	store, err := newSourceDataStore(dc, rdbc, readStore, logger)
+	if err != nil {
+		return nil, err
+	}
+	if wsHost := dc.NodeConfig.RestClientConfig.WebsocketHost; wsHost != "" {
+		return srcstore.NewSubscription(wsHost, store, logger), nil
+	}
+	return store, nil
+}
+
+func newSourceDataStore(dc configs.ParserDexConfig, rdbc configs.RdbConfig, readStore datastore.ReadStore, logger logging.Logger) (p_dex.SourceDataStore, error) {
+	if dc.CollectorGrpc.Host != "" {
+		conn := grpc.GetServiceDesc("collector-api", dc.CollectorGrpc).GetConnection()
+		if conn == nil {
+			return nil, fmt.Errorf("cannot connect to collector gRPC API: %s", dc.CollectorGrpc.Host)
+		}
+		pools, err := newNodePoolSource(dc, readStore)
+		if err != nil {
+			return nil, err
+		}
+		return srcstore.NewCollectorGrpc(dc.ChainId, conn, pools), nil
+	}
+
 	switch dc.TargetApp {
 	case dex.Terraswap:
+		if dc.FcdSource {
+			gormDB, err := db.OpenGormPostgres(rdbc)
+			if err != nil {
+				return nil, err
+			}
+			return ts_srcstore.NewFcdStore(dc.ChainId, fcd_collector.NewPermanentStore(gormDB), collectorrepo.NewWithDB(gormDB)), nil
+		}
 		fallback, err := ts_srcstore.NewFromConfig(dc.NodeConfig, dc.FactoryAddress)
 		if err != nil {
 			return nil, err
 		}
 		return srcstore.NewCollectorFallback(dc.ChainId, collectorrepo.New(rdbc), fallback, logger), nil
-	case dex.Dezswap, dex.Starfleit:
+	case dex.Dezswap, dex.Starfleit, dex.Astroport, dex.Generic:
 		if readStore == nil {
 			return nil, fmt.Errorf("collector read store is required for target app: %s", dc.TargetApp)
 		}
@@ -95,3 +140,20 @@ func NewSourceDataStore(dc configs.ParserDexConfig, rdbc configs.RdbConfig, read
 		return nil, fmt.Errorf("unknown target app: %s", dc.TargetApp)
 	}
 }
+
+// newNodePoolSource returns the node backed store the collector gRPC source
+// reads pools from at heights without a collected snapshot, or nil when the
+// target app has none.
+func newNodePoolSource(dc configs.ParserDexConfig, readStore datastore.ReadStore) (p_dex.SourceDataStore, error) {
+	switch dc.TargetApp {
+	case dex.Terraswap:
+		return ts_srcstore.NewFromConfig(dc.NodeConfig, dc.FactoryAddress)
+	case dex.Dezswap, dex.Starfleit, dex.Astroport, dex.Generic:
+		if readStore == nil {
+			return nil, nil
+		}
+		return srcstore.New(readStore), nil
+	default:
+		return nil, fmt.Errorf("unknown target app: %s", dc.TargetApp)
+	}
+}
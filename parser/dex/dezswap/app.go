@@ -21,6 +21,8 @@ type dezswapApp struct {
 	// state
 	pairs       map[string]dex.Pair
 	lpPairAddrs map[string]string
+
+	parsersVersion dex.ParsersVersion
 }
 
 var _ dex.TargetApp = &dezswapApp{}
@@ -49,7 +51,7 @@ func New(repo dex.PairRepo, _ logging.Logger, c configs.ParserDexConfig, chainId
 		lpPairAddrs[p.LpAddr] = p.ContractAddr
 	}
 
-	return &dezswapApp{repo, parsers, dex.DexMixin{}, chainId, pairs, lpPairAddrs}, nil
+	return &dezswapApp{repo, parsers, dex.DexMixin{}, chainId, pairs, lpPairAddrs, dex.ParsersVersion{}}, nil
 }
 
 func (p *dezswapApp) ParseTxs(tx parser.RawTx, height uint64) ([]dex.ParsedTx, error) {
@@ -63,7 +65,7 @@ func (p *dezswapApp) ParseTxs(tx parser.RawTx, height uint64) ([]dex.ParsedTx, e
 		p.pairs[ctx.ContractAddr] = dex.Pair{
 			ContractAddr: ctx.ContractAddr,
 			LpAddr:       ctx.LpAddr,
-			Assets:       []string{ctx.Assets[0].Addr, ctx.Assets[1].Addr},
+			Assets:       ctx.AssetAddrs(),
 		}
 		p.lpPairAddrs[ctx.LpAddr] = ctx.ContractAddr
 		ctx.Sender = tx.Sender
@@ -141,6 +143,14 @@ func (p *dezswapApp) IsValidationExceptionCandidate(contractAddress string) bool
 }
 
 func (p *dezswapApp) UpdateParsers(tokenExceptions map[string]bool, height uint64) error {
+	mapperVersion, err := pairMapperVersion(p.chainId, height)
+	if err != nil {
+		return errors.Wrap(err, "updateParsers")
+	}
+	if !p.parsersVersion.Stale(len(p.pairs), mapperVersion, tokenExceptions) {
+		return nil
+	}
+
 	pairFilter := make(map[string]bool)
 	for k := range p.pairs {
 		pairFilter[k] = true
@@ -203,5 +213,6 @@ func (p *dezswapApp) UpdateParsers(tokenExceptions map[string]bool, height uint6
 		p.Parsers.BurnParser = parser.NewParser(burnRule, dex.NewBurnMapper())
 	}
 
+	p.parsersVersion.Record(len(p.pairs), mapperVersion, tokenExceptions)
 	return nil
 }
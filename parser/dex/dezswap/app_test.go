@@ -7,9 +7,13 @@ import (
 	"testing"
 	"time"
 
+	abcitypes "github.com/cometbft/cometbft/abci/types"
+	"github.com/dezswap/cosmwasm-etl/collector/datastore"
 	"github.com/dezswap/cosmwasm-etl/configs"
 	"github.com/dezswap/cosmwasm-etl/parser"
 	"github.com/dezswap/cosmwasm-etl/parser/dex"
+	"github.com/dezswap/cosmwasm-etl/parser/srcstore"
+	ds "github.com/dezswap/cosmwasm-etl/pkg/dex/dezswap"
 	"github.com/dezswap/cosmwasm-etl/pkg/eventlog"
 	"github.com/dezswap/cosmwasm-etl/pkg/logging"
 	"github.com/stretchr/testify/assert"
@@ -248,17 +252,17 @@ func Test_ParseTxs_PartialQuarantineKeepsParsedTxsFromSameRawLog(t *testing.T) {
 	}}
 	wasmTransferParser := parseFunc{parse: func(eventlog.LogResults, parser.Overrider[dex.ParsedTx], ...interface{}) ([]*dex.ParsedTx, error) {
 		return []*dex.ParsedTx{{
-				Hash:         txHash,
-				Type:         dex.Transfer,
-				Sender:       "partial-sender",
-				ContractAddr: "partial-token",
-				Assets:       [2]dex.Asset{{Addr: asset1, Amount: "3"}},
-			}}, &eventlog.AmbiguousEventError{
-				Contract: "ambiguous-token",
-				Action:   "transfer",
-				Key:      "amount",
-				Values:   []string{"1", "2"},
-			}
+			Hash:         txHash,
+			Type:         dex.Transfer,
+			Sender:       "partial-sender",
+			ContractAddr: "partial-token",
+			Assets:       [2]dex.Asset{{Addr: asset1, Amount: "3"}},
+		}}, &eventlog.AmbiguousEventError{
+			Contract: "ambiguous-token",
+			Action:   "transfer",
+			Key:      "amount",
+			Values:   []string{"1", "2"},
+		}
 	}}
 	transferParser := parseFunc{parse: func(eventlog.LogResults, parser.Overrider[dex.ParsedTx], ...interface{}) ([]*dex.ParsedTx, error) {
 		return []*dex.ParsedTx{{
@@ -710,3 +714,132 @@ const withdrawLogStr = `[
         ]
     }
 ]`
+
+func Test_UpdateParsers_RebuildsOnlyWhenChanged(t *testing.T) {
+	app := dezswapApp{
+		Parsers:     &dex.PairParsers{},
+		chainId:     ds.MainnetPrefix,
+		pairs:       map[string]dex.Pair{pairAddr: {ContractAddr: pairAddr, LpAddr: lpAddr, Assets: []string{asset1, asset2}}},
+		lpPairAddrs: map[string]string{lpAddr: pairAddr},
+	}
+	tokenExceptions := map[string]bool{}
+
+	require.NoError(t, app.UpdateParsers(tokenExceptions, 100))
+	built := app.Parsers.PairActionParser
+
+	require.NoError(t, app.UpdateParsers(tokenExceptions, 101))
+	assert.Same(t, built, app.Parsers.PairActionParser)
+
+	app.pairs["new_pair"] = dex.Pair{ContractAddr: "new_pair", Assets: []string{asset1, asset2}}
+	require.NoError(t, app.UpdateParsers(tokenExceptions, 102))
+	assert.NotSame(t, built, app.Parsers.PairActionParser)
+	built = app.Parsers.PairActionParser
+
+	require.NoError(t, app.UpdateParsers(tokenExceptions, ds.MainnetV2Height))
+	assert.NotSame(t, built, app.Parsers.PairActionParser)
+	built = app.Parsers.PairActionParser
+
+	require.NoError(t, app.UpdateParsers(map[string]bool{}, ds.MainnetV2Height+1))
+	assert.NotSame(t, built, app.Parsers.PairActionParser)
+}
+
+func BenchmarkUpdateParsers(b *testing.B) {
+	pairs := make(map[string]dex.Pair, 1000)
+	for idx := 0; idx < 1000; idx++ {
+		addr := fmt.Sprintf("pair%d", idx)
+		pairs[addr] = dex.Pair{ContractAddr: addr, LpAddr: "lp" + addr, Assets: []string{asset1, asset2}}
+	}
+	app := dezswapApp{
+		Parsers: &dex.PairParsers{},
+		chainId: ds.MainnetPrefix,
+		pairs:   pairs,
+	}
+	tokenExceptions := map[string]bool{}
+
+	b.ResetTimer()
+	for height := uint64(0); height < uint64(b.N); height++ {
+		if err := app.UpdateParsers(tokenExceptions, ds.MainnetV2Height+height); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func Test_ParseTxs_AttributesRouterHopsByMessage(t *testing.T) {
+	const (
+		height      = uint64(100)
+		factoryAddr = "xpla1j4kgjl6h4rt96uddtzdxdu39h0mhn4vrtydufdrk4uxxnrpsnw2qug2yx3"
+		routerAddr  = "xpla1j4kgjl6h4rt96uddtzdxdu39h0mhn4vrtydufdrk4uxxnrpsnw2qug2yx2"
+		pair2Addr   = "xpla1sdzaas0068n42xk8ndm6959gpu6n09tajmeuq7vak8t8qt5jrp6szltsnk"
+		lp2Addr     = "xpla1kfmdk8kwvjmdmrwcgfrrnh7f3ecqn6lqgacu6c4aqf62mpaxcnqqxq9ysk"
+		asset3      = "xpla1hz3svgdhmv67lsqlduu0tcnd3f75c0xr0mu48l6ywuwlz43zssjqc0z2h4"
+		user        = "xpla190465x8qz4p7uxylrmwcn8rufkv30j655h6h7q"
+	)
+	repo := dex.RepoMock{}
+	repo.On("GetPairs").Return(map[string]dex.Pair{
+		pairAddr:  testPair,
+		pair2Addr: {ContractAddr: pair2Addr, LpAddr: lp2Addr, Assets: []string{asset1, asset3}},
+	}, nil)
+	app, err := New(&repo, logging.Discard, configs.ParserDexConfig{FactoryAddress: factoryAddr}, chainId)
+	require.NoError(t, err)
+	require.NoError(t, app.UpdateParsers(map[string]bool{}, height))
+
+	wasmEvent := func(msgIndex string, kvs ...string) abcitypes.Event {
+		event := abcitypes.Event{Type: string(eventlog.WasmType)}
+		for idx := 0; idx < len(kvs); idx += 2 {
+			event.Attributes = append(event.Attributes, abcitypes.EventAttribute{Key: kvs[idx], Value: kvs[idx+1]})
+		}
+		event.Attributes = append(event.Attributes, abcitypes.EventAttribute{Key: "msg_index", Value: msgIndex})
+		return event
+	}
+	swapEvent := func(msgIndex, pair, offerAsset, askAsset, offerAmount, returnAmount, sender, receiver string) abcitypes.Event {
+		return wasmEvent(msgIndex,
+			"_contract_address", pair, "action", "swap",
+			"ask_asset", askAsset, "commission_amount", "3",
+			"offer_amount", offerAmount, "offer_asset", offerAsset,
+			"receiver", receiver, "return_amount", returnAmount,
+			"sender", sender, "spread_amount", "1",
+		)
+	}
+	cw20Event := func(msgIndex, token, action, from, to, amount string) abcitypes.Event {
+		return wasmEvent(msgIndex, "_contract_address", token, "action", action, "amount", amount, "from", from, "to", to)
+	}
+	// msg 0 swaps on the pair directly, msg 1 routes asset2 -> asset1 -> asset3
+	rawTx := srcstore.NewMapper().TxToParserRawTx(datastore.TxDTO{
+		TxHash: txHash,
+		Events: []abcitypes.Event{
+			cw20Event("0", asset2, "send", user, pairAddr, "100"),
+			swapEvent("0", pairAddr, asset2, asset1, "100", "90", user, user),
+			cw20Event("0", asset1, "transfer", pairAddr, user, "90"),
+			cw20Event("1", asset2, "send", user, routerAddr, "200"),
+			wasmEvent("1", "_contract_address", routerAddr, "action", "execute_swap_operations"),
+			cw20Event("1", asset2, "send", routerAddr, pairAddr, "200"),
+			swapEvent("1", pairAddr, asset2, asset1, "200", "180", routerAddr, routerAddr),
+			cw20Event("1", asset1, "transfer", pairAddr, routerAddr, "180"),
+			cw20Event("1", asset1, "send", routerAddr, pair2Addr, "180"),
+			swapEvent("1", pair2Addr, asset1, asset3, "180", "170", routerAddr, user),
+			cw20Event("1", asset3, "transfer", pair2Addr, user, "170"),
+		},
+	})
+
+	unrouted, err := app.ParseTxs(rawTx, height)
+	require.NoError(t, err)
+	for _, other := range []string{"", "other"} {
+		txs := append([]dex.ParsedTx{}, unrouted...)
+		assert.Equal(t, unrouted, dex.AttributeRoutes(other, rawTx, txs))
+	}
+
+	txs := dex.AttributeRoutes(routerAddr, rawTx, append([]dex.ParsedTx{}, unrouted...))
+
+	routes := map[string]map[string]interface{}{}
+	for _, tx := range txs {
+		if tx.Type != dex.Swap {
+			continue
+		}
+		routes[tx.ContractAddr+"-"+tx.Assets[0].Amount+"/"+tx.Assets[1].Amount] = tx.Meta
+	}
+	assert.Equal(t, map[string]map[string]interface{}{
+		pairAddr + "--90/100":   nil,
+		pairAddr + "--180/200":  {dex.RouteIdMetaKey: txHash + "-1", dex.RouteHopMetaKey: 0},
+		pair2Addr + "-180/-170": {dex.RouteIdMetaKey: txHash + "-1", dex.RouteHopMetaKey: 1},
+	}, routes)
+}
@@ -34,6 +34,7 @@ type ParsedTx struct {
 	Sender           string   `json:"sender"`
 	ContractAddr     string   `json:"contractAddr"`
 	Assets           [2]Asset `json:"assets"`
+	ExtraAssets      []Asset  `json:"extraAssets,omitempty" faker:"-"` // assets after the second of pools with more than two, nil otherwise
 	LpAddr           string   `json:"lpAddr"`
 	LpAmount         string   `json:"lpAmount" faker:"amountString"`
 	CommissionAmount string   `json:"commissionAmount" faker:"amountString"`
@@ -70,6 +71,9 @@ func (defaultVal ParsedTx) Override(tx ParsedTx) (ParsedTx, error) {
 	if tx.Assets[1].Amount != "" {
 		defaultVal.Assets[1].Amount = tx.Assets[1].Amount
 	}
+	if tx.ExtraAssets != nil {
+		defaultVal.ExtraAssets = append([]Asset{}, tx.ExtraAssets...)
+	}
 	if tx.LpAddr != "" {
 		defaultVal.LpAddr = tx.LpAddr
 	}
@@ -94,6 +98,34 @@ func (defaultVal ParsedTx) Override(tx ParsedTx) (ParsedTx, error) {
 	return defaultVal, nil
 }
 
+// AllAssets returns Assets followed by ExtraAssets.
+func (tx ParsedTx) AllAssets() []Asset {
+	return append(tx.Assets[:], tx.ExtraAssets...)
+}
+
+// SetAssets stores the first two assets in Assets and the rest in
+// ExtraAssets. Missing assets of a pool with less than two are left empty.
+func (tx *ParsedTx) SetAssets(assets []Asset) {
+	tx.Assets = [2]Asset{}
+	tx.ExtraAssets = nil
+	for idx, asset := range assets {
+		if idx < len(tx.Assets) {
+			tx.Assets[idx] = asset
+			continue
+		}
+		tx.ExtraAssets = append(tx.ExtraAssets, asset)
+	}
+}
+
+// AssetAddrs returns the addresses of AllAssets.
+func (tx ParsedTx) AssetAddrs() []string {
+	addrs := []string{}
+	for _, asset := range tx.AllAssets() {
+		addrs = append(addrs, asset.Addr)
+	}
+	return addrs
+}
+
 type PoolInfo struct {
 	ContractAddr string  `json:"contractAddr"`
 	Assets       []Asset `json:"assets"`
@@ -101,8 +133,40 @@ type PoolInfo struct {
 	TotalShare   string  `json:"totalShare" faker:"amountString"`
 }
 
+// BlockHeader identifies a block and links it to its parent so collected
+// heights can be checked for forks.
+type BlockHeader struct {
+	Height     uint64 `json:"height"`
+	Hash       string `json:"hash"`
+	ParentHash string `json:"parentHash"`
+}
+
 type Pair struct {
 	ContractAddr string   `json:"contractAddr"`
 	Assets       []string `json:"assets"`
 	LpAddr       string   `json:"lpAddr"`
 }
+
+const (
+	PoolValidationPassed  = "passed"
+	PoolValidationFailed  = "failed"
+	PoolValidationSkipped = "skipped"
+)
+
+// PoolValidationResult is the outcome of comparing the parsed pools with the
+// source pools at a validation height. Skipped results had no source pools to
+// compare with.
+type PoolValidationResult struct {
+	Height     uint64                   `json:"height"`
+	Status     string                   `json:"status"`
+	Mismatches []PoolValidationMismatch `json:"mismatches"`
+}
+
+// ParsedBlock is what the parser writes for one height.
+type ParsedBlock struct {
+	Height      uint64
+	Txs         []ParsedTx
+	Pools       []PoolInfo
+	Pairs       []Pair
+	Quarantines []ParseQuarantine
+}
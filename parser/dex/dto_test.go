@@ -0,0 +1,45 @@
+package dex
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ParsedTx_SetAssets(t *testing.T) {
+	tcs := []struct {
+		assets        []Asset
+		expected      [2]Asset
+		expectedExtra []Asset
+	}{
+		{[]Asset{{"a", "1"}}, [2]Asset{{"a", "1"}, {}}, nil},
+		{[]Asset{{"a", "1"}, {"b", "2"}}, [2]Asset{{"a", "1"}, {"b", "2"}}, nil},
+		{[]Asset{{"a", "1"}, {"b", "2"}, {"c", "-3"}}, [2]Asset{{"a", "1"}, {"b", "2"}}, []Asset{{"c", "-3"}}},
+	}
+	assert := assert.New(t)
+
+	for _, tc := range tcs {
+		tx := ParsedTx{ExtraAssets: []Asset{{"stale", "1"}}}
+		tx.SetAssets(tc.assets)
+
+		assert.Equal(tc.expected, tx.Assets)
+		assert.Equal(tc.expectedExtra, tx.ExtraAssets)
+		if len(tc.assets) >= 2 {
+			assert.Equal(tc.assets, tx.AllAssets())
+		}
+	}
+}
+
+func Test_ParsedTx_Override_ExtraAssets(t *testing.T) {
+	assert := assert.New(t)
+	defaultTx := ParsedTx{Type: Swap, ExtraAssets: []Asset{{"c", "1"}}}
+
+	tx, err := defaultTx.Override(ParsedTx{Hash: "hash"})
+	assert.NoError(err)
+	assert.Equal([]Asset{{"c", "1"}}, tx.ExtraAssets)
+
+	tx, err = defaultTx.Override(ParsedTx{ExtraAssets: []Asset{{"c", "-2"}}})
+	assert.NoError(err)
+	assert.Equal([]Asset{{"c", "-2"}}, tx.ExtraAssets)
+	assert.Equal([]string{"", "", "c"}, tx.AssetAddrs())
+}
@@ -0,0 +1,98 @@
+package generic
+
+import (
+	"github.com/dezswap/cosmwasm-etl/configs"
+	"github.com/dezswap/cosmwasm-etl/parser"
+	"github.com/dezswap/cosmwasm-etl/parser/dex"
+	"github.com/dezswap/cosmwasm-etl/pkg/dex/generic"
+	"github.com/dezswap/cosmwasm-etl/pkg/eventlog"
+	"github.com/dezswap/cosmwasm-etl/pkg/logging"
+	"github.com/pkg/errors"
+)
+
+// runner for a terraswap compatible DEX described by a spec
+type genericApp struct {
+	dex.PairRepo
+	Parsers *dex.PairParsers
+	dex.DexMixin
+	spec generic.Spec
+
+	// state
+	pairs         map[string]dex.Pair
+	lpPairAddrs   map[string]string
+	flaggedAssets map[string]bool
+
+	parsersVersion dex.ParsersVersion
+}
+
+var _ dex.TargetApp = &genericApp{}
+
+// New loads the spec file of c and builds its target app.
+func New(repo dex.PairRepo, logger logging.Logger, c configs.ParserDexConfig) (dex.TargetApp, error) {
+	spec, err := generic.LoadSpec(c.SpecFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "generic.New")
+	}
+	return NewWithSpec(repo, logger, c, spec)
+}
+
+// NewWithSpec builds the target app of an already parsed spec.
+func NewWithSpec(repo dex.PairRepo, logger logging.Logger, c configs.ParserDexConfig, spec generic.Spec) (dex.TargetApp, error) {
+	finder, err := generic.CreateCreatePairRuleFinder(spec, c.FactoryAddress)
+	if err != nil {
+		return nil, errors.Wrap(err, "NewApp")
+	}
+
+	parsers := dex.PairParsers{
+		CreatePairParser: parser.NewParser[dex.ParsedTx](finder, &createPairMapper{spec: spec.CreatePair}),
+		PairActionParser: nil,
+		InitialProvide:   nil,
+		WasmTransfer:     nil,
+		Transfer:         nil,
+	}
+
+	pairs, err := repo.GetPairs()
+	if err != nil {
+		return nil, errors.Wrap(err, "generic.New")
+	}
+
+	lpPairAddrs := make(map[string]string)
+	for _, p := range pairs {
+		lpPairAddrs[p.LpAddr] = p.ContractAddr
+	}
+
+	logger.Infof("parsing %s with a terraswap compatible spec", spec.Name)
+	return &genericApp{repo, &parsers, dex.DexMixin{}, spec, pairs, lpPairAddrs, make(map[string]bool), dex.ParsersVersion{}}, nil
+}
+
+func (p *genericApp) ParseTxs(tx parser.RawTx, height uint64) ([]dex.ParsedTx, error) {
+	return p.ParsePairTxs(p.spec.Name, p.Parsers, p.pairs, p.lpPairAddrs, tx, height)
+}
+
+func (p *genericApp) IsValidationExceptionCandidate(contractAddress string) bool {
+	return p.flaggedAssets[contractAddress]
+}
+
+func (p *genericApp) UpdateParsers(tokenExceptions map[string]bool, height uint64) error {
+	if !p.parsersVersion.Stale(len(p.pairs), 0, tokenExceptions) {
+		return nil
+	}
+
+	if err := p.UpdatePairParsers(p.Parsers, dex.PairParserSpec{
+		PairFinder: func(pairFilter map[string]bool) (eventlog.LogFinder, error) {
+			return generic.CreatePairCommonRulesFinder(p.spec, pairFilter)
+		},
+		PairMapper: &pairMapper{spec: p.spec, pairSet: p.pairs},
+		WasmTransferFinder: func() (eventlog.LogFinder, error) {
+			return generic.CreateWasmCommonTransferRuleFinder(p.spec)
+		},
+		Cw20AddrKey:        p.spec.Mapper.Cw20AddrKey,
+		SkipInitialProvide: p.spec.Mapper.SkipInitialProvide,
+		SkipLpBurn:         p.spec.Mapper.SkipLpBurn,
+	}, p.pairs, p.flaggedAssets, tokenExceptions); err != nil {
+		return err
+	}
+
+	p.parsersVersion.Record(len(p.pairs), 0, tokenExceptions)
+	return nil
+}
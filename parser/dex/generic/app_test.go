@@ -0,0 +1,122 @@
+package generic
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/dezswap/cosmwasm-etl/configs"
+	"github.com/dezswap/cosmwasm-etl/parser"
+	"github.com/dezswap/cosmwasm-etl/parser/dex"
+	"github.com/dezswap/cosmwasm-etl/pkg/dex/generic"
+	"github.com/dezswap/cosmwasm-etl/pkg/eventlog"
+	"github.com/dezswap/cosmwasm-etl/pkg/logging"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	factoryAddr = "terra1factory"
+	pairAddr    = "terra1pair"
+	lpAddr      = "terra1lp"
+	tokenAddr   = "terra1token"
+	userAddr    = "terra1user"
+)
+
+// a fork that reorders create_pair, renames the swap keys and refunds provides
+const forkSpec = `
+name: forkswap
+createPair:
+  attributes: [lp_token, pair_addr, asset_infos]
+  assetsKey: asset_infos
+  assetsSeparator: ","
+  pairAddrKey: pair_addr
+  lpAddrKey: lp_token
+swap:
+  action: trade
+  senderKey: trader
+  offerAssetKey: in_asset
+  offerAmountKey: in_amount
+  returnAmountKey: out_amount
+  commissionAmountKey: fee_amount
+  metaKeys: [burn_fee_amount]
+provide:
+  refundAssetsKey: refund_assets
+mapper:
+  skipInitialProvide: true
+  skipLpBurn: true
+`
+
+func newTestApp(t *testing.T, pairs map[string]dex.Pair) dex.TargetApp {
+	spec, err := generic.ParseSpec([]byte(forkSpec))
+	require.NoError(t, err)
+	repo := dex.RepoMock{}
+	repo.On("GetPairs").Return(pairs, nil)
+	app, err := NewWithSpec(&repo, logging.Discard, configs.ParserDexConfig{FactoryAddress: factoryAddr}, spec)
+	require.NoError(t, err)
+	require.NoError(t, app.UpdateParsers(map[string]bool{}, 100))
+	return app
+}
+
+func rawTx(t *testing.T, logs string) parser.RawTx {
+	var logResults eventlog.LogResults
+	require.NoError(t, json.Unmarshal([]byte(logs), &logResults))
+	return parser.RawTx{Hash: "hash", Sender: userAddr, LogResults: logResults}
+}
+
+func Test_ParseTxs_CreatePairThenSwap(t *testing.T) {
+	app := newTestApp(t, map[string]dex.Pair{})
+
+	txs, err := app.ParseTxs(rawTx(t, `[{"type":"wasm","attributes":[
+		{"key":"_contract_address","value":"`+factoryAddr+`"},{"key":"action","value":"create_pair"},
+		{"key":"lp_token","value":"`+lpAddr+`"},{"key":"pair_addr","value":"`+pairAddr+`"},{"key":"asset_infos","value":"uluna,`+tokenAddr+`"}]}]`), 100)
+
+	require.NoError(t, err)
+	require.Len(t, txs, 1)
+	require.Equal(t, dex.ParsedTx{
+		Hash:         "hash",
+		Type:         dex.CreatePair,
+		Sender:       userAddr,
+		ContractAddr: pairAddr,
+		Assets:       [2]dex.Asset{{Addr: "uluna"}, {Addr: tokenAddr}},
+		LpAddr:       lpAddr,
+	}, txs[0])
+
+	require.NoError(t, app.UpdateParsers(map[string]bool{}, 101))
+	txs, err = app.ParseTxs(rawTx(t, `[{"type":"wasm","attributes":[
+		{"key":"_contract_address","value":"`+pairAddr+`"},{"key":"action","value":"trade"},{"key":"out_amount","value":"990"},{"key":"trader","value":"`+userAddr+`"},
+		{"key":"in_asset","value":"uluna"},{"key":"in_amount","value":"1000"},{"key":"fee_amount","value":"3"},{"key":"burn_fee_amount","value":"1"},
+		{"key":"_contract_address","value":"`+tokenAddr+`"},{"key":"action","value":"transfer"},{"key":"from","value":"`+pairAddr+`"},{"key":"to","value":"`+userAddr+`"},{"key":"amount","value":"990"}]}]`), 101)
+
+	require.NoError(t, err)
+	require.Len(t, txs, 1)
+	require.Equal(t, dex.ParsedTx{
+		Hash:             "hash",
+		Type:             dex.Swap,
+		Sender:           userAddr,
+		ContractAddr:     pairAddr,
+		Assets:           [2]dex.Asset{{Addr: "uluna", Amount: "1000"}, {Addr: tokenAddr, Amount: "-990"}},
+		CommissionAmount: "3",
+		Meta:             map[string]interface{}{"burn_fee_amount": "1"},
+	}, txs[0])
+}
+
+func Test_ParseTxs_ProvideWithRefund(t *testing.T) {
+	app := newTestApp(t, map[string]dex.Pair{
+		pairAddr: {ContractAddr: pairAddr, LpAddr: lpAddr, Assets: []string{"uluna", tokenAddr}},
+	})
+
+	txs, err := app.ParseTxs(rawTx(t, `[{"type":"wasm","attributes":[
+		{"key":"_contract_address","value":"`+pairAddr+`"},{"key":"action","value":"provide_liquidity"},{"key":"sender","value":"`+userAddr+`"},
+		{"key":"assets","value":"100`+tokenAddr+`, 50uluna"},{"key":"share","value":"70"},{"key":"refund_assets","value":"0`+tokenAddr+`, 5uluna"}]}]`), 100)
+
+	require.NoError(t, err)
+	require.Len(t, txs, 1)
+	require.Equal(t, dex.Provide, txs[0].Type)
+	require.Equal(t, [2]dex.Asset{{Addr: "uluna", Amount: "50"}, {Addr: tokenAddr, Amount: "100"}}, txs[0].Assets)
+	require.Equal(t, "70", txs[0].LpAmount)
+	require.Contains(t, txs[0].Meta, "refund_assets")
+}
+
+func Test_New_RequiresSpecFile(t *testing.T) {
+	_, err := New(&dex.RepoMock{}, logging.Discard, configs.ParserDexConfig{FactoryAddress: factoryAddr, SpecFile: "missing.yaml"})
+	require.Error(t, err)
+}
@@ -0,0 +1,192 @@
+package generic
+
+import (
+	"fmt"
+
+	"github.com/dezswap/cosmwasm-etl/parser"
+	"github.com/dezswap/cosmwasm-etl/parser/dex"
+	pdex "github.com/dezswap/cosmwasm-etl/pkg/dex"
+	"github.com/dezswap/cosmwasm-etl/pkg/dex/generic"
+	"github.com/dezswap/cosmwasm-etl/pkg/eventlog"
+	"github.com/pkg/errors"
+)
+
+var _ parser.Mapper[dex.ParsedTx] = &createPairMapper{}
+var _ parser.Mapper[dex.ParsedTx] = &pairMapper{}
+
+type createPairMapper struct {
+	pdex.MapperMixin
+	spec generic.CreatePairSpec
+}
+
+// pairMapper reads pair attributes by the keys of the spec, so their order
+// does not matter.
+type pairMapper struct {
+	spec    generic.Spec
+	pairSet map[string]dex.Pair
+}
+
+// match implements mapper
+func (m *createPairMapper) MatchedToParsedTx(res eventlog.MatchedResult, optionals ...interface{}) ([]*dex.ParsedTx, error) {
+	if err := m.CheckResult(res, generic.FactoryAttributesIdx+len(m.spec.Attributes)); err != nil {
+		return nil, errors.Wrap(err, "createPairMapper.MatchedToParsedTx")
+	}
+	attrs := map[string]eventlog.MatchedItem{}
+	for _, item := range res[generic.FactoryAttributesIdx:] {
+		if _, ok := attrs[item.Key]; !ok {
+			attrs[item.Key] = item
+		}
+	}
+
+	assets, err := dex.SplitPairAssets(attrs[m.spec.AssetsKey].Value, m.spec.AssetsSeparator)
+	if err != nil {
+		return nil, errors.Wrap(err, "createPairMapper.MatchedToParsedTx")
+	}
+
+	tx := &dex.ParsedTx{
+		Type:         dex.CreatePair,
+		Sender:       "",
+		ContractAddr: attrs[m.spec.PairAddrKey].Value,
+		LpAddr:       attrs[m.spec.LpAddrKey].Value,
+		LpAmount:     "",
+		MsgIndex:     eventlog.MsgIndex(res),
+	}
+	tx.SetAssets(dex.EmptyAssets(assets))
+	return []*dex.ParsedTx{tx}, nil
+}
+
+// match implements mapper
+func (m *pairMapper) MatchedToParsedTx(res eventlog.MatchedResult, optionals ...interface{}) ([]*dex.ParsedTx, error) {
+	pair, ok := m.pairSet[res[generic.PairAddrIdx].Value]
+	if !ok {
+		msg := fmt.Sprintf("pairMapper.MatchedToParsedTx no pair(%s)", res[generic.PairAddrIdx].Value)
+		return nil, errors.New(msg)
+	}
+
+	switch res[generic.PairActionIdx].Value {
+	case m.spec.Swap.Action:
+		return m.swapMatchedToParsedTx(res, pair)
+	case m.spec.Provide.Action:
+		return m.provideMatchedToParsedTx(res, pair)
+	case m.spec.Withdraw.Action:
+		return m.withdrawMatchedToParsedTx(res, pair)
+	}
+
+	msg := fmt.Sprintf("action must be (%s, %s, %s)", m.spec.Swap.Action, m.spec.Provide.Action, m.spec.Withdraw.Action)
+	return nil, errors.New(msg)
+}
+
+func (m *pairMapper) swapMatchedToParsedTx(res eventlog.MatchedResult, pair dex.Pair) ([]*dex.ParsedTx, error) {
+	spec := m.spec.Swap
+	keys := append([]string{
+		spec.OfferAssetKey,
+		spec.OfferAmountKey,
+		spec.AskAssetKey,
+		spec.ReturnAmountKey,
+		spec.SenderKey,
+		spec.CommissionAmountKey,
+	}, spec.MetaKeys...)
+	matchMap, err := eventlog.ResultToItemMapForKeys(res[generic.PairActionIdx+1:], keys...)
+	if err != nil {
+		return nil, errors.Wrap(err, "pairMapper.swapMatchedToParsedTx")
+	}
+
+	assets, err := dex.SwapAssets(
+		pair,
+		matchMap[spec.OfferAssetKey].Value,
+		matchMap[spec.OfferAmountKey].Value,
+		matchMap[spec.AskAssetKey].Value,
+		matchMap[spec.ReturnAmountKey].Value,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "pairMapper.swapMatchedToParsedTx")
+	}
+
+	var meta map[string]interface{}
+	for _, key := range spec.MetaKeys {
+		if item, ok := matchMap[key]; ok {
+			if meta == nil {
+				meta = map[string]interface{}{}
+			}
+			meta[key] = item.Value
+		}
+	}
+
+	tx := &dex.ParsedTx{
+		Type:             dex.Swap,
+		ContractAddr:     res[generic.PairAddrIdx].Value,
+		Sender:           matchMap[spec.SenderKey].Value,
+		CommissionAmount: matchMap[spec.CommissionAmountKey].Value,
+		Meta:             meta,
+		MsgIndex:         eventlog.MsgIndex(res),
+	}
+	tx.SetAssets(assets)
+	return []*dex.ParsedTx{tx}, nil
+}
+
+// provideMatchedToParsedTx keeps refunded assets in the meta without
+// deducting them, since the refund is also reported as a transfer.
+func (m *pairMapper) provideMatchedToParsedTx(res eventlog.MatchedResult, pair dex.Pair) ([]*dex.ParsedTx, error) {
+	spec := m.spec.Provide
+	keys := []string{spec.AssetsKey, spec.SenderKey, spec.ShareKey}
+	if spec.RefundAssetsKey != "" {
+		keys = append(keys, spec.RefundAssetsKey)
+	}
+	matchMap, err := eventlog.ResultToItemMapForKeys(res[generic.PairActionIdx+1:], keys...)
+	if err != nil {
+		return nil, errors.Wrap(err, "pairMapper.provideMatchedToParsedTx")
+	}
+
+	assets, err := dex.GetAssetsFromAssetsString(matchMap[spec.AssetsKey].Value)
+	if err != nil {
+		return nil, errors.Wrap(err, "pairMapper.provideMatchedToParsedTx")
+	}
+
+	var meta map[string]interface{}
+	if refundItem, ok := matchMap[spec.RefundAssetsKey]; ok {
+		refundAssets, err := dex.GetAssetsFromAssetsString(refundItem.Value)
+		if err != nil {
+			return nil, errors.Wrap(err, "pairMapper.provideMatchedToParsedTx")
+		}
+		meta = map[string]interface{}{spec.RefundAssetsKey: refundAssets}
+	}
+
+	tx := &dex.ParsedTx{
+		Type:         dex.Provide,
+		ContractAddr: res[generic.PairAddrIdx].Value,
+		Sender:       matchMap[spec.SenderKey].Value,
+		LpAddr:       pair.LpAddr,
+		LpAmount:     matchMap[spec.ShareKey].Value,
+		Meta:         meta,
+		MsgIndex:     eventlog.MsgIndex(res),
+	}
+	tx.SetAssets(dex.SortAssetsByPair(pair, assets))
+	return []*dex.ParsedTx{tx}, nil
+}
+
+func (m *pairMapper) withdrawMatchedToParsedTx(res eventlog.MatchedResult, pair dex.Pair) ([]*dex.ParsedTx, error) {
+	spec := m.spec.Withdraw
+	matchMap, err := eventlog.ResultToItemMapForKeys(res[generic.PairActionIdx+1:], spec.RefundAssetsKey, spec.SenderKey, spec.ShareKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "pairMapper.withdrawMatchedToParsedTx")
+	}
+
+	assets, err := dex.GetAssetsFromAssetsString(matchMap[spec.RefundAssetsKey].Value)
+	if err != nil {
+		return nil, errors.Wrap(err, "pairMapper.withdrawMatchedToParsedTx")
+	}
+	for idx := range assets {
+		assets[idx].Amount = fmt.Sprintf("-%s", assets[idx].Amount)
+	}
+
+	tx := &dex.ParsedTx{
+		Type:         dex.Withdraw,
+		ContractAddr: res[generic.PairAddrIdx].Value,
+		Sender:       matchMap[spec.SenderKey].Value,
+		LpAddr:       pair.LpAddr,
+		LpAmount:     matchMap[spec.ShareKey].Value,
+		MsgIndex:     eventlog.MsgIndex(res),
+	}
+	tx.SetAssets(dex.SortAssetsByPair(pair, assets))
+	return []*dex.ParsedTx{tx}, nil
+}
@@ -1,6 +1,9 @@
 package dex
 
 import (
+	"context"
+	"time"
+
 	"github.com/dezswap/cosmwasm-etl/parser"
 )
 
@@ -27,12 +30,18 @@ type TargetApp interface {
 type Repo interface {
 	parser.Repo[ParsedTx]
 	PairRepo
+	// InsertBatch writes the blocks of consecutive heights after srcHeight in
+	// one transaction and advances the synced height to the last of them.
+	InsertBatch(srcHeight uint64, blocks []ParsedBlock) error
 	ParsedPoolsInfo(from, to uint64) ([]PoolInfo, error)
 	ValidationExceptionList() ([]string, error)
 	InsertPairValidationException(chainID string, contractAddress string) error
 	GetValidationHeight() (uint64, error)
 	SetValidationHeight(height uint64) error
 	ClearValidationHeight() error
+	// SavePoolValidationResult records the latest result of a validation
+	// height, replacing an earlier one of the same height.
+	SavePoolValidationResult(result PoolValidationResult) error
 	PendingParseQuarantines() ([]ParseQuarantine, error)
 	ResolveParseQuarantine(id uint64, height uint64, txs []ParsedTx) error
 }
@@ -42,6 +51,52 @@ type SourceDataStore interface {
 	GetPoolInfos(height uint64) ([]PoolInfo, error)
 }
 
+// BlockHeaderSource is implemented by source stores that can report block
+// hashes. Stores without it are collected without fork detection.
+type BlockHeaderSource interface {
+	GetBlockHeader(height uint64) (BlockHeader, error)
+}
+
+// BlockSource is implemented by source stores that read the header and the
+// txs of a block from the same node response.
+type BlockSource interface {
+	BlockHeaderSource
+	GetBlock(height uint64) (BlockHeader, parser.RawTxs, error)
+}
+
+// StreamSource is implemented by source stores that can send the txs of a
+// height range in one request. StreamSourceTxs calls handle for each height
+// of [from, to] in order and may return before to when the source lacks a
+// height.
+type StreamSource interface {
+	StreamSourceTxs(ctx context.Context, from, to uint64, handle func(height uint64, txs parser.RawTxs) error) error
+}
+
+// PairSource is implemented by source stores that can list the pairs created
+// by the factory as of a height.
+type PairSource interface {
+	GetPairs(height uint64) ([]Pair, error)
+}
+
+// PoolSource is implemented by source stores that can query the pool of a
+// single pair at a height.
+type PoolSource interface {
+	GetPoolInfo(pair Pair, height uint64) (PoolInfo, error)
+}
+
+// BatchPoolSource is implemented by source stores that can query the pools
+// of several pairs at a height in a single request.
+type BatchPoolSource interface {
+	GetPoolInfosOf(pairs []Pair, height uint64) ([]PoolInfo, error)
+}
+
+// HeightNotifier is implemented by source stores that learn about new blocks
+// as they are produced. WaitForHeight blocks until a height above after is
+// known or timeout elapses, and reports whether a new height arrived.
+type HeightNotifier interface {
+	WaitForHeight(after uint64, timeout time.Duration) bool
+}
+
 type PairRepo interface {
 	GetPairs() (map[string]Pair, error)
 }
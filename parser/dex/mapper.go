@@ -38,24 +38,21 @@ func (m *factoryMapper) MatchedToParsedTx(res eventlog.MatchedResult, optional .
 		return nil, errors.Wrap(err, "factoryMapper.MatchedToParsedTx")
 	}
 
-	assets := strings.Split(res[pdex.FactoryPairIdx].Value, "-")
-	if len(assets) != 2 {
-		msg := fmt.Sprintf("expected assets length(%d)", 2)
-		return nil, errors.New(msg)
+	assets, err := SplitPairAssets(res[pdex.FactoryPairIdx].Value, "-")
+	if err != nil {
+		return nil, errors.Wrap(err, "factoryMapper.MatchedToParsedTx")
 	}
 
-	return []*ParsedTx{{
+	tx := &ParsedTx{
 		Type:         CreatePair,
 		Sender:       "",
 		ContractAddr: res[pdex.FactoryPairAddrIdx].Value,
-		Assets: [2]Asset{
-			{Addr: assets[0]},
-			{Addr: assets[1]},
-		},
-		LpAddr:   res[pdex.FactoryLpAddrIdx].Value,
-		LpAmount: "",
-		MsgIndex: eventlog.MsgIndex(res),
-	}}, nil
+		LpAddr:       res[pdex.FactoryLpAddrIdx].Value,
+		LpAmount:     "",
+		MsgIndex:     eventlog.MsgIndex(res),
+	}
+	tx.SetAssets(EmptyAssets(assets))
+	return []*ParsedTx{tx}, nil
 }
 
 func NewWasmTransferMapper(cw20AddrKey string, pairSet map[string]Pair, flaggedPairs map[string]bool, tokenExceptions map[string]bool) parser.Mapper[ParsedTx] {
@@ -121,10 +118,7 @@ func firstValueInResult(res eventlog.MatchedResult, key string) string {
 }
 
 func (m *wasmCommonTransferMapper) wasmTransferToParsedTx(pair Pair, cw20Addr, from, amount string, fromPair bool) *ParsedTx {
-	assets := [2]Asset{
-		{Addr: pair.Assets[0]},
-		{Addr: pair.Assets[1]},
-	}
+	assets := pairAssets(pair)
 	meta := make(map[string]interface{})
 
 	idx := IndexOf(pair.Assets, cw20Addr)
@@ -139,13 +133,23 @@ func (m *wasmCommonTransferMapper) wasmTransferToParsedTx(pair Pair, cw20Addr, f
 		assets[idx].Amount = fmt.Sprintf("-%s", assets[idx].Amount)
 	}
 
-	return &ParsedTx{
+	tx := &ParsedTx{
 		Type:         Transfer,
 		Sender:       from,
 		ContractAddr: pair.ContractAddr,
-		Assets:       assets,
 		Meta:         meta,
 	}
+	tx.SetAssets(assets)
+	return tx
+}
+
+// pairAssets returns the empty assets of every pair asset in pair order.
+func pairAssets(pair Pair) []Asset {
+	assets := make([]Asset, 0, len(pair.Assets))
+	for _, addr := range pair.Assets {
+		assets = append(assets, Asset{Addr: addr})
+	}
+	return assets
 }
 
 func NewTransferMapper(pairSet map[string]Pair) parser.Mapper[ParsedTx] {
@@ -213,10 +217,7 @@ func TransferFallbackSender(optionals ...interface{}) string {
 }
 
 func (m transferMapper) transferToParsedTx(pair Pair, from, assetsStr string, fromPair bool, msgIndex int) (*ParsedTx, error) {
-	assets := [2]Asset{
-		{Addr: pair.Assets[0]},
-		{Addr: pair.Assets[1]},
-	}
+	assets := pairAssets(pair)
 	meta := make(map[string]interface{})
 
 	amountStrs := strings.Split(assetsStr, ",")
@@ -236,16 +237,17 @@ func (m transferMapper) transferToParsedTx(pair Pair, from, assetsStr string, fr
 		}
 	}
 
-	return &ParsedTx{
+	tx := &ParsedTx{
 		Type:         Transfer,
 		Sender:       from,
 		ContractAddr: pair.ContractAddr,
-		Assets:       assets,
 		LpAddr:       "",
 		LpAmount:     "",
 		MsgIndex:     msgIndex,
 		Meta:         meta,
-	}, nil
+	}
+	tx.SetAssets(assets)
+	return tx, nil
 }
 
 func NewInitialProvideMapper() parser.Mapper[ParsedTx] {
@@ -274,6 +276,7 @@ func (m *initialProvideMapper) MatchedToParsedTx(res eventlog.MatchedResult, opt
 		LpAddr:       matchMap[pdex.PairInitialProvideAddrKey].Value,
 		LpAmount:     matchMap[pdex.PairInitialProvideAmountKey].Value,
 		Meta:         nil,
+		MsgIndex:     eventlog.MsgIndex(res),
 	}}, nil
 }
 
@@ -335,5 +338,6 @@ func (m *burnMapper) MatchedToParsedTx(res eventlog.MatchedResult, optionals ...
 		LpAddr:   matchMap[pdex.BurnAddrKey].Value,
 		Assets:   [2]Asset{{}, {}},
 		LpAmount: lpAmount,
+		MsgIndex: eventlog.MsgIndex(res),
 	}}, nil
 }
@@ -27,7 +27,7 @@ func Test_TransferMapper(t *testing.T) {
 			el.MatchedResult{
 				{Key: "recipient", Value: pair.ContractAddr}, {Key: "sender", Value: userAddr}, {Key: "amount", Value: "1000Asset1"},
 			},
-			[]*ParsedTx{{"", time.Time{}, Transfer, userAddr, pair.ContractAddr, [2]Asset{{pair.Assets[0], "1000"}, {pair.Assets[1], ""}}, "", "", "", 0, make(map[string]interface{})}},
+			[]*ParsedTx{{"", time.Time{}, Transfer, userAddr, pair.ContractAddr, [2]Asset{{pair.Assets[0], "1000"}, {pair.Assets[1], ""}}, nil, "", "", "", 0, make(map[string]interface{})}},
 			"",
 		},
 		{
@@ -35,7 +35,7 @@ func Test_TransferMapper(t *testing.T) {
 			el.MatchedResult{
 				{Key: "recipient", Value: pair.ContractAddr}, {Key: "sender", Value: userAddr}, {Key: "amount", Value: "1000Asset1,2000Asset2"},
 			},
-			[]*ParsedTx{{"", time.Time{}, Transfer, userAddr, pair.ContractAddr, [2]Asset{{pair.Assets[0], "1000"}, {pair.Assets[1], "2000"}}, "", "", "", 0, make(map[string]interface{})}},
+			[]*ParsedTx{{"", time.Time{}, Transfer, userAddr, pair.ContractAddr, [2]Asset{{pair.Assets[0], "1000"}, {pair.Assets[1], "2000"}}, nil, "", "", "", 0, make(map[string]interface{})}},
 			"",
 		},
 		{
@@ -43,7 +43,7 @@ func Test_TransferMapper(t *testing.T) {
 			el.MatchedResult{
 				{Key: "recipient", Value: pair.ContractAddr}, {Key: "sender", Value: userAddr}, {Key: "amount", Value: "123456789012345678901234567890123456Asset2"},
 			},
-			[]*ParsedTx{{"", time.Time{}, Transfer, userAddr, pair.ContractAddr, [2]Asset{{pair.Assets[0], ""}, {pair.Assets[1], "123456789012345678901234567890123456"}}, "", "", "", 0, make(map[string]interface{})}},
+			[]*ParsedTx{{"", time.Time{}, Transfer, userAddr, pair.ContractAddr, [2]Asset{{pair.Assets[0], ""}, {pair.Assets[1], "123456789012345678901234567890123456"}}, nil, "", "", "", 0, make(map[string]interface{})}},
 			"",
 		},
 		{
@@ -59,7 +59,7 @@ func Test_TransferMapper(t *testing.T) {
 			el.MatchedResult{
 				{Key: "recipient", Value: pair.ContractAddr}, {Key: "sender", Value: userAddr}, {Key: "amount", Value: "1000WrongAsset1"},
 			},
-			[]*ParsedTx{{"", time.Time{}, Transfer, userAddr, pair.ContractAddr, [2]Asset{{pair.Assets[0], ""}, {pair.Assets[1], ""}}, "", "", "", 0, map[string]interface{}{"WrongAsset1": "1000"}}},
+			[]*ParsedTx{{"", time.Time{}, Transfer, userAddr, pair.ContractAddr, [2]Asset{{pair.Assets[0], ""}, {pair.Assets[1], ""}}, nil, "", "", "", 0, map[string]interface{}{"WrongAsset1": "1000"}}},
 			"",
 		},
 		// / wasm transfer
@@ -68,7 +68,7 @@ func Test_TransferMapper(t *testing.T) {
 			el.MatchedResult{
 				{Key: "_contract_address", Value: pair.Assets[0]}, {Key: "action", Value: "transfer"}, {Key: "from", Value: userAddr}, {Key: "to", Value: pair.ContractAddr}, {Key: "amount", Value: "1000"},
 			},
-			[]*ParsedTx{{"", time.Time{}, Transfer, userAddr, pair.ContractAddr, [2]Asset{{pair.Assets[0], "1000"}, {pair.Assets[1], ""}}, "", "", "", 0, make(map[string]interface{})}},
+			[]*ParsedTx{{"", time.Time{}, Transfer, userAddr, pair.ContractAddr, [2]Asset{{pair.Assets[0], "1000"}, {pair.Assets[1], ""}}, nil, "", "", "", 0, make(map[string]interface{})}},
 			"",
 		},
 		{
@@ -78,7 +78,7 @@ func Test_TransferMapper(t *testing.T) {
 				{Key: "from", Value: userAddr}, {Key: "to", Value: pair.ContractAddr},
 				{Key: "amount", Value: "123456789012345678901234567890123456"},
 			},
-			[]*ParsedTx{{"", time.Time{}, Transfer, userAddr, pair.ContractAddr, [2]Asset{{pair.Assets[0], ""}, {pair.Assets[1], "123456789012345678901234567890123456"}}, "", "", "", 0, make(map[string]interface{})}},
+			[]*ParsedTx{{"", time.Time{}, Transfer, userAddr, pair.ContractAddr, [2]Asset{{pair.Assets[0], ""}, {pair.Assets[1], "123456789012345678901234567890123456"}}, nil, "", "", "", 0, make(map[string]interface{})}},
 			"",
 		},
 		{
@@ -96,7 +96,7 @@ func Test_TransferMapper(t *testing.T) {
 				{Key: "_contract_address", Value: "WRONG_CW_20"}, {Key: "action", Value: "transfer"},
 				{Key: "from", Value: userAddr}, {Key: "to", Value: pair.ContractAddr}, {Key: "amount", Value: "1000"},
 			},
-			[]*ParsedTx{{"", time.Time{}, Transfer, userAddr, pair.ContractAddr, [2]Asset{{pair.Assets[0], ""}, {pair.Assets[1], ""}}, "", "", "", 0, map[string]interface{}{"WRONG_CW_20": "1000"}}},
+			[]*ParsedTx{{"", time.Time{}, Transfer, userAddr, pair.ContractAddr, [2]Asset{{pair.Assets[0], ""}, {pair.Assets[1], ""}}, nil, "", "", "", 0, map[string]interface{}{"WRONG_CW_20": "1000"}}},
 			"",
 		},
 	}
@@ -131,7 +131,7 @@ func Test_TransferMapper_OptionalFallbackSenderAndPairFiltering(t *testing.T) {
 				{Key: "recipient", Value: pair.ContractAddr},
 			},
 			optionals:  []interface{}{userAddr},
-			expectedTx: []*ParsedTx{{"", time.Time{}, Transfer, userAddr, pair.ContractAddr, [2]Asset{{pair.Assets[0], "1000"}, {pair.Assets[1], ""}}, "", "", "", 0, make(map[string]interface{})}},
+			expectedTx: []*ParsedTx{{"", time.Time{}, Transfer, userAddr, pair.ContractAddr, [2]Asset{{pair.Assets[0], "1000"}, {pair.Assets[1], ""}}, nil, "", "", "", 0, make(map[string]interface{})}},
 		},
 		{
 			name: "sender missing fallback still skips non-pair transfer",
@@ -149,7 +149,7 @@ func Test_TransferMapper_OptionalFallbackSenderAndPairFiltering(t *testing.T) {
 				{Key: "recipient", Value: userAddr},
 				{Key: "sender", Value: pair.ContractAddr},
 			},
-			expectedTx: []*ParsedTx{{"", time.Time{}, Transfer, pair.ContractAddr, pair.ContractAddr, [2]Asset{{pair.Assets[0], ""}, {pair.Assets[1], "-1000"}}, "", "", "", 0, make(map[string]interface{})}},
+			expectedTx: []*ParsedTx{{"", time.Time{}, Transfer, pair.ContractAddr, pair.ContractAddr, [2]Asset{{pair.Assets[0], ""}, {pair.Assets[1], "-1000"}}, nil, "", "", "", 0, make(map[string]interface{})}},
 		},
 	}
 
@@ -181,7 +181,7 @@ func Test_InitialProvideMapper(t *testing.T) {
 				{Key: "amount", Value: "1000"},
 				{Key: "to", Value: pair.ContractAddr},
 			},
-			[]*ParsedTx{{"", time.Time{}, InitialProvide, "", pair.ContractAddr, [2]Asset{}, pair.LpAddr, "1000", "", 0, nil}},
+			[]*ParsedTx{{"", time.Time{}, InitialProvide, "", pair.ContractAddr, [2]Asset{}, nil, pair.LpAddr, "1000", "", 0, nil}},
 			"",
 		},
 		{
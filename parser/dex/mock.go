@@ -93,6 +93,12 @@ func (m *RepoMock) Insert(srcHeight uint64, targetHeight uint64, txs []ParsedTx,
 	return args.Error(0)
 }
 
+// InsertBatch implements Repo
+func (m *RepoMock) InsertBatch(srcHeight uint64, blocks []ParsedBlock) error {
+	args := m.MethodCalled("InsertBatch", srcHeight, blocks)
+	return args.Error(0)
+}
+
 func (m *RepoMock) InsertPairValidationException(chainID string, contractAddress string) error {
 	args := m.MethodCalled("InsertPairValidationException")
 	return args.Error(0)
@@ -125,6 +131,11 @@ func (m *RepoMock) ClearValidationHeight() error {
 	return nil
 }
 
+// SavePoolValidationResult implements Repo.
+func (m *RepoMock) SavePoolValidationResult(_ PoolValidationResult) error {
+	return nil
+}
+
 func (m *RepoMock) PendingParseQuarantines() ([]ParseQuarantine, error) {
 	args := m.MethodCalled("PendingParseQuarantines")
 	return args.Get(0).([]ParseQuarantine), args.Error(1)
@@ -0,0 +1,164 @@
+package dex
+
+import (
+	"github.com/dezswap/cosmwasm-etl/parser"
+	pdex "github.com/dezswap/cosmwasm-etl/pkg/dex"
+	"github.com/dezswap/cosmwasm-etl/pkg/eventlog"
+	"github.com/pkg/errors"
+)
+
+// PairParserSpec is what a terraswap compatible app adds to the parsers
+// every such app shares.
+type PairParserSpec struct {
+	// PairFinder matches the swap, provide and withdraw events of pairFilter.
+	PairFinder         func(pairFilter map[string]bool) (eventlog.LogFinder, error)
+	PairMapper         parser.Mapper[ParsedTx]
+	WasmTransferFinder func() (eventlog.LogFinder, error)
+	// Cw20AddrKey is the key cw20 transfer events report the token with.
+	Cw20AddrKey        string
+	SkipInitialProvide bool
+	SkipLpBurn         bool
+}
+
+// UpdatePairParsers rebuilds the pair action, initial provide, wasm transfer,
+// transfer and LP burn parsers of a terraswap compatible app for pairs.
+// Skipped parsers are left nil.
+func (mixin *DexMixin) UpdatePairParsers(parsers *PairParsers, spec PairParserSpec, pairs map[string]Pair, flaggedAssets map[string]bool, tokenExceptions map[string]bool) error {
+	pairFilter := make(map[string]bool)
+	for k := range pairs {
+		pairFilter[k] = true
+	}
+
+	pairFinder, err := spec.PairFinder(pairFilter)
+	if err != nil {
+		return errors.Wrap(err, "updateParsers")
+	}
+	parsers.PairActionParser = parser.NewParser[ParsedTx](pairFinder, spec.PairMapper)
+
+	if !spec.SkipInitialProvide {
+		initialProvideFinder, err := pdex.CreatePairInitialProvideRuleFinder(pairFilter)
+		if err != nil {
+			return errors.Wrap(err, "updateParsers")
+		}
+		parsers.InitialProvide = parser.NewParser[ParsedTx](initialProvideFinder, NewInitialProvideMapper())
+	}
+
+	wasmTransferFinder, err := spec.WasmTransferFinder()
+	if err != nil {
+		return errors.Wrap(err, "updateParsers")
+	}
+	parsers.WasmTransfer = parser.NewParser[ParsedTx](
+		wasmTransferFinder,
+		NewWasmTransferMapper(
+			spec.Cw20AddrKey,
+			pairs,
+			flaggedAssets,
+			tokenExceptions,
+		),
+	)
+
+	transferRule, err := pdex.CreateTransferRuleFinder(nil)
+	if err != nil {
+		return errors.Wrap(err, "updateParsers")
+	}
+	parsers.Transfer = parser.NewParser[ParsedTx](transferRule, NewTransferMapper(pairs))
+
+	// burn parser - to collect and parse LP burn event
+	if !spec.SkipLpBurn {
+		burnRule, err := pdex.CreateBurnRuleFinder()
+		if err != nil {
+			return errors.Wrap(err, "updateParser")
+		}
+		parsers.BurnParser = parser.NewParser(burnRule, NewBurnMapper())
+	}
+	return nil
+}
+
+// ParsePairTxs parses tx with the parsers of a terraswap compatible app.
+// Created pairs are added to pairs and lpPairAddrs before the pair actions,
+// transfers and LP burns of every parsable log are collected. A nil
+// InitialProvide or BurnParser is skipped, and name prefixes the errors.
+func (mixin *DexMixin) ParsePairTxs(name string, parsers *PairParsers, pairs map[string]Pair, lpPairAddrs map[string]string, tx parser.RawTx, height uint64) ([]ParsedTx, error) {
+	txDtos := []ParsedTx{}
+	partialQuarantine := NewPartialQuarantineRecorder(tx, height)
+	createPairTxs, err := parsers.CreatePairParser.Parse(tx.LogResults, ParsedTx{Hash: tx.Hash, Timestamp: tx.Timestamp}, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s.ParseTxs create_pair tx_hash=%s", name, tx.Hash)
+	}
+	for _, ctx := range createPairTxs {
+		pairs[ctx.ContractAddr] = Pair{
+			ContractAddr: ctx.ContractAddr,
+			LpAddr:       ctx.LpAddr,
+			Assets:       ctx.AssetAddrs(),
+		}
+		lpPairAddrs[ctx.LpAddr] = ctx.ContractAddr
+		ctx.Sender = tx.Sender
+		txDtos = append(txDtos, *ctx)
+	}
+
+	pairTxs := []*ParsedTx{}
+	wasmTxs := []*ParsedTx{}
+	transferTxs := []*ParsedTx{}
+	burnTxs := []*ParsedTx{}
+	for _, raw := range tx.LogResults {
+		if !pdex.ParsableRules[string(raw.Type)] {
+			continue
+		}
+		ptxs, err := parsers.PairActionParser.Parse(eventlog.LogResults{raw}, ParsedTx{Hash: tx.Hash, Timestamp: tx.Timestamp})
+		if err != nil {
+			return nil, errors.Wrapf(err, "%s.ParseTxs pair_action tx_hash=%s", name, tx.Hash)
+		}
+		pairTxs = append(pairTxs, ptxs...)
+		// find initial provide to a pair
+		if parsers.InitialProvide != nil && mixin.HasProvide(ptxs) {
+			ipTxs, err := parsers.InitialProvide.Parse(eventlog.LogResults{raw}, ParsedTx{Hash: tx.Hash, Timestamp: tx.Timestamp})
+			if err != nil {
+				return nil, errors.Wrapf(err, "%s.ParseTxs initial_provide tx_hash=%s", name, tx.Hash)
+			}
+			pairTxs = append(pairTxs, ipTxs...)
+		}
+
+		wtxs, err := parsers.WasmTransfer.Parse(eventlog.LogResults{raw}, ParsedTx{Hash: tx.Hash, Timestamp: tx.Timestamp})
+		if err != nil {
+			wrapped := errors.Wrapf(err, "%s.ParseTxs wasm_transfer tx_hash=%s", name, tx.Hash)
+			if !partialQuarantine.Record("wasm_transfer", wrapped) {
+				return nil, wrapped
+			}
+		}
+		wasmTxs = append(wasmTxs, wtxs...)
+
+		if raw.Type == eventlog.TransferType {
+			sorted, err := pdex.NormalizeTransferAttrs(raw.Attributes)
+			if err != nil {
+				return nil, errors.Wrapf(err, "%s.ParseTxs sort_transfer_attrs tx_hash=%s", name, tx.Hash)
+			}
+			raw.Attributes = sorted
+		}
+		transfers, err := parsers.Transfer.Parse(eventlog.LogResults{raw}, ParsedTx{Hash: tx.Hash, Timestamp: tx.Timestamp}, tx.Sender)
+		if err != nil {
+			return nil, errors.Wrapf(err, "%s.ParseTxs transfer tx_hash=%s", name, tx.Hash)
+		}
+		transferTxs = append(transferTxs, transfers...)
+
+		if parsers.BurnParser != nil {
+			burns, err := parsers.BurnParser.Parse(eventlog.LogResults{raw}, ParsedTx{Hash: tx.Hash, Timestamp: tx.Timestamp})
+			if err != nil {
+				return nil, errors.Wrapf(err, "%s.ParseTxs burn tx_hash=%s", name, tx.Hash)
+			}
+			burnTxs = append(burnTxs, burns...)
+		}
+	}
+	for _, ptx := range pairTxs {
+		ptx.Sender = tx.Sender
+		txDtos = append(txDtos, *ptx)
+	}
+
+	txDtos = append(txDtos, mixin.RemoveDuplicatedTxs(pairTxs, append(wasmTxs, transferTxs...))...)
+	txDtos = append(txDtos, CollectLpBurnTxs(burnTxs, lpPairAddrs)...)
+
+	if err := partialQuarantine.Err(txDtos); err != nil {
+		return txDtos, err
+	}
+
+	return txDtos, nil
+}
@@ -0,0 +1,34 @@
+package dex
+
+import "reflect"
+
+// ParsersVersion records what the pair parsers of a target app were last
+// built from, so UpdateParsers rebuilds its finders and mappers only when
+// they would differ. Pairs are only ever added, so their count identifies the
+// pair set. mapperVersion is the height-based mapper switch of the app, 0 for
+// apps without one.
+type ParsersVersion struct {
+	built           bool
+	pairCount       int
+	mapperVersion   int
+	tokenExceptions uintptr
+}
+
+// Stale reports whether parsers built for the arguments differ from the
+// recorded ones.
+func (v *ParsersVersion) Stale(pairCount int, mapperVersion int, tokenExceptions map[string]bool) bool {
+	return !v.built ||
+		v.pairCount != pairCount ||
+		v.mapperVersion != mapperVersion ||
+		v.tokenExceptions != reflect.ValueOf(tokenExceptions).Pointer()
+}
+
+// Record marks the parsers as built for the arguments.
+func (v *ParsersVersion) Record(pairCount int, mapperVersion int, tokenExceptions map[string]bool) {
+	*v = ParsersVersion{
+		built:           true,
+		pairCount:       pairCount,
+		mapperVersion:   mapperVersion,
+		tokenExceptions: reflect.ValueOf(tokenExceptions).Pointer(),
+	}
+}
@@ -2,6 +2,7 @@ package dex
 
 import (
 	"errors"
+	"fmt"
 	"strings"
 
 	"github.com/dezswap/cosmwasm-etl/parser"
@@ -11,6 +12,9 @@ import (
 const (
 	QuarantineStatusPending  = "pending"
 	QuarantineStatusResolved = "resolved"
+	// QuarantineStatusIgnored is a quarantine closed by hand without parsing
+	// its tx.
+	QuarantineStatusIgnored = "ignored"
 
 	PartialQuarantineStagePrefix = "partial_"
 )
@@ -94,6 +98,19 @@ func (r *PartialQuarantineRecorder) Err(parsedTxs []ParsedTx) error {
 	}
 }
 
+// ReplayQuarantine parses the raw tx of quarantine again with app at its
+// height. An ambiguous parse, partial ones included, is returned as an error.
+func ReplayQuarantine(app TargetApp, routerAddr string, tokenExceptions map[string]bool, quarantine ParseQuarantine) ([]ParsedTx, error) {
+	if err := app.UpdateParsers(tokenExceptions, quarantine.Height); err != nil {
+		return nil, fmt.Errorf("update parsers for quarantine id=%d: %w", quarantine.ID, err)
+	}
+	txs, err := app.ParseTxs(quarantine.RawTx, quarantine.Height)
+	if err != nil {
+		return nil, err
+	}
+	return AttributeRoutes(routerAddr, quarantine.RawTx, txs), nil
+}
+
 func RawTxContainsCreatePair(tx parser.RawTx) bool {
 	for _, log := range tx.LogResults {
 		for _, attr := range log.Attributes {
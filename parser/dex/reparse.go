@@ -0,0 +1,41 @@
+package dex
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/dezswap/cosmwasm-etl/configs"
+	"github.com/dezswap/cosmwasm-etl/pkg/logging"
+)
+
+// ParseRange parses heights from..to of source with app the way Run does and
+// passes each parsed height to commit in order. It writes nothing itself, so
+// it serves dry runs as well as reparsing. app must hold the pair state as of
+// from.
+func ParseRange(app TargetApp, source SourceDataStore, tokenExceptions map[string]bool, c configs.ParserDexConfig, logger logging.Logger, from, to uint64, commit func(ParsedBlock) error) error {
+	runner := &dexApp{
+		TargetApp:            app,
+		SourceDataStore:      source,
+		logger:               logger,
+		chainId:              c.ChainId,
+		routerAddr:           c.RouterAddr,
+		poolSnapshotInterval: c.PoolSnapshotInterval,
+	}
+	for height := from; height <= to; height++ {
+		src := runner.fetchSource(height)
+		if src.err != nil {
+			return fmt.Errorf("parse range at height %d: %w", height, src.err)
+		}
+		block, err := runner.parseHeight(tokenExceptions, height, src)
+		if err != nil {
+			return fmt.Errorf("parse range at height %d: %w", height, err)
+		}
+		if err := commit(block); err != nil {
+			return fmt.Errorf("parse range at height %d: %w", height, err)
+		}
+		if height == math.MaxUint64 {
+			break
+		}
+	}
+	return nil
+}
@@ -0,0 +1,269 @@
+package repo
+
+import (
+	"reflect"
+	"sort"
+
+	"github.com/dezswap/cosmwasm-etl/configs"
+	"github.com/dezswap/cosmwasm-etl/parser/dex"
+	"github.com/dezswap/cosmwasm-etl/pkg/db"
+	"github.com/dezswap/cosmwasm-etl/pkg/db/schemas"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+// comparedOutFields are the parsed_tx columns a replayed row never has.
+var comparedOutFields = map[string]bool{"id": true}
+
+// FieldChange is a parsed_tx column whose stored and replayed values differ.
+type FieldChange struct {
+	Field    string      `json:"field"`
+	Stored   interface{} `json:"stored"`
+	Replayed interface{} `json:"replayed"`
+}
+
+// ParsedTxChange is a stored parsed_tx row and its replayed counterpart that
+// differ in Fields. Rows are paired by type and contract in their order.
+type ParsedTxChange struct {
+	Type     dex.TxType    `json:"type"`
+	Contract string        `json:"contract"`
+	Fields   []FieldChange `json:"fields"`
+}
+
+// PoolDelta is the change of a pool the parsed_tx rows of a tx add up to.
+// Assets follow the pair asset order.
+type PoolDelta struct {
+	Assets   []dex.Asset `json:"assets"`
+	LpAmount string      `json:"lp_amount"`
+}
+
+// PoolDeltaComparison is the pool delta of a contract from the stored and from
+// the replayed rows of a tx.
+type PoolDeltaComparison struct {
+	Contract string     `json:"contract"`
+	Stored   *PoolDelta `json:"stored"`
+	Replayed *PoolDelta `json:"replayed"`
+	Changed  bool       `json:"changed"`
+}
+
+// ParsedTxComparison is the difference between the stored parsed_tx rows of a
+// tx and the rows it is parsed into again.
+type ParsedTxComparison struct {
+	Added      []schemas.ParsedTx    `json:"added,omitempty"`
+	Missing    []schemas.ParsedTx    `json:"missing,omitempty"`
+	Changed    []ParsedTxChange      `json:"changed,omitempty"`
+	PoolDeltas []PoolDeltaComparison `json:"pool_deltas,omitempty"`
+}
+
+// Unchanged reports whether the replayed rows match the stored ones.
+func (c ParsedTxComparison) Unchanged() bool {
+	for _, delta := range c.PoolDeltas {
+		if delta.Changed {
+			return false
+		}
+	}
+	return len(c.Added) == 0 && len(c.Missing) == 0 && len(c.Changed) == 0
+}
+
+// ParsedTxComparer compares stored parsed_tx rows with a replayed parse.
+type ParsedTxComparer interface {
+	// CompareParsedTxs compares the stored parsed_tx rows of hash at height
+	// with txs, the tx parsed again. With poolDelta the pool deltas of both
+	// versions are compared as well.
+	CompareParsedTxs(height uint64, hash string, txs []dex.ParsedTx, poolDelta bool) (ParsedTxComparison, error)
+	// StoredParsedTxs returns the stored parsed_tx rows of hashes at height
+	// in insert order.
+	StoredParsedTxs(height uint64, hashes []string) ([]schemas.ParsedTx, error)
+}
+
+var _ ParsedTxComparer = (*repoImpl)(nil)
+
+func NewParsedTxComparer(chainId string, dbConfig configs.RdbConfig) ParsedTxComparer {
+	gormDB, err := db.OpenGormPostgres(dbConfig)
+	if err != nil {
+		panic(err)
+	}
+
+	return NewParsedTxComparerWithDB(chainId, gormDB)
+}
+
+func NewParsedTxComparerWithDB(chainId string, db *gorm.DB) ParsedTxComparer {
+	return &repoImpl{mapper: &parserMapperImpl{}, db: db, chainId: chainId}
+}
+
+// CompareParsedTxs implements ParsedTxComparer
+func (r *repoImpl) CompareParsedTxs(height uint64, hash string, txs []dex.ParsedTx, poolDelta bool) (ParsedTxComparison, error) {
+	stored, err := r.StoredParsedTxs(height, []string{hash})
+	if err != nil {
+		return ParsedTxComparison{}, errors.Wrap(err, "repo.CompareParsedTxs")
+	}
+	replayed := make([]schemas.ParsedTx, len(txs))
+	for idx, tx := range txs {
+		replayed[idx] = r.toParsedTxModel(r.chainId, height, tx)
+	}
+
+	comparison, err := compareParsedTxs(stored, replayed)
+	if err != nil {
+		return ParsedTxComparison{}, errors.Wrap(err, "repo.CompareParsedTxs")
+	}
+	if poolDelta {
+		if comparison.PoolDeltas, err = comparePoolDeltas(stored, replayed); err != nil {
+			return ParsedTxComparison{}, errors.Wrap(err, "repo.CompareParsedTxs")
+		}
+	}
+	return comparison, nil
+}
+
+// StoredParsedTxs implements ParsedTxComparer
+func (r *repoImpl) StoredParsedTxs(height uint64, hashes []string) ([]schemas.ParsedTx, error) {
+	stored := []schemas.ParsedTx{}
+	if len(hashes) == 0 {
+		return stored, nil
+	}
+	if err := r.db.Where("chain_id = ? AND height = ? AND hash IN ?", r.chainId, height, hashes).
+		Order("id").Find(&stored).Error; err != nil {
+		return nil, errors.Wrap(err, "repo.StoredParsedTxs")
+	}
+	return stored, nil
+}
+
+// compareParsedTxs pairs stored and replayed rows of the same type and
+// contract in their order and reports the unpaired and the differing ones.
+func compareParsedTxs(stored, replayed []schemas.ParsedTx) (ParsedTxComparison, error) {
+	type rowKey struct {
+		txType   dex.TxType
+		contract string
+	}
+	storedByKey := map[rowKey][]int{}
+	for idx, row := range stored {
+		key := rowKey{row.Type, row.Contract}
+		storedByKey[key] = append(storedByKey[key], idx)
+	}
+
+	comparison := ParsedTxComparison{}
+	paired := make([]bool, len(stored))
+	for _, row := range replayed {
+		key := rowKey{row.Type, row.Contract}
+		candidates := storedByKey[key]
+		if len(candidates) == 0 {
+			comparison.Added = append(comparison.Added, row)
+			continue
+		}
+		storedByKey[key] = candidates[1:]
+		paired[candidates[0]] = true
+
+		fields, err := changedFields(stored[candidates[0]], row)
+		if err != nil {
+			return ParsedTxComparison{}, err
+		}
+		if len(fields) > 0 {
+			comparison.Changed = append(comparison.Changed, ParsedTxChange{Type: row.Type, Contract: row.Contract, Fields: fields})
+		}
+	}
+	for idx, row := range stored {
+		if !paired[idx] {
+			comparison.Missing = append(comparison.Missing, row)
+		}
+	}
+	return comparison, nil
+}
+
+// changedFields lists the columns of stored and replayed that differ, by
+// their JSON name.
+func changedFields(stored, replayed schemas.ParsedTx) ([]FieldChange, error) {
+	storedFields, err := normalizedRow(stored)
+	if err != nil {
+		return nil, err
+	}
+	replayedFields, err := normalizedRow(replayed)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(storedFields))
+	for name := range storedFields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fields := []FieldChange{}
+	for _, name := range names {
+		if comparedOutFields[name] || reflect.DeepEqual(storedFields[name], replayedFields[name]) {
+			continue
+		}
+		fields = append(fields, FieldChange{Field: name, Stored: storedFields[name], Replayed: replayedFields[name]})
+	}
+	return fields, nil
+}
+
+// comparePoolDeltas sums the pool delta of each contract from the stored and
+// from the replayed rows.
+func comparePoolDeltas(stored, replayed []schemas.ParsedTx) ([]PoolDeltaComparison, error) {
+	storedDeltas, err := poolDeltas(stored)
+	if err != nil {
+		return nil, err
+	}
+	replayedDeltas, err := poolDeltas(replayed)
+	if err != nil {
+		return nil, err
+	}
+
+	contracts := []string{}
+	for contract := range storedDeltas {
+		contracts = append(contracts, contract)
+	}
+	for contract := range replayedDeltas {
+		if _, ok := storedDeltas[contract]; !ok {
+			contracts = append(contracts, contract)
+		}
+	}
+	sort.Strings(contracts)
+
+	comparisons := make([]PoolDeltaComparison, 0, len(contracts))
+	for _, contract := range contracts {
+		comparisons = append(comparisons, PoolDeltaComparison{
+			Contract: contract,
+			Stored:   storedDeltas[contract],
+			Replayed: replayedDeltas[contract],
+			Changed:  !reflect.DeepEqual(storedDeltas[contract], replayedDeltas[contract]),
+		})
+	}
+	return comparisons, nil
+}
+
+// poolDeltas sums rows by contract the way ParsedPoolsInfo sums parsed_tx.
+func poolDeltas(rows []schemas.ParsedTx) (map[string]*PoolDelta, error) {
+	deltas := map[string]*PoolDelta{}
+	for _, row := range rows {
+		assets := []dex.Asset{{Addr: row.Asset0, Amount: row.Asset0Amount}, {Addr: row.Asset1, Amount: row.Asset1Amount}}
+		for idx, addr := range row.ExtraAssets {
+			amount := "0"
+			if idx < len(row.ExtraAssetAmounts) {
+				amount = row.ExtraAssetAmounts[idx]
+			}
+			assets = append(assets, dex.Asset{Addr: addr, Amount: amount})
+		}
+
+		delta, ok := deltas[row.Contract]
+		if !ok {
+			delta = &PoolDelta{LpAmount: "0"}
+			deltas[row.Contract] = delta
+		}
+		for idx, asset := range assets {
+			if idx == len(delta.Assets) {
+				delta.Assets = append(delta.Assets, dex.Asset{Addr: asset.Addr, Amount: "0"})
+			}
+			amount, err := dex.AmountAdd(delta.Assets[idx].Amount, asset.Amount)
+			if err != nil {
+				return nil, errors.Wrapf(err, "pool delta of %s", row.Contract)
+			}
+			delta.Assets[idx].Amount = amount
+		}
+		lpAmount, err := dex.AmountAdd(delta.LpAmount, row.LpAmount)
+		if err != nil {
+			return nil, errors.Wrapf(err, "pool delta of %s", row.Contract)
+		}
+		delta.LpAmount = lpAmount
+	}
+	return deltas, nil
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/dezswap/cosmwasm-etl/parser/dex"
 	"github.com/dezswap/cosmwasm-etl/pkg/db/schemas"
+	"github.com/lib/pq"
 )
 
 type mapper interface {
@@ -22,11 +23,12 @@ type parserMapperImpl struct{}
 // toPairModel implements mapper
 func (*parserMapperImpl) toPairModel(chainId string, pair dex.Pair) schemas.Pair {
 	return schemas.Pair{
-		ChainId:  chainId,
-		Contract: pair.ContractAddr,
-		Asset0:   pair.Assets[0],
-		Asset1:   pair.Assets[1],
-		Lp:       pair.LpAddr,
+		ChainId:     chainId,
+		Contract:    pair.ContractAddr,
+		Asset0:      pair.Assets[0],
+		Asset1:      pair.Assets[1],
+		ExtraAssets: extraStrings(pair.Assets),
+		Lp:          pair.LpAddr,
 	}
 }
 
@@ -34,7 +36,7 @@ func (*parserMapperImpl) toPairModel(chainId string, pair dex.Pair) schemas.Pair
 func (*parserMapperImpl) toPairDto(pair schemas.Pair) dex.Pair {
 	return dex.Pair{
 		ContractAddr: pair.Contract,
-		Assets:       []string{pair.Asset0, pair.Asset1},
+		Assets:       append([]string{pair.Asset0, pair.Asset1}, pair.ExtraAssets...),
 		LpAddr:       pair.Lp,
 	}
 }
@@ -46,42 +48,54 @@ func (p *parserMapperImpl) toParsedTxModel(chainId string, height uint64, tx dex
 		lpAmount = "-" + lpAmount
 	}
 	commission := p.emptyStringToZero(tx.CommissionAmount)
-	commission0, commission1 := "0", "0"
-	if strings.HasPrefix(tx.Assets[0].Amount, "-") {
-		commission0 = commission
-	} else {
-		commission1 = commission
+	// the commission is taken from the returned asset, the first outflow
+	assets := tx.AllAssets()
+	commissions := make([]string, len(assets))
+	returnIdx := -1
+	for idx, asset := range assets {
+		commissions[idx] = "0"
+		if returnIdx == -1 && strings.HasPrefix(asset.Amount, "-") {
+			returnIdx = idx
+		}
 	}
+	if returnIdx == -1 {
+		returnIdx = 1
+	}
+	commissions[returnIdx] = commission
 	return schemas.ParsedTx{
-		ChainId:           chainId,
-		Height:            height,
-		Timestamp:         float64(tx.Timestamp.UTC().Unix()),
-		Type:              tx.Type,
-		Hash:              tx.Hash,
-		Contract:          tx.ContractAddr,
-		Asset0:            tx.Assets[0].Addr,
-		Asset0Amount:      p.emptyStringToZero(tx.Assets[0].Amount),
-		Asset1:            tx.Assets[1].Addr,
-		Asset1Amount:      p.emptyStringToZero(tx.Assets[1].Amount),
-		Lp:                tx.LpAddr,
-		LpAmount:          lpAmount,
-		Sender:            tx.Sender,
-		Commission0Amount: commission0,
-		Commission1Amount: commission1,
-		CommissionAmount:  commission,
-		Meta:              tx.Meta,
+		ChainId:                chainId,
+		Height:                 height,
+		Timestamp:              float64(tx.Timestamp.UTC().Unix()),
+		Type:                   tx.Type,
+		Hash:                   tx.Hash,
+		Contract:               tx.ContractAddr,
+		Asset0:                 tx.Assets[0].Addr,
+		Asset0Amount:           p.emptyStringToZero(tx.Assets[0].Amount),
+		Asset1:                 tx.Assets[1].Addr,
+		Asset1Amount:           p.emptyStringToZero(tx.Assets[1].Amount),
+		ExtraAssets:            extraAssetAddrs(tx.ExtraAssets),
+		ExtraAssetAmounts:      p.extraAssetAmounts(tx.ExtraAssets),
+		Lp:                     tx.LpAddr,
+		LpAmount:               lpAmount,
+		Sender:                 tx.Sender,
+		Commission0Amount:      commissions[0],
+		Commission1Amount:      commissions[1],
+		ExtraCommissionAmounts: extraStrings(commissions),
+		CommissionAmount:       commission,
+		Meta:                   tx.Meta,
 	}
 }
 
 // toPoolInfoModel implements mapper
-func (*parserMapperImpl) toPoolInfoModel(chainId string, height uint64, pool dex.PoolInfo) schemas.PoolInfo {
+func (p *parserMapperImpl) toPoolInfoModel(chainId string, height uint64, pool dex.PoolInfo) schemas.PoolInfo {
 	return schemas.PoolInfo{
-		ChainId:      chainId,
-		Height:       height,
-		Contract:     pool.ContractAddr,
-		Asset0Amount: pool.Assets[0].Amount,
-		Asset1Amount: pool.Assets[1].Amount,
-		LpAmount:     pool.TotalShare,
+		ChainId:           chainId,
+		Height:            height,
+		Contract:          pool.ContractAddr,
+		Asset0Amount:      pool.Assets[0].Amount,
+		Asset1Amount:      pool.Assets[1].Amount,
+		ExtraAssetAmounts: p.extraAssetAmounts(extraPoolAssets(pool.Assets)),
+		LpAmount:          pool.TotalShare,
 	}
 }
 
@@ -91,3 +105,41 @@ func (p *parserMapperImpl) emptyStringToZero(amount string) string {
 	}
 	return amount
 }
+
+func (p *parserMapperImpl) extraAssetAmounts(assets []dex.Asset) pq.StringArray {
+	if len(assets) == 0 {
+		return nil
+	}
+	amounts := make(pq.StringArray, 0, len(assets))
+	for _, asset := range assets {
+		amounts = append(amounts, p.emptyStringToZero(asset.Amount))
+	}
+	return amounts
+}
+
+// extraStrings returns the values after the second, or nil for two values so
+// two asset pools keep NULL extra columns.
+func extraStrings(values []string) pq.StringArray {
+	if len(values) <= 2 {
+		return nil
+	}
+	return append(pq.StringArray{}, values[2:]...)
+}
+
+func extraPoolAssets(assets []dex.Asset) []dex.Asset {
+	if len(assets) <= 2 {
+		return nil
+	}
+	return assets[2:]
+}
+
+func extraAssetAddrs(assets []dex.Asset) pq.StringArray {
+	if len(assets) == 0 {
+		return nil
+	}
+	addrs := make(pq.StringArray, 0, len(assets))
+	for _, asset := range assets {
+		addrs = append(addrs, asset.Addr)
+	}
+	return addrs
+}
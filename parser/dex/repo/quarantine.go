@@ -0,0 +1,131 @@
+package repo
+
+import (
+	"encoding/json"
+
+	"github.com/dezswap/cosmwasm-etl/configs"
+	"github.com/dezswap/cosmwasm-etl/parser"
+	"github.com/dezswap/cosmwasm-etl/parser/dex"
+	"github.com/dezswap/cosmwasm-etl/pkg/db"
+	"github.com/dezswap/cosmwasm-etl/pkg/db/schemas"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+// QuarantineFilter selects parse_quarantine rows. Zero fields match any row.
+type QuarantineFilter struct {
+	Status     string
+	Stage      string
+	Contract   string
+	Action     string
+	FromHeight uint64
+	ToHeight   uint64
+	Limit      int
+}
+
+// QuarantineManager looks up parse_quarantine rows and closes them by hand.
+type QuarantineManager interface {
+	// ParseQuarantines lists the rows matching filter by height without
+	// their raw tx.
+	ParseQuarantines(filter QuarantineFilter) ([]schemas.ParseQuarantine, error)
+	ParseQuarantine(id uint64) (schemas.ParseQuarantine, error)
+	// CloseParseQuarantine moves a pending quarantine to status, resolved or
+	// ignored, with reason. Its parsed_tx rows are left as they are.
+	CloseParseQuarantine(id uint64, status string, reason string) error
+}
+
+var _ QuarantineManager = (*repoImpl)(nil)
+
+func NewQuarantineManager(chainId string, dbConfig configs.RdbConfig) QuarantineManager {
+	gormDB, err := db.OpenGormPostgres(dbConfig)
+	if err != nil {
+		panic(err)
+	}
+
+	return NewQuarantineManagerWithDB(chainId, gormDB)
+}
+
+func NewQuarantineManagerWithDB(chainId string, db *gorm.DB) QuarantineManager {
+	return &repoImpl{mapper: &parserMapperImpl{}, db: db, chainId: chainId}
+}
+
+// ParseQuarantines implements QuarantineManager
+func (r *repoImpl) ParseQuarantines(filter QuarantineFilter) ([]schemas.ParseQuarantine, error) {
+	query := r.db.Omit("raw_tx").Where("chain_id = ?", r.chainId)
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.Stage != "" {
+		query = query.Where("stage = ?", filter.Stage)
+	}
+	if filter.Contract != "" {
+		query = query.Where("contract = ?", filter.Contract)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.FromHeight > 0 {
+		query = query.Where("height >= ?", filter.FromHeight)
+	}
+	if filter.ToHeight > 0 {
+		query = query.Where("height <= ?", filter.ToHeight)
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	rows := []schemas.ParseQuarantine{}
+	if err := query.Order("height ASC, id ASC").Find(&rows).Error; err != nil {
+		return nil, errors.Wrap(err, "repo.ParseQuarantines")
+	}
+	return rows, nil
+}
+
+// ParseQuarantine implements QuarantineManager
+func (r *repoImpl) ParseQuarantine(id uint64) (schemas.ParseQuarantine, error) {
+	row := schemas.ParseQuarantine{}
+	if err := r.db.Where("id = ? AND chain_id = ?", id, r.chainId).First(&row).Error; err != nil {
+		return row, errors.Wrapf(err, "repo.ParseQuarantine id=%d", id)
+	}
+	return row, nil
+}
+
+// CloseParseQuarantine implements QuarantineManager
+func (r *repoImpl) CloseParseQuarantine(id uint64, status string, reason string) error {
+	if status != dex.QuarantineStatusResolved && status != dex.QuarantineStatusIgnored {
+		return errors.Errorf("repo.CloseParseQuarantine: invalid status %q", status)
+	}
+	result := r.db.Model(&schemas.ParseQuarantine{}).
+		Where("id = ? AND chain_id = ? AND status = ?", id, r.chainId, dex.QuarantineStatusPending).
+		Updates(map[string]interface{}{
+			"status":      status,
+			"reason":      reason,
+			"resolved_at": gorm.Expr("EXTRACT(EPOCH FROM NOW())"),
+			"updated_at":  gorm.Expr("EXTRACT(EPOCH FROM NOW())"),
+		})
+	if result.Error != nil {
+		return errors.Wrap(result.Error, "repo.CloseParseQuarantine")
+	}
+	if result.RowsAffected != 1 {
+		return errors.Errorf("repo.CloseParseQuarantine: pending quarantine not found id=%d", id)
+	}
+	return nil
+}
+
+// ToParseQuarantineDto decodes row for replaying its raw tx.
+func ToParseQuarantineDto(row schemas.ParseQuarantine) (dex.ParseQuarantine, error) {
+	var rawTx parser.RawTx
+	if err := json.Unmarshal(row.RawTx, &rawTx); err != nil {
+		return dex.ParseQuarantine{}, errors.Wrapf(err, "repo.ToParseQuarantineDto id=%d", row.Id)
+	}
+	return dex.ParseQuarantine{
+		ID:       row.Id,
+		Height:   row.Height,
+		Hash:     row.Hash,
+		Stage:    row.Stage,
+		Contract: row.Contract,
+		Action:   row.Action,
+		Error:    row.Error,
+		RawTx:    rawTx,
+	}, nil
+}
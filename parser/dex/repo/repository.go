@@ -5,7 +5,6 @@ import (
 	"fmt"
 
 	"github.com/dezswap/cosmwasm-etl/configs"
-	"github.com/dezswap/cosmwasm-etl/parser"
 	"github.com/dezswap/cosmwasm-etl/parser/dex"
 	"github.com/dezswap/cosmwasm-etl/pkg/db"
 	"github.com/dezswap/cosmwasm-etl/pkg/db/schemas"
@@ -82,43 +81,68 @@ func (r *repoImpl) Insert(srcHeight uint64, targetHeight uint64, txs []dex.Parse
 		return errors.New(errMsg)
 	}
 
+	block := dex.ParsedBlock{Height: targetHeight, Txs: txs, Pools: pools, Pairs: pairs, Quarantines: quarantines}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := r.insertBlock(tx, block); err != nil {
+			return err
+		}
+		if err := tx.Model(&schemas.SyncedHeight{}).Where("chain_id = ? AND height = ?", r.chainId, srcHeight).Update("height", targetHeight).Error; err != nil {
+			return errors.Wrap(err, "repo.Insert.SyncedHeight")
+		}
+		return nil
+	})
+}
+
+// InsertBatch implements dex.Repo
+func (r *repoImpl) InsertBatch(srcHeight uint64, blocks []dex.ParsedBlock) error {
+	if len(blocks) == 0 {
+		return nil
+	}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for _, block := range blocks {
+			if err := r.insertBlock(tx, block); err != nil {
+				return err
+			}
+		}
+		targetHeight := blocks[len(blocks)-1].Height
+		if err := tx.Model(&schemas.SyncedHeight{}).Where("chain_id = ? AND height = ?", r.chainId, srcHeight).Update("height", targetHeight).Error; err != nil {
+			return errors.Wrap(err, "repo.InsertBatch.SyncedHeight")
+		}
+		return nil
+	})
+}
+
+// insertBlock writes the rows of one height within tx.
+func (r *repoImpl) insertBlock(tx *gorm.DB, block dex.ParsedBlock) error {
 	parsedTxs := []schemas.ParsedTx{}
-	for _, tx := range txs {
-		parsedTxs = append(parsedTxs, r.toParsedTxModel(r.chainId, targetHeight, tx))
+	for _, parsedTx := range block.Txs {
+		parsedTxs = append(parsedTxs, r.toParsedTxModel(r.chainId, block.Height, parsedTx))
 	}
 	poolInfoTxs := []schemas.PoolInfo{}
-	for _, pool := range pools {
-		poolInfoTxs = append(poolInfoTxs, r.toPoolInfoModel(r.chainId, targetHeight, pool))
+	for _, pool := range block.Pools {
+		poolInfoTxs = append(poolInfoTxs, r.toPoolInfoModel(r.chainId, block.Height, pool))
 	}
 	pairTxs := []schemas.Pair{}
-	for _, pair := range pairs {
+	for _, pair := range block.Pairs {
 		pairTxs = append(pairTxs, r.toPairModel(r.chainId, pair))
 	}
 
-	return r.db.Transaction(func(tx *gorm.DB) error {
-		if len(pairTxs) > 0 {
-			if err := tx.Model(schemas.Pair{}).CreateInBatches(pairTxs, len(pairTxs)).Error; err != nil {
-				return errors.Wrap(err, "repo.Insert.Pair")
-			}
-		}
-		if len(parsedTxs) > 0 {
-			if err := tx.Model(schemas.ParsedTx{}).Omit("Id").CreateInBatches(parsedTxs, len(parsedTxs)).Error; err != nil {
-				return errors.Wrap(err, "repo.Insert.ParsedTx")
-			}
-		}
-		if len(poolInfoTxs) > 0 {
-			if err := tx.Model(schemas.PoolInfo{}).CreateInBatches(poolInfoTxs, len(poolInfoTxs)).Error; err != nil {
-				return errors.Wrap(err, "repo.Insert.PoolInfo")
-			}
+	if len(pairTxs) > 0 {
+		if err := tx.Model(schemas.Pair{}).CreateInBatches(pairTxs, len(pairTxs)).Error; err != nil {
+			return errors.Wrap(err, "repo.Insert.Pair")
 		}
-		if err := r.upsertParseQuarantines(tx, quarantines); err != nil {
-			return err
+	}
+	if len(parsedTxs) > 0 {
+		if err := tx.Model(schemas.ParsedTx{}).Omit("Id").CreateInBatches(parsedTxs, len(parsedTxs)).Error; err != nil {
+			return errors.Wrap(err, "repo.Insert.ParsedTx")
 		}
-		if err := tx.Model(&schemas.SyncedHeight{}).Where("chain_id = ? AND height = ?", r.chainId, srcHeight).Update("height", targetHeight).Error; err != nil {
-			return errors.Wrap(err, "repo.Insert.SyncedHeight")
+	}
+	if len(poolInfoTxs) > 0 {
+		if err := tx.Model(schemas.PoolInfo{}).CreateInBatches(poolInfoTxs, len(poolInfoTxs)).Error; err != nil {
+			return errors.Wrap(err, "repo.Insert.PoolInfo")
 		}
-		return nil
-	})
+	}
+	return r.upsertParseQuarantines(tx, block.Quarantines)
 }
 
 func (r *repoImpl) InsertPairValidationException(chainID string, contractAddress string) error {
@@ -152,14 +176,19 @@ func (r *repoImpl) ParsedPoolsInfo(from, to uint64) ([]dex.PoolInfo, error) {
 		return nil, errors.Wrap(err, "repoImpl.ParsedPoolInfo")
 	}
 
+	extraAssets, err := r.parsedExtraAssets(from, to)
+	if err != nil {
+		return nil, errors.Wrap(err, "repoImpl.ParsedPoolInfo")
+	}
+
 	results := []dex.PoolInfo{}
 	for _, pool := range pools {
 		results = append(results, dex.PoolInfo{
 			ContractAddr: pool.Contract,
-			Assets: []dex.Asset{
+			Assets: append([]dex.Asset{
 				{Addr: pool.Asset0, Amount: pool.Asset0_amount},
 				{Addr: pool.Asset1, Amount: pool.Asset1_amount},
-			},
+			}, extraAssets[pool.Contract]...),
 			TotalShare: pool.LpAmount,
 		})
 	}
@@ -167,6 +196,34 @@ func (r *repoImpl) ParsedPoolsInfo(from, to uint64) ([]dex.PoolInfo, error) {
 	return results, nil
 }
 
+// parsedExtraAssets sums the extra assets of pools with more than two assets
+// by their position, keyed by pair contract.
+func (r *repoImpl) parsedExtraAssets(from, to uint64) (map[string][]dex.Asset, error) {
+	type extraAsset struct {
+		Contract string
+		Idx      int
+		Asset    string
+		Amount   string
+	}
+
+	rows := []extraAsset{}
+	if err := r.db.Raw(`
+SELECT pt.contract, e.idx, MAX(e.asset) AS asset, SUM(e.amount) AS amount
+FROM parsed_tx pt
+    CROSS JOIN LATERAL UNNEST(pt.extra_assets, pt.extra_asset_amounts) WITH ORDINALITY AS e(asset, amount, idx)
+WHERE pt.chain_id = ? AND pt.height >= ? AND pt.height <= ?
+GROUP BY pt.contract, e.idx
+ORDER BY pt.contract, e.idx`, r.chainId, from, to).Scan(&rows).Error; err != nil {
+		return nil, errors.Wrap(err, "repoImpl.parsedExtraAssets")
+	}
+
+	result := map[string][]dex.Asset{}
+	for _, row := range rows {
+		result[row.Contract] = append(result[row.Contract], dex.Asset{Addr: row.Asset, Amount: row.Amount})
+	}
+	return result, nil
+}
+
 // GetTokenExceptions implements dex.PairRepo.
 func (r *repoImpl) GetTokenExceptions() (map[string]bool, error) {
 	var rows []schemas.TokenParseException
@@ -281,20 +338,11 @@ func (r *repoImpl) PendingParseQuarantines() ([]dex.ParseQuarantine, error) {
 
 	result := make([]dex.ParseQuarantine, 0, len(rows))
 	for _, row := range rows {
-		var rawTx parser.RawTx
-		if err := json.Unmarshal(row.RawTx, &rawTx); err != nil {
-			return nil, errors.Wrapf(err, "repo.PendingParseQuarantines id=%d", row.Id)
+		quarantine, err := ToParseQuarantineDto(row)
+		if err != nil {
+			return nil, errors.Wrap(err, "repo.PendingParseQuarantines")
 		}
-		result = append(result, dex.ParseQuarantine{
-			ID:       row.Id,
-			Height:   row.Height,
-			Hash:     row.Hash,
-			Stage:    row.Stage,
-			Contract: row.Contract,
-			Action:   row.Action,
-			Error:    row.Error,
-			RawTx:    rawTx,
-		})
+		result = append(result, quarantine)
 	}
 	return result, nil
 }
@@ -307,6 +355,12 @@ func (r *repoImpl) ResolveParseQuarantine(id uint64, height uint64, txs []dex.Pa
 
 	return r.db.Transaction(func(tx *gorm.DB) error {
 		// The whole transaction is persisted here to avoid partial, non-idempotent replay results.
+		// Rows a partial quarantine inserted with its height are replaced.
+		if err := tx.Where("chain_id = ? AND height = ? AND hash IN (?)", r.chainId, height,
+			tx.Model(&schemas.ParseQuarantine{}).Select("hash").Where("id = ? AND chain_id = ?", id, r.chainId),
+		).Delete(&schemas.ParsedTx{}).Error; err != nil {
+			return errors.Wrap(err, "repo.ResolveParseQuarantine.ParsedTx")
+		}
 		if len(parsedTxs) > 0 {
 			if err := tx.Model(schemas.ParsedTx{}).Omit("Id").CreateInBatches(parsedTxs, len(parsedTxs)).Error; err != nil {
 				return errors.Wrap(err, "repo.ResolveParseQuarantine.ParsedTx")
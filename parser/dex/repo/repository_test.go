@@ -12,8 +12,10 @@ import (
 	"github.com/dezswap/cosmwasm-etl/parser"
 	"github.com/dezswap/cosmwasm-etl/parser/dex"
 	rootdb "github.com/dezswap/cosmwasm-etl/pkg/db"
+	"github.com/dezswap/cosmwasm-etl/pkg/db/schemas"
 	"github.com/dezswap/cosmwasm-etl/pkg/eventlog"
 	"github.com/dezswap/cosmwasm-etl/pkg/faker"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
@@ -252,6 +254,34 @@ func (s *insertSuite) Test_Insert() {
 	assert.Error(err)
 }
 
+func (s *insertSuite) Test_InsertBatch() {
+	assert := assert.New(s.T())
+	parsedTxIds := sqlmock.NewRows([]string{"id"})
+	for i := 0; i < len(s.parsedTxs); i++ {
+		parsedTxIds = parsedTxIds.AddRow(0)
+	}
+	blocks := []dex.ParsedBlock{
+		{Height: s.height, Txs: s.parsedTxs},
+		{Height: s.height + 1},
+		{Height: s.height + 2, Txs: s.parsedTxs},
+	}
+
+	s.Mock.ExpectBegin()
+	s.Mock.ExpectQuery(`INSERT INTO "parsed_tx" (.*)`).WillReturnRows(parsedTxIds)
+	s.Mock.ExpectQuery(`INSERT INTO "parsed_tx" (.*)`).WillReturnRows(parsedTxIds)
+	s.Mock.ExpectExec(`UPDATE "synced_height" SET "height"=\$1 WHERE chain\_id = \$2 AND height = \$3`).WithArgs(s.height+2, s.Repo.chainId, s.height-1).WillReturnResult(sqlmock.NewResult(1, 1))
+	s.Mock.ExpectCommit()
+	assert.NoError(s.Repo.InsertBatch(s.height-1, blocks))
+
+	s.Mock.ExpectBegin()
+	s.Mock.ExpectQuery(`INSERT INTO "parsed_tx" (.*)`).WillReturnError(errors.New("insert failed"))
+	s.Mock.ExpectRollback()
+	assert.Error(s.Repo.InsertBatch(s.height-1, blocks))
+
+	assert.NoError(s.Repo.InsertBatch(s.height-1, nil))
+	assert.NoError(s.Mock.ExpectationsWereMet())
+}
+
 type validationExceptionSuite struct {
 	baseSuite
 }
@@ -408,6 +438,9 @@ func (s *parseQuarantineSuite) Test_PendingParseQuarantines() {
 
 func (s *parseQuarantineSuite) Test_ResolveParseQuarantine() {
 	s.Mock.ExpectBegin()
+	s.Mock.ExpectExec(`DELETE FROM "parsed_tx" WHERE chain_id = \$1 AND height = \$2 AND hash IN \(SELECT "hash" FROM "parse_quarantine" WHERE id = \$3 AND chain_id = \$4\)`).
+		WithArgs(s.Repo.chainId, uint64(10), uint64(1), s.Repo.chainId).
+		WillReturnResult(sqlmock.NewResult(0, 2))
 	s.Mock.ExpectExec(`UPDATE "parse_quarantine" SET "resolved_at"=EXTRACT\(EPOCH FROM NOW\(\)\),"status"=\$1,"updated_at"=EXTRACT\(EPOCH FROM NOW\(\)\) WHERE id = \$2 AND chain_id = \$3 AND status = \$4`).
 		WithArgs(dex.QuarantineStatusResolved, uint64(1), s.Repo.chainId, dex.QuarantineStatusPending).
 		WillReturnResult(sqlmock.NewResult(0, 1))
@@ -416,6 +449,285 @@ func (s *parseQuarantineSuite) Test_ResolveParseQuarantine() {
 	s.NoError(s.Repo.ResolveParseQuarantine(1, 10, nil))
 }
 
+func (s *parseQuarantineSuite) Test_ParseQuarantines_Filters() {
+	s.Mock.ExpectQuery(`SELECT (.+)"parse_quarantine"."reason","parse_quarantine"."resolved_at" FROM "parse_quarantine" WHERE chain_id = \$1 AND status = \$2 AND stage = \$3 AND height >= \$4 ORDER BY height ASC, id ASC LIMIT \$5`).
+		WithArgs(s.Repo.chainId, dex.QuarantineStatusPending, "partial_wasm_transfer", uint64(10), 5).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "height", "stage"}).AddRow(1, 10, "partial_wasm_transfer"))
+
+	rows, err := s.Repo.ParseQuarantines(QuarantineFilter{
+		Status: dex.QuarantineStatusPending, Stage: "partial_wasm_transfer", FromHeight: 10, Limit: 5,
+	})
+	s.NoError(err)
+	s.Len(rows, 1)
+	s.NoError(s.Mock.ExpectationsWereMet())
+}
+
+func (s *parseQuarantineSuite) Test_CloseParseQuarantine() {
+	s.Mock.ExpectBegin()
+	s.Mock.ExpectExec(`UPDATE "parse_quarantine" SET "reason"=\$1,"resolved_at"=EXTRACT\(EPOCH FROM NOW\(\)\),"status"=\$2,"updated_at"=EXTRACT\(EPOCH FROM NOW\(\)\) WHERE id = \$3 AND chain_id = \$4 AND status = \$5`).
+		WithArgs("known bad event", dex.QuarantineStatusIgnored, uint64(1), s.Repo.chainId, dex.QuarantineStatusPending).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	s.Mock.ExpectCommit()
+	s.NoError(s.Repo.CloseParseQuarantine(1, dex.QuarantineStatusIgnored, "known bad event"))
+
+	s.Mock.ExpectBegin()
+	s.Mock.ExpectExec(`UPDATE "parse_quarantine" (.+)`).WillReturnResult(sqlmock.NewResult(0, 0))
+	s.Mock.ExpectCommit()
+	s.Error(s.Repo.CloseParseQuarantine(2, dex.QuarantineStatusResolved, "fixed"))
+
+	s.Error(s.Repo.CloseParseQuarantine(3, dex.QuarantineStatusPending, "reopen"))
+	s.NoError(s.Mock.ExpectationsWereMet())
+}
+
+type poolValidationResultSuite struct {
+	baseSuite
+}
+
+func (s *poolValidationResultSuite) Test_SavePoolValidationResult() {
+	s.Mock.ExpectBegin()
+	s.Mock.ExpectQuery(`INSERT INTO "pool_validation_result" \("chain_id","height","status","mismatch_count","mismatches"\) VALUES \(\$1,\$2,\$3,\$4,\$5\) ON CONFLICT \("chain_id","height"\) DO UPDATE SET "checked_at"=EXTRACT\(EPOCH FROM NOW\(\)\),(.+) RETURNING "id"`).
+		WithArgs(s.Repo.chainId, uint64(100), dex.PoolValidationFailed, 1,
+			[]byte(`[{"type":"total_share_mismatch","contract":"pair1","actual":"1","expected":"2"}]`),
+			sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	s.Mock.ExpectCommit()
+
+	s.NoError(s.Repo.SavePoolValidationResult(dex.PoolValidationResult{
+		Height:     100,
+		Status:     dex.PoolValidationFailed,
+		Mismatches: []dex.PoolValidationMismatch{{Type: "total_share_mismatch", Contract: "pair1", Actual: "1", Expected: "2"}},
+	}))
+	s.NoError(s.Mock.ExpectationsWereMet())
+}
+
+func (s *poolValidationResultSuite) Test_FirstFailedPoolValidation() {
+	s.Mock.ExpectQuery(`SELECT \* FROM "pool_validation_result" WHERE chain_id = \$1 AND status = \$2 ORDER BY height ASC LIMIT \$3`).
+		WithArgs(s.Repo.chainId, dex.PoolValidationFailed, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"height", "status", "mismatches"}).
+			AddRow(200, dex.PoolValidationFailed, []byte(`[{"type":"unexpected_pool","contract":"pair1","actual":"5","expected":""}]`)))
+
+	result, err := s.Repo.FirstFailedPoolValidation()
+	s.NoError(err)
+	s.Equal(&dex.PoolValidationResult{
+		Height:     200,
+		Status:     dex.PoolValidationFailed,
+		Mismatches: []dex.PoolValidationMismatch{{Type: "unexpected_pool", Contract: "pair1", Actual: "5"}},
+	}, result)
+
+	s.Mock.ExpectQuery(`SELECT \* FROM "pool_validation_result" WHERE (.+)`).
+		WillReturnRows(sqlmock.NewRows([]string{"height"}))
+	result, err = s.Repo.FirstFailedPoolValidation()
+	s.NoError(err)
+	s.Nil(result)
+	s.NoError(s.Mock.ExpectationsWereMet())
+}
+
+func (s *poolValidationResultSuite) Test_PoolValidationResults() {
+	s.Mock.ExpectQuery(`SELECT \* FROM "pool_validation_result" WHERE \(chain_id = \$1 AND height >= \$2 AND height <= \$3\) AND status = \$4 ORDER BY height ASC`).
+		WithArgs(s.Repo.chainId, uint64(100), uint64(300), dex.PoolValidationPassed).
+		WillReturnRows(sqlmock.NewRows([]string{"height", "status", "mismatches"}).
+			AddRow(100, dex.PoolValidationPassed, []byte(`[]`)).
+			AddRow(300, dex.PoolValidationPassed, []byte(`[]`)))
+
+	results, err := s.Repo.PoolValidationResults(100, 300, dex.PoolValidationPassed)
+	s.NoError(err)
+	s.Len(results, 2)
+	s.Equal(uint64(300), results[1].Height)
+	s.NoError(s.Mock.ExpectationsWereMet())
+}
+
+type rewindSuite struct {
+	baseSuite
+}
+
+func (s *rewindSuite) expectRewindDeletes() {
+	s.Mock.ExpectBegin()
+	s.Mock.ExpectQuery(`SELECT DISTINCT "contract" FROM "parsed_tx" WHERE (.+)`).
+		WithArgs(s.Repo.chainId, dex.CreatePair, uint64(10), uint64(20)).
+		WillReturnRows(sqlmock.NewRows([]string{"contract"}).AddRow("pair1"))
+	s.Mock.ExpectExec(`DELETE FROM "parsed_tx" WHERE (.+)`).
+		WithArgs(s.Repo.chainId, uint64(10), uint64(20)).WillReturnResult(sqlmock.NewResult(0, 3))
+	s.Mock.ExpectExec(`DELETE FROM "pool_info" WHERE (.+)`).
+		WithArgs(s.Repo.chainId, uint64(10), uint64(20)).WillReturnResult(sqlmock.NewResult(0, 2))
+	s.Mock.ExpectExec(`DELETE FROM "pair" WHERE (.+)`).
+		WithArgs(s.Repo.chainId, "pair1").WillReturnResult(sqlmock.NewResult(0, 1))
+	s.Mock.ExpectExec(`DELETE FROM "parse_quarantine" WHERE (.+)`).
+		WithArgs(s.Repo.chainId, uint64(10), uint64(20)).WillReturnResult(sqlmock.NewResult(0, 0))
+	s.Mock.ExpectExec(`DELETE FROM "pool_validation_result" WHERE (.+)`).
+		WithArgs(s.Repo.chainId, uint64(10), uint64(20)).WillReturnResult(sqlmock.NewResult(0, 1))
+	s.Mock.ExpectExec(`UPDATE "synced_height" SET "validation_height"=\$1 WHERE chain_id = \$2 AND \(validation_height IS NULL OR validation_height > \$3\)`).
+		WithArgs(uint64(12), s.Repo.chainId, uint64(12)).WillReturnResult(sqlmock.NewResult(0, 1))
+}
+
+func (s *rewindSuite) Test_Rewind() {
+	s.expectRewindDeletes()
+	s.Mock.ExpectExec(`UPDATE "synced_height" SET "height"=LEAST\(height, \$1\),"reparse_synced_height"=CASE WHEN reparse_synced_height >= \$2 THEN NULL ELSE reparse_synced_height END WHERE chain_id = \$3`).
+		WithArgs(uint64(9), uint64(10), s.Repo.chainId).WillReturnResult(sqlmock.NewResult(0, 1))
+	s.Mock.ExpectCommit()
+
+	counts, err := s.Repo.Rewind(10, 20, 4)
+	s.NoError(err)
+	s.Equal(RewindCounts{ParsedTxs: 3, PoolInfos: 2, Pairs: 1, PoolValidationResults: 1}, counts)
+	s.NoError(s.Mock.ExpectationsWereMet())
+}
+
+func (s *rewindSuite) Test_StartReparse_KeepsSyncedHeight() {
+	s.expectRewindDeletes()
+	s.Mock.ExpectExec(`UPDATE "synced_height" SET "height"=LEAST\(height, \$1\),"reparse_synced_height"=COALESCE\(reparse_synced_height, height\) WHERE chain_id = \$2`).
+		WithArgs(uint64(9), s.Repo.chainId).WillReturnResult(sqlmock.NewResult(0, 1))
+	s.Mock.ExpectCommit()
+
+	_, err := s.Repo.StartReparse(10, 20, 4)
+	s.NoError(err)
+	s.NoError(s.Mock.ExpectationsWereMet())
+}
+
+func (s *rewindSuite) Test_PendingReparseHeight() {
+	s.Mock.ExpectQuery(`SELECT \* FROM "synced_height" WHERE chain_id = \$1 LIMIT \$2`).
+		WithArgs(s.Repo.chainId, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"chain_id", "height", "reparse_synced_height"}).AddRow(s.Repo.chainId, 12, 20))
+	height, err := s.Repo.PendingReparseHeight()
+	s.NoError(err)
+	s.Equal(uint64(20), height)
+
+	s.Mock.ExpectQuery(`SELECT \* FROM "synced_height" WHERE chain_id = \$1 LIMIT \$2`).
+		WithArgs(s.Repo.chainId, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"chain_id", "height", "reparse_synced_height"}).AddRow(s.Repo.chainId, 12, nil))
+	height, err = s.Repo.PendingReparseHeight()
+	s.NoError(err)
+	s.Zero(height)
+	s.NoError(s.Mock.ExpectationsWereMet())
+}
+
+func (s *rewindSuite) Test_FinishReparse() {
+	s.Mock.ExpectBegin()
+	s.Mock.ExpectExec(`UPDATE "synced_height" SET "height"=GREATEST\(height, reparse_synced_height\),"reparse_synced_height"=\$1 WHERE chain_id = \$2 AND reparse_synced_height IS NOT NULL`).
+		WithArgs(nil, s.Repo.chainId).WillReturnResult(sqlmock.NewResult(0, 1))
+	s.Mock.ExpectCommit()
+
+	s.NoError(s.Repo.FinishReparse())
+	s.NoError(s.Mock.ExpectationsWereMet())
+}
+
+type compareSuite struct {
+	baseSuite
+}
+
+func (s *compareSuite) Test_CompareParsedTxs() {
+	s.Mock.ExpectQuery(`SELECT \* FROM "parsed_tx" WHERE (.+) ORDER BY id`).
+		WithArgs(s.Repo.chainId, uint64(10), "hash").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "chain_id", "height", "hash", "type", "contract", "asset0_amount", "asset1_amount", "lp_amount"}).
+			AddRow(1, s.Repo.chainId, 10, "hash", dex.Transfer, "token1", "5", "0", "0"))
+
+	comparison, err := s.Repo.CompareParsedTxs(10, "hash", nil, false)
+	s.NoError(err)
+	s.Len(comparison.Missing, 1)
+	s.Empty(comparison.PoolDeltas)
+	s.NoError(s.Mock.ExpectationsWereMet())
+}
+
+func (s *compareSuite) Test_StoredParsedTxs() {
+	s.Mock.ExpectQuery(`SELECT \* FROM "parsed_tx" WHERE (.+) ORDER BY id`).
+		WithArgs(s.Repo.chainId, uint64(10), "hash1", "hash2").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "chain_id", "height", "hash"}).
+			AddRow(1, s.Repo.chainId, 10, "hash2").
+			AddRow(2, s.Repo.chainId, 10, "hash1"))
+
+	rows, err := s.Repo.StoredParsedTxs(10, []string{"hash1", "hash2"})
+	s.NoError(err)
+	s.Len(rows, 2)
+	s.Equal("hash2", rows[0].Hash)
+
+	rows, err = s.Repo.StoredParsedTxs(10, nil)
+	s.NoError(err)
+	s.Empty(rows)
+	s.NoError(s.Mock.ExpectationsWereMet())
+}
+
+func (s *rewindSuite) Test_Rewind_RollsBackOnError() {
+	s.Mock.ExpectBegin()
+	s.Mock.ExpectQuery(`SELECT DISTINCT "contract" FROM "parsed_tx" WHERE (.+)`).
+		WillReturnRows(sqlmock.NewRows([]string{"contract"}))
+	s.Mock.ExpectExec(`DELETE FROM "parsed_tx" WHERE (.+)`).WillReturnError(errors.New("delete error"))
+	s.Mock.ExpectRollback()
+
+	_, err := s.Repo.Rewind(10, 20, 4)
+	s.Error(err)
+	s.NoError(s.Mock.ExpectationsWereMet())
+}
+
+func Test_diffRows(t *testing.T) {
+	assert := assert.New(t)
+	stored := []interface{}{
+		schemas.ParsedTx{Hash: "a", Asset0Amount: "1"},
+		schemas.ParsedTx{Hash: "b", Asset0Amount: "1", Meta: map[string]interface{}{}},
+		schemas.ParsedTx{Hash: "b", Asset0Amount: "1"},
+	}
+	reparsed := []interface{}{
+		schemas.ParsedTx{Hash: "a", Asset0Amount: "2"},
+		schemas.ParsedTx{Hash: "b", Asset0Amount: "1"},
+		schemas.ParsedTx{Hash: "b", Asset0Amount: "1"},
+	}
+
+	diffs, err := diffRows("parsed_tx", stored, reparsed)
+	assert.NoError(err)
+	assert.Equal([]RowDiff{
+		{Table: "parsed_tx", Change: RowRemoved, Row: stored[0]},
+		{Table: "parsed_tx", Change: RowAdded, Row: reparsed[0]},
+	}, diffs)
+}
+
+func Test_compareParsedTxs(t *testing.T) {
+	assert := assert.New(t)
+	stored := []schemas.ParsedTx{
+		{Id: 1, Type: dex.Swap, Contract: "pair1", Asset0Amount: "10", Asset1Amount: "-9", LpAmount: "0"},
+		{Id: 2, Type: dex.Transfer, Contract: "token1", Asset0Amount: "5", Asset1Amount: "0", LpAmount: "0"},
+	}
+	replayed := []schemas.ParsedTx{
+		{Type: dex.Swap, Contract: "pair1", Asset0Amount: "10", Asset1Amount: "-8", LpAmount: "0", Meta: map[string]interface{}{}},
+		{Type: dex.Provide, Contract: "pair1", Asset0Amount: "1", Asset1Amount: "1", LpAmount: "1"},
+	}
+
+	comparison, err := compareParsedTxs(stored, replayed)
+	assert.NoError(err)
+	assert.Equal([]schemas.ParsedTx{replayed[1]}, comparison.Added)
+	assert.Equal([]schemas.ParsedTx{stored[1]}, comparison.Missing)
+	assert.Equal([]ParsedTxChange{{
+		Type:     dex.Swap,
+		Contract: "pair1",
+		Fields:   []FieldChange{{Field: "asset1Amount", Stored: "-9", Replayed: "-8"}},
+	}}, comparison.Changed)
+	assert.False(comparison.Unchanged())
+
+	comparison, err = compareParsedTxs(stored, stored)
+	assert.NoError(err)
+	assert.True(comparison.Unchanged())
+}
+
+func Test_comparePoolDeltas(t *testing.T) {
+	assert := assert.New(t)
+	stored := []schemas.ParsedTx{
+		{Contract: "pair1", Asset0: "a", Asset0Amount: "10", Asset1: "b", Asset1Amount: "-9", LpAmount: "0"},
+		{Contract: "pair1", Asset0: "a", Asset0Amount: "1", Asset1: "b", Asset1Amount: "1", LpAmount: "1",
+			ExtraAssets: pq.StringArray{"c"}, ExtraAssetAmounts: pq.StringArray{"2"}},
+	}
+	replayed := []schemas.ParsedTx{
+		{Contract: "pair1", Asset0: "a", Asset0Amount: "10", Asset1: "b", Asset1Amount: "-9", LpAmount: "0"},
+	}
+
+	deltas, err := comparePoolDeltas(stored, replayed)
+	assert.NoError(err)
+	assert.Equal([]PoolDeltaComparison{{
+		Contract: "pair1",
+		Stored:   &PoolDelta{Assets: []dex.Asset{{Addr: "a", Amount: "11"}, {Addr: "b", Amount: "-8"}, {Addr: "c", Amount: "2"}}, LpAmount: "1"},
+		Replayed: &PoolDelta{Assets: []dex.Asset{{Addr: "a", Amount: "10"}, {Addr: "b", Amount: "-9"}}, LpAmount: "0"},
+		Changed:  true,
+	}}, deltas)
+
+	_, err = comparePoolDeltas([]schemas.ParsedTx{{Contract: "pair1", Asset0Amount: "x"}}, nil)
+	assert.Error(err)
+}
+
 func Test_repo(t *testing.T) {
 	dex.FakerCustomGenerator()
 	faker.CustomGenerator()
@@ -428,4 +740,35 @@ func Test_repo(t *testing.T) {
 	suite.Run(t, new(validationExceptionSuite))
 	suite.Run(t, new(validationHeightSuite))
 	suite.Run(t, new(parseQuarantineSuite))
+	suite.Run(t, new(poolValidationResultSuite))
+	suite.Run(t, new(rewindSuite))
+	suite.Run(t, new(compareSuite))
+}
+
+func Test_parserMapper_ExtraAssets(t *testing.T) {
+	assert := assert.New(t)
+	m := parserMapperImpl{}
+
+	tx := dex.ParsedTx{Type: dex.Swap, CommissionAmount: "3"}
+	tx.SetAssets([]dex.Asset{{Addr: "a", Amount: "10"}, {Addr: "b", Amount: ""}, {Addr: "c", Amount: "-9"}})
+	model := m.toParsedTxModel("chain", 1, tx)
+	assert.Equal("10", model.Asset0Amount)
+	assert.Equal("0", model.Asset1Amount)
+	assert.Equal(pq.StringArray{"c"}, model.ExtraAssets)
+	assert.Equal(pq.StringArray{"-9"}, model.ExtraAssetAmounts)
+	assert.Equal("0", model.Commission0Amount)
+	assert.Equal("0", model.Commission1Amount)
+	assert.Equal(pq.StringArray{"3"}, model.ExtraCommissionAmounts)
+
+	tx = dex.ParsedTx{Type: dex.Swap, CommissionAmount: "3", Assets: [2]dex.Asset{{Addr: "a", Amount: "10"}, {Addr: "b", Amount: "-9"}}}
+	model = m.toParsedTxModel("chain", 1, tx)
+	assert.Nil(model.ExtraAssets)
+	assert.Nil(model.ExtraCommissionAmounts)
+	assert.Equal("3", model.Commission1Amount)
+
+	pair := dex.Pair{ContractAddr: "pair", Assets: []string{"a", "b", "c"}, LpAddr: "lp"}
+	pairModel := m.toPairModel("chain", pair)
+	assert.Equal(pq.StringArray{"c"}, pairModel.ExtraAssets)
+	assert.Equal(pair, m.toPairDto(pairModel))
+	assert.Nil(m.toPairModel("chain", dex.Pair{Assets: []string{"a", "b"}}).ExtraAssets)
 }
@@ -0,0 +1,314 @@
+package repo
+
+import (
+	"encoding/json"
+
+	"github.com/dezswap/cosmwasm-etl/configs"
+	"github.com/dezswap/cosmwasm-etl/parser/dex"
+	"github.com/dezswap/cosmwasm-etl/pkg/db"
+	"github.com/dezswap/cosmwasm-etl/pkg/db/schemas"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// RowRemoved is a stored row the re-parsed output does not have.
+	RowRemoved = "removed"
+	// RowAdded is a re-parsed row that is not stored.
+	RowAdded = "added"
+)
+
+// RewindCounts are the parser rows of a height range deleted by a rewind.
+type RewindCounts struct {
+	ParsedTxs        int64 `json:"parsed_txs"`
+	PoolInfos        int64 `json:"pool_infos"`
+	Pairs            int64 `json:"pairs"`
+	ParseQuarantines int64 `json:"parse_quarantines"`
+	// PoolValidationResults are the validation results of the range, which
+	// are produced again once the validation cursor reaches them.
+	PoolValidationResults int64 `json:"pool_validation_results"`
+}
+
+// RowDiff is a row of a table that only the stored or only the re-parsed
+// output of a height range has. A changed row shows up as one of each.
+type RowDiff struct {
+	Table  string      `json:"table"`
+	Change string      `json:"change"`
+	Row    interface{} `json:"row"`
+}
+
+// Rewinder removes and compares the parser rows of a height range so it can
+// be parsed again.
+type Rewinder interface {
+	// PairsCreatedIn returns the pairs whose create_pair tx was parsed
+	// within [from, to].
+	PairsCreatedIn(from, to uint64) ([]string, error)
+	// Diff compares the stored parsed_tx, pool_info and pair rows of
+	// [from, to] with blocks parsed again over the same range.
+	Diff(from, to uint64, blocks []dex.ParsedBlock) ([]RowDiff, error)
+	// Rewind deletes the parsed_tx, pool_info, pair, parse_quarantine and
+	// pool_validation_result rows of [from, to], moves the synced height back
+	// to from-1 and the validation cursor back to the first validation height
+	// at or above from in one transaction. A pending reparse that reaches from
+	// is dropped, since the heights it would restore are gone.
+	Rewind(from, to uint64, validationInterval uint) (RewindCounts, error)
+	// StartReparse rewinds [from, to] like Rewind and keeps the synced height
+	// from before the first rewind of a reparse until FinishReparse.
+	StartReparse(from, to uint64, validationInterval uint) (RewindCounts, error)
+	// PendingReparseHeight returns the synced height kept by an unfinished
+	// reparse, or 0 when no reparse is pending.
+	PendingReparseHeight() (uint64, error)
+	// FinishReparse restores the synced height kept by StartReparse.
+	FinishReparse() error
+}
+
+var _ Rewinder = (*repoImpl)(nil)
+
+func NewRewinder(chainId string, dbConfig configs.RdbConfig) Rewinder {
+	gormDB, err := db.OpenGormPostgres(dbConfig)
+	if err != nil {
+		panic(err)
+	}
+
+	return NewRewinderWithDB(chainId, gormDB)
+}
+
+func NewRewinderWithDB(chainId string, db *gorm.DB) Rewinder {
+	return &repoImpl{mapper: &parserMapperImpl{}, db: db, chainId: chainId}
+}
+
+// PairsCreatedIn implements Rewinder
+func (r *repoImpl) PairsCreatedIn(from, to uint64) ([]string, error) {
+	return r.pairsCreatedIn(r.db, from, to)
+}
+
+func (r *repoImpl) pairsCreatedIn(tx *gorm.DB, from, to uint64) ([]string, error) {
+	contracts := []string{}
+	if err := tx.Model(&schemas.ParsedTx{}).
+		Where("chain_id = ? AND type = ? AND height >= ? AND height <= ?", r.chainId, dex.CreatePair, from, to).
+		Distinct().Order("contract").Pluck("contract", &contracts).Error; err != nil {
+		return nil, errors.Wrap(err, "repo.PairsCreatedIn")
+	}
+	return contracts, nil
+}
+
+// Diff implements Rewinder
+func (r *repoImpl) Diff(from, to uint64, blocks []dex.ParsedBlock) ([]RowDiff, error) {
+	storedTxs := []schemas.ParsedTx{}
+	if err := r.db.Where("chain_id = ? AND height >= ? AND height <= ?", r.chainId, from, to).
+		Order("height, id").Find(&storedTxs).Error; err != nil {
+		return nil, errors.Wrap(err, "repo.Diff.ParsedTx")
+	}
+	storedPools := []schemas.PoolInfo{}
+	if err := r.db.Where("chain_id = ? AND height >= ? AND height <= ?", r.chainId, from, to).
+		Order("height, contract").Find(&storedPools).Error; err != nil {
+		return nil, errors.Wrap(err, "repo.Diff.PoolInfo")
+	}
+	createdPairs, err := r.PairsCreatedIn(from, to)
+	if err != nil {
+		return nil, errors.Wrap(err, "repo.Diff")
+	}
+	storedPairs := []schemas.Pair{}
+	if len(createdPairs) > 0 {
+		if err := r.db.Where("chain_id = ? AND contract IN ?", r.chainId, createdPairs).
+			Order("contract").Find(&storedPairs).Error; err != nil {
+			return nil, errors.Wrap(err, "repo.Diff.Pair")
+		}
+	}
+
+	stored := map[string][]interface{}{}
+	for _, tx := range storedTxs {
+		tx.Id = 0
+		stored[schemas.ParsedTx{}.TableName()] = append(stored[schemas.ParsedTx{}.TableName()], tx)
+	}
+	for _, pool := range storedPools {
+		stored[schemas.PoolInfo{}.TableName()] = append(stored[schemas.PoolInfo{}.TableName()], pool)
+	}
+	for _, pair := range storedPairs {
+		stored[schemas.Pair{}.TableName()] = append(stored[schemas.Pair{}.TableName()], pair)
+	}
+
+	reparsed := map[string][]interface{}{}
+	for _, block := range blocks {
+		for _, tx := range block.Txs {
+			reparsed[schemas.ParsedTx{}.TableName()] = append(reparsed[schemas.ParsedTx{}.TableName()], r.toParsedTxModel(r.chainId, block.Height, tx))
+		}
+		for _, pool := range block.Pools {
+			reparsed[schemas.PoolInfo{}.TableName()] = append(reparsed[schemas.PoolInfo{}.TableName()], r.toPoolInfoModel(r.chainId, block.Height, pool))
+		}
+		for _, pair := range block.Pairs {
+			reparsed[schemas.Pair{}.TableName()] = append(reparsed[schemas.Pair{}.TableName()], r.toPairModel(r.chainId, pair))
+		}
+	}
+
+	diffs := []RowDiff{}
+	for _, table := range []string{schemas.ParsedTx{}.TableName(), schemas.PoolInfo{}.TableName(), schemas.Pair{}.TableName()} {
+		tableDiffs, err := diffRows(table, stored[table], reparsed[table])
+		if err != nil {
+			return nil, errors.Wrap(err, "repo.Diff")
+		}
+		diffs = append(diffs, tableDiffs...)
+	}
+	return diffs, nil
+}
+
+// diffRows compares rows as multisets of their normalized JSON encoding.
+func diffRows(table string, stored, reparsed []interface{}) ([]RowDiff, error) {
+	keyOf := func(row interface{}) (string, error) {
+		normalized, err := normalizedRow(row)
+		if err != nil {
+			return "", err
+		}
+		bytes, err := json.Marshal(normalized)
+		return string(bytes), err
+	}
+
+	reparsedKeys := make([]string, len(reparsed))
+	remaining := map[string]int{}
+	for idx, row := range reparsed {
+		key, err := keyOf(row)
+		if err != nil {
+			return nil, err
+		}
+		reparsedKeys[idx] = key
+		remaining[key]++
+	}
+
+	diffs := []RowDiff{}
+	for _, row := range stored {
+		key, err := keyOf(row)
+		if err != nil {
+			return nil, err
+		}
+		if remaining[key] > 0 {
+			remaining[key]--
+			continue
+		}
+		diffs = append(diffs, RowDiff{Table: table, Change: RowRemoved, Row: row})
+	}
+	for idx, row := range reparsed {
+		if remaining[reparsedKeys[idx]] > 0 {
+			remaining[reparsedKeys[idx]]--
+			diffs = append(diffs, RowDiff{Table: table, Change: RowAdded, Row: row})
+		}
+	}
+	return diffs, nil
+}
+
+// Rewind implements Rewinder
+func (r *repoImpl) Rewind(from, to uint64, validationInterval uint) (RewindCounts, error) {
+	return r.rewind(from, to, validationInterval,
+		gorm.Expr("CASE WHEN reparse_synced_height >= ? THEN NULL ELSE reparse_synced_height END", from))
+}
+
+// StartReparse implements Rewinder
+func (r *repoImpl) StartReparse(from, to uint64, validationInterval uint) (RewindCounts, error) {
+	return r.rewind(from, to, validationInterval, gorm.Expr("COALESCE(reparse_synced_height, height)"))
+}
+
+// rewind deletes the rows of [from, to] and sets reparse_synced_height to
+// reparseSyncedHeight, which is evaluated against the row before the rewind.
+func (r *repoImpl) rewind(from, to uint64, validationInterval uint, reparseSyncedHeight clause.Expr) (RewindCounts, error) {
+	counts := RewindCounts{}
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		createdPairs, err := r.pairsCreatedIn(tx, from, to)
+		if err != nil {
+			return err
+		}
+
+		parsedTxs := tx.Where("chain_id = ? AND height >= ? AND height <= ?", r.chainId, from, to).Delete(&schemas.ParsedTx{})
+		if parsedTxs.Error != nil {
+			return errors.Wrap(parsedTxs.Error, "repo.Rewind.ParsedTx")
+		}
+		poolInfos := tx.Where("chain_id = ? AND height >= ? AND height <= ?", r.chainId, from, to).Delete(&schemas.PoolInfo{})
+		if poolInfos.Error != nil {
+			return errors.Wrap(poolInfos.Error, "repo.Rewind.PoolInfo")
+		}
+		if len(createdPairs) > 0 {
+			pairs := tx.Where("chain_id = ? AND contract IN ?", r.chainId, createdPairs).Delete(&schemas.Pair{})
+			if pairs.Error != nil {
+				return errors.Wrap(pairs.Error, "repo.Rewind.Pair")
+			}
+			counts.Pairs = pairs.RowsAffected
+		}
+		quarantines := tx.Where("chain_id = ? AND height >= ? AND height <= ?", r.chainId, from, to).Delete(&schemas.ParseQuarantine{})
+		if quarantines.Error != nil {
+			return errors.Wrap(quarantines.Error, "repo.Rewind.ParseQuarantine")
+		}
+		validationResults := tx.Where("chain_id = ? AND height >= ? AND height <= ?", r.chainId, from, to).Delete(&schemas.PoolValidationResult{})
+		if validationResults.Error != nil {
+			return errors.Wrap(validationResults.Error, "repo.Rewind.PoolValidationResult")
+		}
+		if validationInterval > 0 {
+			next := firstValidationHeight(from, validationInterval)
+			if err := tx.Model(&schemas.SyncedHeight{}).
+				Where("chain_id = ? AND (validation_height IS NULL OR validation_height > ?)", r.chainId, next).
+				Update("validation_height", next).Error; err != nil {
+				return errors.Wrap(err, "repo.Rewind.ValidationHeight")
+			}
+		}
+		if err := tx.Model(&schemas.SyncedHeight{}).Where("chain_id = ?", r.chainId).Updates(map[string]interface{}{
+			"height":                gorm.Expr("LEAST(height, ?)", from-1),
+			"reparse_synced_height": reparseSyncedHeight,
+		}).Error; err != nil {
+			return errors.Wrap(err, "repo.Rewind.SyncedHeight")
+		}
+
+		counts.ParsedTxs = parsedTxs.RowsAffected
+		counts.PoolInfos = poolInfos.RowsAffected
+		counts.ParseQuarantines = quarantines.RowsAffected
+		counts.PoolValidationResults = validationResults.RowsAffected
+		return nil
+	})
+	return counts, err
+}
+
+// firstValidationHeight is the first multiple of validationInterval at or
+// above height.
+func firstValidationHeight(height uint64, validationInterval uint) uint64 {
+	interval := uint64(validationInterval)
+	return (height + interval - 1) / interval * interval
+}
+
+// PendingReparseHeight implements Rewinder
+func (r *repoImpl) PendingReparseHeight() (uint64, error) {
+	syncedHeight := schemas.SyncedHeight{}
+	if err := r.db.Where("chain_id = ?", r.chainId).Limit(1).Find(&syncedHeight).Error; err != nil {
+		return 0, errors.Wrap(err, "repo.PendingReparseHeight")
+	}
+	if syncedHeight.ReparseSyncedHeight == nil {
+		return 0, nil
+	}
+	return *syncedHeight.ReparseSyncedHeight, nil
+}
+
+// FinishReparse implements Rewinder
+func (r *repoImpl) FinishReparse() error {
+	if err := r.db.Model(&schemas.SyncedHeight{}).
+		Where("chain_id = ? AND reparse_synced_height IS NOT NULL", r.chainId).
+		Updates(map[string]interface{}{
+			"height":                gorm.Expr("GREATEST(height, reparse_synced_height)"),
+			"reparse_synced_height": nil,
+		}).Error; err != nil {
+		return errors.Wrap(err, "repo.FinishReparse")
+	}
+	return nil
+}
+
+// normalizedRow decodes the JSON encoding of row, which evens out the number
+// types of decoded meta, and treats empty meta as none.
+func normalizedRow(row interface{}) (map[string]interface{}, error) {
+	bytes, err := json.Marshal(row)
+	if err != nil {
+		return nil, err
+	}
+	var normalized map[string]interface{}
+	if err := json.Unmarshal(bytes, &normalized); err != nil {
+		return nil, err
+	}
+	if meta, ok := normalized["meta"].(map[string]interface{}); ok && len(meta) == 0 {
+		normalized["meta"] = nil
+	}
+	return normalized, nil
+}
@@ -0,0 +1,134 @@
+package repo
+
+import (
+	"encoding/json"
+
+	"github.com/dezswap/cosmwasm-etl/configs"
+	"github.com/dezswap/cosmwasm-etl/parser/dex"
+	"github.com/dezswap/cosmwasm-etl/pkg/db"
+	"github.com/dezswap/cosmwasm-etl/pkg/db/schemas"
+	"github.com/pkg/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PoolValidationResultRepo queries the recorded pool validation results.
+type PoolValidationResultRepo interface {
+	// PoolValidationResults lists the results of [from, to] by height. A
+	// non-empty status only lists results of that status.
+	PoolValidationResults(from, to uint64, status string) ([]dex.PoolValidationResult, error)
+	// FirstFailedPoolValidation returns the lowest validation height whose
+	// latest result failed, nil when there is none.
+	FirstFailedPoolValidation() (*dex.PoolValidationResult, error)
+	// LatestPoolValidation returns the result of the highest validation
+	// height, nil when nothing was validated yet.
+	LatestPoolValidation() (*dex.PoolValidationResult, error)
+}
+
+var _ PoolValidationResultRepo = (*repoImpl)(nil)
+
+func NewPoolValidationResultRepo(chainId string, dbConfig configs.RdbConfig) PoolValidationResultRepo {
+	gormDB, err := db.OpenGormPostgres(dbConfig)
+	if err != nil {
+		panic(err)
+	}
+
+	return NewPoolValidationResultRepoWithDB(chainId, gormDB)
+}
+
+func NewPoolValidationResultRepoWithDB(chainId string, db *gorm.DB) PoolValidationResultRepo {
+	return &repoImpl{mapper: &parserMapperImpl{}, db: db, chainId: chainId}
+}
+
+// SavePoolValidationResult implements dex.Repo.
+func (r *repoImpl) SavePoolValidationResult(result dex.PoolValidationResult) error {
+	mismatches := result.Mismatches
+	if mismatches == nil {
+		mismatches = []dex.PoolValidationMismatch{}
+	}
+	mismatchesJSON, err := json.Marshal(mismatches)
+	if err != nil {
+		return errors.Wrap(err, "repo.SavePoolValidationResult")
+	}
+
+	row := schemas.PoolValidationResult{
+		ChainId:       r.chainId,
+		Height:        result.Height,
+		Status:        result.Status,
+		MismatchCount: len(mismatches),
+		Mismatches:    schemas.JSON(mismatchesJSON),
+	}
+	if err := r.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "chain_id"}, {Name: "height"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"status":         row.Status,
+			"mismatch_count": row.MismatchCount,
+			"mismatches":     row.Mismatches,
+			"checked_at":     gorm.Expr("EXTRACT(EPOCH FROM NOW())"),
+		}),
+	}).Omit("Id").Create(&row).Error; err != nil {
+		return errors.Wrap(err, "repo.SavePoolValidationResult")
+	}
+	return nil
+}
+
+// PoolValidationResults implements PoolValidationResultRepo
+func (r *repoImpl) PoolValidationResults(from, to uint64, status string) ([]dex.PoolValidationResult, error) {
+	query := r.db.Where("chain_id = ? AND height >= ? AND height <= ?", r.chainId, from, to)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	rows := []schemas.PoolValidationResult{}
+	if err := query.Order("height ASC").Find(&rows).Error; err != nil {
+		return nil, errors.Wrap(err, "repo.PoolValidationResults")
+	}
+
+	results := make([]dex.PoolValidationResult, 0, len(rows))
+	for _, row := range rows {
+		result, err := toPoolValidationResultDto(row)
+		if err != nil {
+			return nil, errors.Wrap(err, "repo.PoolValidationResults")
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// FirstFailedPoolValidation implements PoolValidationResultRepo
+func (r *repoImpl) FirstFailedPoolValidation() (*dex.PoolValidationResult, error) {
+	query := r.db.Where("chain_id = ? AND status = ?", r.chainId, dex.PoolValidationFailed).Order("height ASC")
+	result, err := r.firstPoolValidation(query)
+	return result, errors.Wrap(err, "repo.FirstFailedPoolValidation")
+}
+
+// LatestPoolValidation implements PoolValidationResultRepo
+func (r *repoImpl) LatestPoolValidation() (*dex.PoolValidationResult, error) {
+	query := r.db.Where("chain_id = ?", r.chainId).Order("height DESC")
+	result, err := r.firstPoolValidation(query)
+	return result, errors.Wrap(err, "repo.LatestPoolValidation")
+}
+
+func (r *repoImpl) firstPoolValidation(query *gorm.DB) (*dex.PoolValidationResult, error) {
+	rows := []schemas.PoolValidationResult{}
+	if err := query.Limit(1).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	result, err := toPoolValidationResultDto(rows[0])
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func toPoolValidationResultDto(row schemas.PoolValidationResult) (dex.PoolValidationResult, error) {
+	mismatches := []dex.PoolValidationMismatch{}
+	if len(row.Mismatches) > 0 {
+		if err := json.Unmarshal(row.Mismatches, &mismatches); err != nil {
+			return dex.PoolValidationResult{}, errors.Wrapf(err, "pool validation result of height %d", row.Height)
+		}
+	}
+	return dex.PoolValidationResult{Height: row.Height, Status: row.Status, Mismatches: mismatches}, nil
+}
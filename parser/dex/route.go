@@ -0,0 +1,58 @@
+package dex
+
+import (
+	"fmt"
+
+	"github.com/dezswap/cosmwasm-etl/parser"
+)
+
+const (
+	// RouteIdMetaKey groups the swap hops of one router execute_swap_operations
+	// message. Its value is "<hash>-<msg index>".
+	RouteIdMetaKey = "route_id"
+	// RouteHopMetaKey is the 0-based position of a hop in its route. Hops
+	// after the first are intermediate and carry no new user volume.
+	RouteHopMetaKey = "route_hop"
+)
+
+// routerContractKeys are the event keys wasmd reports the executed contract
+// under, _contract_address since wasmd 0.16 and contract_address before.
+var routerContractKeys = map[string]bool{"_contract_address": true, "contract_address": true}
+
+// AttributeRoutes groups the swaps of txs that were executed through the
+// router by their message, setting the route id and hop of each in Meta. txs
+// are those parsed from rawTx and keep their order. Nothing is changed when
+// routerAddr is empty or the router is not part of rawTx.
+func AttributeRoutes(routerAddr string, rawTx parser.RawTx, txs []ParsedTx) []ParsedTx {
+	if routerAddr == "" {
+		return txs
+	}
+
+	routerMsgs := map[int]bool{}
+	for _, log := range rawTx.LogResults {
+		for _, attr := range log.Attributes {
+			if routerContractKeys[attr.Key] && attr.Value == routerAddr {
+				routerMsgs[attr.MsgIndex] = true
+			}
+		}
+	}
+	if len(routerMsgs) == 0 {
+		return txs
+	}
+
+	hops := map[int]int{}
+	for idx, tx := range txs {
+		if tx.Type != Swap || !routerMsgs[tx.MsgIndex] {
+			continue
+		}
+		meta := make(map[string]interface{}, len(tx.Meta)+2)
+		for k, v := range tx.Meta {
+			meta[k] = v
+		}
+		meta[RouteIdMetaKey] = fmt.Sprintf("%s-%d", rawTx.Hash, tx.MsgIndex)
+		meta[RouteHopMetaKey] = hops[tx.MsgIndex]
+		hops[tx.MsgIndex]++
+		txs[idx].Meta = meta
+	}
+	return txs
+}
@@ -0,0 +1,42 @@
+package dex
+
+import (
+	"testing"
+
+	"github.com/dezswap/cosmwasm-etl/parser"
+	"github.com/dezswap/cosmwasm-etl/pkg/eventlog"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_AttributeRoutes(t *testing.T) {
+	rawTx := parser.RawTx{
+		Hash: "hash",
+		LogResults: eventlog.LogResults{{
+			Type: eventlog.WasmType,
+			Attributes: eventlog.Attributes{
+				{Key: "_contract_address", Value: "router", MsgIndex: 1},
+				{Key: "_contract_address", Value: "pair0", MsgIndex: 1},
+				{Key: "_contract_address", Value: "pair1", MsgIndex: 1},
+				{Key: "_contract_address", Value: "pair0", MsgIndex: 2},
+			},
+		}},
+	}
+	newTxs := func() []ParsedTx {
+		return []ParsedTx{
+			{Type: Transfer, MsgIndex: 1},
+			{Type: Swap, ContractAddr: "pair0", MsgIndex: 1, Meta: map[string]interface{}{"k": "v"}},
+			{Type: Swap, ContractAddr: "pair1", MsgIndex: 1},
+			{Type: Swap, ContractAddr: "pair0", MsgIndex: 2},
+		}
+	}
+	assert := assert.New(t)
+
+	txs := AttributeRoutes("router", rawTx, newTxs())
+	assert.Nil(txs[0].Meta)
+	assert.Equal(map[string]interface{}{"k": "v", RouteIdMetaKey: "hash-1", RouteHopMetaKey: 0}, txs[1].Meta)
+	assert.Equal(map[string]interface{}{RouteIdMetaKey: "hash-1", RouteHopMetaKey: 1}, txs[2].Meta)
+	assert.Nil(txs[3].Meta)
+
+	assert.Equal(newTxs(), AttributeRoutes("", rawTx, newTxs()))
+	assert.Equal(newTxs(), AttributeRoutes("other", rawTx, newTxs()))
+}
@@ -20,6 +20,7 @@ type collectorFallbackStore struct {
 }
 
 var _ dex.SourceDataStore = (*collectorFallbackStore)(nil)
+var _ dex.BlockHeaderSource = (*collectorFallbackStore)(nil)
 
 // NewCollectorFallback reads parser source data from collector DB first and
 // delegates to fallback when collector data is not available yet. Corrupt data
@@ -69,6 +70,19 @@ func (s *collectorFallbackStore) GetPoolInfos(height uint64) ([]dex.PoolInfo, er
 	return nil, err
 }
 
+func (s *collectorFallbackStore) GetBlockHeader(height uint64) (dex.BlockHeader, error) {
+	header, err := s.repo.GetBlockHeader(s.chainID, height)
+	if err == nil {
+		return header, nil
+	}
+	headers, ok := s.fallback.(dex.BlockHeaderSource)
+	if ok && shouldFallbackCollector(err) {
+		s.logCollectorFallback("block header", err)
+		return headers.GetBlockHeader(height)
+	}
+	return dex.BlockHeader{}, err
+}
+
 func shouldFallbackCollector(err error) bool {
 	return errors.Is(err, collectorrepo.ErrNotFound) || errors.Is(err, collectorrepo.ErrUnavailable)
 }
@@ -19,6 +19,8 @@ type fakeCollectorRepo struct {
 	txsErr      error
 	poolInfos   []dex.PoolInfo
 	poolInfoErr error
+	header      dex.BlockHeader
+	headerErr   error
 }
 
 func (f *fakeCollectorRepo) GetSyncedHeight(string) (uint64, error) {
@@ -33,7 +35,31 @@ func (f *fakeCollectorRepo) GetPoolInfos(string, uint64) ([]dex.PoolInfo, error)
 	return f.poolInfos, f.poolInfoErr
 }
 
-func (f *fakeCollectorRepo) SaveHeight(string, uint64, time.Time, parser.RawTxs, []dex.PoolInfo, bool) error {
+func (f *fakeCollectorRepo) GetBlockHeader(string, uint64) (dex.BlockHeader, error) {
+	return f.header, f.headerErr
+}
+
+func (f *fakeCollectorRepo) SaveHeight(string, uint64, time.Time, dex.BlockHeader, parser.RawTxs, uint, []dex.PoolInfo, bool) error {
+	return nil
+}
+
+func (f *fakeCollectorRepo) GetBlockHeights(string, uint64, uint64) ([]uint64, error) {
+	return nil, nil
+}
+
+func (f *fakeCollectorRepo) GetPoolSnapshotHeights(string, uint64, uint64) ([]uint64, error) {
+	return nil, nil
+}
+
+func (f *fakeCollectorRepo) GetFilterMismatchHeights(string, uint64, uint64, uint) ([]uint64, error) {
+	return nil, nil
+}
+
+func (f *fakeCollectorRepo) SavePoolSnapshot(string, uint64, []dex.PoolInfo) error {
+	return nil
+}
+
+func (f *fakeCollectorRepo) Rollback(string, uint64) error {
 	return nil
 }
 
@@ -178,3 +204,24 @@ func TestCollectorFallbackStore_GetPoolInfosDoesNotFallbackOnHardError(t *testin
 	require.ErrorIs(t, err, hardErr)
 	require.False(t, fallback.called)
 }
+
+func TestCollectorFallbackStore_GetBlockHeaderUsesCollectorDB(t *testing.T) {
+	repo := &fakeCollectorRepo{header: dex.BlockHeader{Height: 10, Hash: "hash", ParentHash: "parent"}}
+	fallback := &fakeCollectorFallback{}
+	store := NewCollectorFallback("chain", repo, fallback, logging.Discard).(dex.BlockHeaderSource)
+
+	actual, err := store.GetBlockHeader(10)
+
+	require.NoError(t, err)
+	require.Equal(t, repo.header, actual)
+	require.False(t, fallback.called)
+}
+
+func TestCollectorFallbackStore_GetBlockHeaderReturnsNotFoundWithoutHeaderFallback(t *testing.T) {
+	repo := &fakeCollectorRepo{headerErr: collectorrepo.ErrNotFound}
+	store := NewCollectorFallback("chain", repo, &fakeCollectorFallback{}, logging.Discard).(dex.BlockHeaderSource)
+
+	_, err := store.GetBlockHeader(10)
+
+	require.ErrorIs(t, err, collectorrepo.ErrNotFound)
+}
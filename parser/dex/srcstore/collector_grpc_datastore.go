@@ -0,0 +1,119 @@
+package srcstore
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	collectorrepo "github.com/dezswap/cosmwasm-etl/collector/repo"
+	"github.com/dezswap/cosmwasm-etl/parser"
+	"github.com/dezswap/cosmwasm-etl/parser/dex"
+	"github.com/dezswap/cosmwasm-etl/pkg/grpc/collectorservice/generated/collectorservice"
+)
+
+// CollectorGrpcStore reads parser source data from a remote collector gRPC API.
+type CollectorGrpcStore struct {
+	chainID string
+	client  collectorservice.CollectorClient
+	pools   dex.SourceDataStore
+}
+
+var _ dex.SourceDataStore = (*CollectorGrpcStore)(nil)
+var _ dex.BlockHeaderSource = (*CollectorGrpcStore)(nil)
+var _ dex.StreamSource = (*CollectorGrpcStore)(nil)
+
+// NewCollectorGrpc returns a store that maps missing heights and tables on the
+// remote collector to collectorrepo.ErrNotFound and collectorrepo.ErrUnavailable.
+// GetPoolInfos of a height without a collected snapshot is read from pools,
+// which queries the node; a nil pools returns the collector error instead.
+func NewCollectorGrpc(chainID string, conn grpc.ClientConnInterface, pools dex.SourceDataStore) *CollectorGrpcStore {
+	return &CollectorGrpcStore{chainID: chainID, client: collectorservice.NewCollectorClient(conn), pools: pools}
+}
+
+func (s *CollectorGrpcStore) GetSourceSyncedHeight() (uint64, error) {
+	res, err := s.client.GetSyncedHeight(context.Background(), &collectorservice.GetSyncedHeightRequest{ChainId: s.chainID})
+	if err != nil {
+		return 0, fromStatus(err, "collector grpc synced height")
+	}
+	return res.GetHeight(), nil
+}
+
+func (s *CollectorGrpcStore) GetSourceTxs(height uint64) (parser.RawTxs, error) {
+	block, err := s.client.GetBlockTxs(context.Background(), &collectorservice.GetBlockTxsRequest{ChainId: s.chainID, Height: height})
+	if err != nil {
+		return nil, fromStatus(err, "collector grpc block txs")
+	}
+	return decodeBlockTxs(block)
+}
+
+func (s *CollectorGrpcStore) GetPoolInfos(height uint64) ([]dex.PoolInfo, error) {
+	res, err := s.client.GetPoolInfos(context.Background(), &collectorservice.GetPoolInfosRequest{ChainId: s.chainID, Height: height})
+	if err != nil {
+		err = fromStatus(err, "collector grpc pool infos")
+		if s.pools != nil && shouldFallbackCollector(err) {
+			return s.pools.GetPoolInfos(height)
+		}
+		return nil, err
+	}
+	poolInfos := []dex.PoolInfo{}
+	if err := json.Unmarshal(res.GetPoolInfos(), &poolInfos); err != nil {
+		return nil, errors.Wrap(err, "collector grpc unmarshal pool infos")
+	}
+	return poolInfos, nil
+}
+
+func (s *CollectorGrpcStore) GetBlockHeader(height uint64) (dex.BlockHeader, error) {
+	header, err := s.client.GetBlockHeader(context.Background(), &collectorservice.GetBlockHeaderRequest{ChainId: s.chainID, Height: height})
+	if err != nil {
+		return dex.BlockHeader{}, fromStatus(err, "collector grpc block header")
+	}
+	return dex.BlockHeader{Height: header.GetHeight(), Hash: header.GetBlockHash(), ParentHash: header.GetParentHash()}, nil
+}
+
+// StreamSourceTxs calls handle for each collected height of [from, to] in
+// order. The stream ends early at the first height the collector lacks.
+func (s *CollectorGrpcStore) StreamSourceTxs(ctx context.Context, from, to uint64, handle func(height uint64, txs parser.RawTxs) error) error {
+	stream, err := s.client.StreamBlocks(ctx, &collectorservice.StreamBlocksRequest{ChainId: s.chainID, FromHeight: from, ToHeight: to})
+	if err != nil {
+		return fromStatus(err, "collector grpc stream blocks")
+	}
+	for {
+		block, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return fromStatus(err, "collector grpc stream blocks")
+		}
+		txs, err := decodeBlockTxs(block)
+		if err != nil {
+			return err
+		}
+		if err := handle(block.GetHeight(), txs); err != nil {
+			return err
+		}
+	}
+}
+
+func decodeBlockTxs(block *collectorservice.Block) (parser.RawTxs, error) {
+	txs := parser.RawTxs{}
+	if err := json.Unmarshal(block.GetTxs(), &txs); err != nil {
+		return nil, errors.Wrap(err, "collector grpc unmarshal block txs")
+	}
+	return txs, nil
+}
+
+func fromStatus(err error, msg string) error {
+	switch status.Code(err) {
+	case codes.NotFound:
+		return errors.Wrap(collectorrepo.ErrNotFound, msg)
+	case codes.Unavailable:
+		return errors.Wrapf(collectorrepo.ErrUnavailable, "%s: %s", msg, status.Convert(err).Message())
+	default:
+		return errors.Wrap(err, msg)
+	}
+}
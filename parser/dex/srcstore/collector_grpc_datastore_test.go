@@ -0,0 +1,122 @@
+package srcstore
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+
+	collectorrepo "github.com/dezswap/cosmwasm-etl/collector/repo"
+	"github.com/dezswap/cosmwasm-etl/parser"
+	"github.com/dezswap/cosmwasm-etl/parser/dex"
+	"github.com/dezswap/cosmwasm-etl/pkg/grpc/collectorservice"
+)
+
+type heightCollectorRepo struct {
+	fakeCollectorRepo
+	blocks map[uint64]parser.RawTxs
+}
+
+func (r *heightCollectorRepo) GetBlockTxs(_ string, height uint64) (parser.RawTxs, time.Time, error) {
+	txs, ok := r.blocks[height]
+	if !ok {
+		return nil, time.Time{}, collectorrepo.ErrNotFound
+	}
+	return txs, time.Unix(int64(height), 0), nil
+}
+
+func (r *heightCollectorRepo) GetBlockHeader(_ string, height uint64) (dex.BlockHeader, error) {
+	return dex.BlockHeader{Height: height, Hash: "hash", ParentHash: "parent"}, nil
+}
+
+func newCollectorGrpcStore(t *testing.T, repo collectorrepo.Repository, pools dex.SourceDataStore) *CollectorGrpcStore {
+	lis := bufconn.Listen(1 << 20)
+	server := collectorservice.NewGrpcServer(repo)
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return lis.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return NewCollectorGrpc("chain", conn, pools)
+}
+
+func TestCollectorGrpcStoreReadsRemoteCollector(t *testing.T) {
+	repo := &heightCollectorRepo{
+		fakeCollectorRepo: fakeCollectorRepo{
+			height:    12,
+			poolInfos: []dex.PoolInfo{{ContractAddr: "pair", Assets: []dex.Asset{{Addr: "a", Amount: "1"}, {Addr: "b", Amount: "2"}}}},
+		},
+		blocks: map[uint64]parser.RawTxs{
+			10: {{Hash: "tx10", Sender: "sender"}},
+			11: {},
+		},
+	}
+	store := newCollectorGrpcStore(t, repo, nil)
+
+	height, err := store.GetSourceSyncedHeight()
+	require.NoError(t, err)
+	require.Equal(t, uint64(12), height)
+
+	txs, err := store.GetSourceTxs(10)
+	require.NoError(t, err)
+	require.Equal(t, parser.RawTxs{{Hash: "tx10", Sender: "sender"}}, txs)
+
+	_, err = store.GetSourceTxs(9)
+	require.ErrorIs(t, err, collectorrepo.ErrNotFound)
+
+	poolInfos, err := store.GetPoolInfos(10)
+	require.NoError(t, err)
+	require.Equal(t, repo.poolInfos, poolInfos)
+
+	header, err := store.GetBlockHeader(11)
+	require.NoError(t, err)
+	require.Equal(t, dex.BlockHeader{Height: 11, Hash: "hash", ParentHash: "parent"}, header)
+
+	streamed := []uint64{}
+	err = store.StreamSourceTxs(context.Background(), 10, 0, func(height uint64, _ parser.RawTxs) error {
+		streamed = append(streamed, height)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []uint64{10, 11}, streamed, "stream stops at the first missing height")
+}
+
+func TestCollectorGrpcStoreMapsUnavailable(t *testing.T) {
+	store := newCollectorGrpcStore(t, &fakeCollectorRepo{heightErr: collectorrepo.ErrUnavailable}, nil)
+
+	_, err := store.GetSourceSyncedHeight()
+	require.ErrorIs(t, err, collectorrepo.ErrUnavailable)
+}
+
+func TestCollectorGrpcStoreReadsPoolsFromNodeWithoutSnapshot(t *testing.T) {
+	pools := &fakeCollectorFallback{poolInfos: []dex.PoolInfo{{ContractAddr: "node"}}}
+	store := newCollectorGrpcStore(t, &fakeCollectorRepo{poolInfoErr: collectorrepo.ErrNotFound}, pools)
+
+	poolInfos, err := store.GetPoolInfos(10)
+
+	require.NoError(t, err)
+	require.Equal(t, pools.poolInfos, poolInfos)
+	require.True(t, pools.called)
+}
+
+func TestCollectorGrpcStoreReadsHeaderWithoutBlockTxs(t *testing.T) {
+	repo := &fakeCollectorRepo{
+		txsErr: collectorrepo.ErrNotFound,
+		header: dex.BlockHeader{Height: 10, Hash: "hash", ParentHash: "parent"},
+	}
+	store := newCollectorGrpcStore(t, repo, nil)
+
+	header, err := store.GetBlockHeader(10)
+
+	require.NoError(t, err)
+	require.Equal(t, repo.header, header)
+}
@@ -36,18 +36,15 @@ func (m *mapperImpl) rawPoolInfosToPoolInfos(rawPoolInfos *datastore.PoolInfoLis
 func (*mapperImpl) rawPoolInfoToPoolInfo(contractAddr string, rawPoolInfo datastore.PoolInfoWithLpAddr) dex.PoolInfo {
 	poolInfo := dex.PoolInfo{
 		ContractAddr: contractAddr,
-		Assets: []dex.Asset{
-			{
-				Addr:   rawPoolInfo.Assets[0].Info.DenomOrAddress,
-				Amount: rawPoolInfo.Assets[0].Amount.String(),
-			},
-			{
-				Addr:   rawPoolInfo.Assets[1].Info.DenomOrAddress,
-				Amount: rawPoolInfo.Assets[1].Amount.String(),
-			},
-		},
-		LpAddr:     rawPoolInfo.LpAddr,
-		TotalShare: rawPoolInfo.TotalShare.String(),
+		Assets:       make([]dex.Asset, 0, len(rawPoolInfo.Assets)),
+		LpAddr:       rawPoolInfo.LpAddr,
+		TotalShare:   rawPoolInfo.TotalShare.String(),
+	}
+	for _, asset := range rawPoolInfo.Assets {
+		poolInfo.Assets = append(poolInfo.Assets, dex.Asset{
+			Addr:   asset.Info.DenomOrAddress,
+			Amount: asset.Amount.String(),
+		})
 	}
 	return poolInfo
 }
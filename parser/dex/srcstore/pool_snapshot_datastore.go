@@ -0,0 +1,191 @@
+package srcstore
+
+import (
+	"sync"
+	"time"
+
+	"github.com/dezswap/cosmwasm-etl/configs"
+	"github.com/dezswap/cosmwasm-etl/parser"
+	"github.com/dezswap/cosmwasm-etl/parser/dex"
+	"github.com/dezswap/cosmwasm-etl/pkg/logging"
+	"github.com/pkg/errors"
+)
+
+// batchedPoolStore replaces the sequential per-pair pool queries of a
+// snapshot height with batches of pairs queried by a bounded worker pool.
+// A batch is a single query when the source implements dex.BatchPoolSource.
+// Otherwise, or when that query fails, each pair of the batch is queried and
+// retried on its own, so one flaky query does not restart the whole height.
+type batchedPoolStore struct {
+	dex.SourceDataStore
+	pairs         dex.PairSource
+	pools         dex.PoolSource
+	batchPools    dex.BatchPoolSource
+	concurrency   uint
+	batchSize     uint
+	maxRetries    uint
+	retryInterval time.Duration
+	logger        logging.Logger
+}
+
+type batchedPoolHeaderStore struct {
+	*batchedPoolStore
+	headers dex.BlockHeaderSource
+}
+
+type batchedPoolBlockStore struct {
+	*batchedPoolHeaderStore
+	blocks dex.BlockSource
+}
+
+var _ dex.SourceDataStore = (*batchedPoolStore)(nil)
+var _ dex.PairSource = (*batchedPoolStore)(nil)
+var _ dex.PoolSource = (*batchedPoolStore)(nil)
+var _ dex.BlockHeaderSource = (*batchedPoolHeaderStore)(nil)
+var _ dex.BlockSource = (*batchedPoolBlockStore)(nil)
+
+// NewBatchedPools wraps source so GetPoolInfos queries pairs concurrently as
+// configured by c. source must implement dex.PairSource and dex.PoolSource.
+// The returned store implements dex.BlockHeaderSource and dex.BlockSource
+// when source does.
+func NewBatchedPools(source dex.SourceDataStore, c configs.PoolSnapshotConfig, logger logging.Logger) (dex.SourceDataStore, error) {
+	pairs, ok := source.(dex.PairSource)
+	if !ok {
+		return nil, errors.New("NewBatchedPools: source cannot list pairs")
+	}
+	pools, ok := source.(dex.PoolSource)
+	if !ok {
+		return nil, errors.New("NewBatchedPools: source cannot query a single pool")
+	}
+	if c.Concurrency == 0 || c.BatchSize == 0 {
+		return nil, errors.New("NewBatchedPools: concurrency and batch size must be greater than 0")
+	}
+
+	store := &batchedPoolStore{
+		SourceDataStore: source,
+		pairs:           pairs,
+		pools:           pools,
+		concurrency:     c.Concurrency,
+		batchSize:       c.BatchSize,
+		maxRetries:      c.MaxRetries,
+		retryInterval:   time.Duration(c.RetryIntervalMs) * time.Millisecond,
+		logger:          logger,
+	}
+	if batchPools, ok := source.(dex.BatchPoolSource); ok {
+		store.batchPools = batchPools
+	}
+	if blocks, ok := source.(dex.BlockSource); ok {
+		return &batchedPoolBlockStore{
+			batchedPoolHeaderStore: &batchedPoolHeaderStore{batchedPoolStore: store, headers: blocks},
+			blocks:                 blocks,
+		}, nil
+	}
+	if headers, ok := source.(dex.BlockHeaderSource); ok {
+		return &batchedPoolHeaderStore{batchedPoolStore: store, headers: headers}, nil
+	}
+	return store, nil
+}
+
+func (s *batchedPoolHeaderStore) GetBlockHeader(height uint64) (dex.BlockHeader, error) {
+	return s.headers.GetBlockHeader(height)
+}
+
+func (s *batchedPoolBlockStore) GetBlock(height uint64) (dex.BlockHeader, parser.RawTxs, error) {
+	return s.blocks.GetBlock(height)
+}
+
+// GetPairs implements dex.PairSource
+func (s *batchedPoolStore) GetPairs(height uint64) ([]dex.Pair, error) {
+	return s.pairs.GetPairs(height)
+}
+
+// GetPoolInfo implements dex.PoolSource
+func (s *batchedPoolStore) GetPoolInfo(pair dex.Pair, height uint64) (dex.PoolInfo, error) {
+	return s.pools.GetPoolInfo(pair, height)
+}
+
+// GetPoolInfos returns the pools of every pair at height in factory order.
+func (s *batchedPoolStore) GetPoolInfos(height uint64) ([]dex.PoolInfo, error) {
+	pairs, err := s.pairs.GetPairs(height)
+	if err != nil {
+		return nil, errors.Wrap(err, "batchedPoolStore.GetPoolInfos")
+	}
+
+	poolInfos := make([]dex.PoolInfo, len(pairs))
+	batches := make(chan int)
+	done := make(chan struct{})
+	errOnce := sync.Once{}
+	var firstErr error
+	wg := sync.WaitGroup{}
+
+	for i := uint(0); i < s.concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for start := range batches {
+				end := start + int(s.batchSize)
+				if end > len(pairs) {
+					end = len(pairs)
+				}
+				if err := s.queryBatch(pairs[start:end], poolInfos[start:end], height); err != nil {
+					errOnce.Do(func() {
+						firstErr = err
+						close(done)
+					})
+					return
+				}
+			}
+		}()
+	}
+
+dispatch:
+	for start := 0; start < len(pairs); start += int(s.batchSize) {
+		select {
+		case batches <- start:
+		case <-done:
+			break dispatch
+		}
+	}
+	close(batches)
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, errors.Wrap(firstErr, "batchedPoolStore.GetPoolInfos")
+	}
+	return poolInfos, nil
+}
+
+// queryBatch writes the pools of pairs at height to poolInfos.
+func (s *batchedPoolStore) queryBatch(pairs []dex.Pair, poolInfos []dex.PoolInfo, height uint64) error {
+	if s.batchPools != nil {
+		batch, err := s.batchPools.GetPoolInfosOf(pairs, height)
+		if err == nil {
+			copy(poolInfos, batch)
+			return nil
+		}
+		s.logger.Warnf("querying %d pools at height %d one by one after the batch query failed: %v", len(pairs), height, err)
+	}
+	for idx, pair := range pairs {
+		poolInfo, err := s.queryPool(pair, height)
+		if err != nil {
+			return err
+		}
+		poolInfos[idx] = poolInfo
+	}
+	return nil
+}
+
+func (s *batchedPoolStore) queryPool(pair dex.Pair, height uint64) (dex.PoolInfo, error) {
+	var err error
+	for attempt := uint(0); attempt <= s.maxRetries; attempt++ {
+		if attempt > 0 {
+			s.logger.Warnf("retrying pool query of %s at height %d (%d/%d): %v", pair.ContractAddr, height, attempt, s.maxRetries, err)
+			time.Sleep(s.retryInterval)
+		}
+		var poolInfo dex.PoolInfo
+		if poolInfo, err = s.pools.GetPoolInfo(pair, height); err == nil {
+			return poolInfo, nil
+		}
+	}
+	return dex.PoolInfo{}, errors.Wrapf(err, "pool %s at height %d", pair.ContractAddr, height)
+}
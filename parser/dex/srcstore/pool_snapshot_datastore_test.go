@@ -0,0 +1,158 @@
+package srcstore
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dezswap/cosmwasm-etl/configs"
+	"github.com/dezswap/cosmwasm-etl/parser"
+	"github.com/dezswap/cosmwasm-etl/parser/dex"
+	"github.com/dezswap/cosmwasm-etl/pkg/logging"
+	"github.com/stretchr/testify/require"
+)
+
+type poolSourceMock struct {
+	pairs    []dex.Pair
+	failures map[string]int
+
+	mu       sync.Mutex
+	attempts map[string]int
+	inFlight int32
+	peak     int32
+}
+
+func (m *poolSourceMock) GetSourceSyncedHeight() (uint64, error)     { return 0, nil }
+func (m *poolSourceMock) GetSourceTxs(uint64) (parser.RawTxs, error) { return nil, nil }
+func (m *poolSourceMock) GetPairs(uint64) ([]dex.Pair, error)        { return m.pairs, nil }
+func (m *poolSourceMock) GetBlockHeader(uint64) (dex.BlockHeader, error) {
+	return dex.BlockHeader{}, nil
+}
+
+func (m *poolSourceMock) GetPoolInfos(uint64) ([]dex.PoolInfo, error) {
+	return nil, errors.New("sequential GetPoolInfos must not be called")
+}
+
+func (m *poolSourceMock) GetPoolInfo(pair dex.Pair, height uint64) (dex.PoolInfo, error) {
+	current := atomic.AddInt32(&m.inFlight, 1)
+	defer atomic.AddInt32(&m.inFlight, -1)
+	for {
+		peak := atomic.LoadInt32(&m.peak)
+		if current <= peak || atomic.CompareAndSwapInt32(&m.peak, peak, current) {
+			break
+		}
+	}
+	time.Sleep(time.Millisecond)
+
+	m.mu.Lock()
+	m.attempts[pair.ContractAddr]++
+	attempt := m.attempts[pair.ContractAddr]
+	m.mu.Unlock()
+
+	if attempt <= m.failures[pair.ContractAddr] {
+		return dex.PoolInfo{}, fmt.Errorf("pool query of %s failed", pair.ContractAddr)
+	}
+	return dex.PoolInfo{ContractAddr: pair.ContractAddr, TotalShare: fmt.Sprint(height)}, nil
+}
+
+func newPoolSourceMock(count int, failures map[string]int) *poolSourceMock {
+	pairs := make([]dex.Pair, count)
+	for idx := range pairs {
+		pairs[idx] = dex.Pair{ContractAddr: fmt.Sprintf("pair%d", idx)}
+	}
+	return &poolSourceMock{pairs: pairs, failures: failures, attempts: map[string]int{}}
+}
+
+func TestBatchedPools_QueriesConcurrentlyInOrder(t *testing.T) {
+	source := newPoolSourceMock(23, map[string]int{"pair5": 2})
+	store, err := NewBatchedPools(source, configs.PoolSnapshotConfig{Concurrency: 3, BatchSize: 4, MaxRetries: 2}, logging.Discard)
+	require.NoError(t, err)
+	_, ok := store.(dex.BlockHeaderSource)
+	require.True(t, ok)
+
+	poolInfos, err := store.GetPoolInfos(10)
+
+	require.NoError(t, err)
+	require.Len(t, poolInfos, 23)
+	for idx, poolInfo := range poolInfos {
+		require.Equal(t, fmt.Sprintf("pair%d", idx), poolInfo.ContractAddr)
+		require.Equal(t, "10", poolInfo.TotalShare)
+	}
+	require.Equal(t, 3, source.attempts["pair5"])
+	require.Equal(t, 1, source.attempts["pair6"])
+	require.LessOrEqual(t, source.peak, int32(3))
+}
+
+func TestBatchedPools_FailsAfterRetries(t *testing.T) {
+	source := newPoolSourceMock(10, map[string]int{"pair2": 5})
+	store, err := NewBatchedPools(source, configs.PoolSnapshotConfig{Concurrency: 2, BatchSize: 2, MaxRetries: 1}, logging.Discard)
+	require.NoError(t, err)
+
+	_, err = store.GetPoolInfos(10)
+
+	require.ErrorContains(t, err, "pool pair2 at height 10")
+	require.Equal(t, 2, source.attempts["pair2"])
+}
+
+func TestBatchedPools_RequiresPoolSource(t *testing.T) {
+	_, err := NewBatchedPools(&fakeCollectorFallback{}, configs.PoolSnapshotConfig{Concurrency: 1, BatchSize: 1}, logging.Discard)
+	require.Error(t, err)
+}
+
+type batchPoolSourceMock struct {
+	*poolSourceMock
+	batchErr error
+	batches  [][]string
+}
+
+func (m *batchPoolSourceMock) GetPoolInfosOf(pairs []dex.Pair, height uint64) ([]dex.PoolInfo, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	addrs := []string{}
+	poolInfos := []dex.PoolInfo{}
+	for _, pair := range pairs {
+		addrs = append(addrs, pair.ContractAddr)
+		poolInfos = append(poolInfos, dex.PoolInfo{ContractAddr: pair.ContractAddr, TotalShare: fmt.Sprint(height)})
+	}
+	m.batches = append(m.batches, addrs)
+	if m.batchErr != nil {
+		return nil, m.batchErr
+	}
+	return poolInfos, nil
+}
+
+func TestBatchedPools_QueriesEachBatchOnce(t *testing.T) {
+	source := &batchPoolSourceMock{poolSourceMock: newPoolSourceMock(10, nil)}
+	store, err := NewBatchedPools(source, configs.PoolSnapshotConfig{Concurrency: 2, BatchSize: 4}, logging.Discard)
+	require.NoError(t, err)
+
+	poolInfos, err := store.GetPoolInfos(10)
+
+	require.NoError(t, err)
+	require.Len(t, poolInfos, 10)
+	for idx, poolInfo := range poolInfos {
+		require.Equal(t, fmt.Sprintf("pair%d", idx), poolInfo.ContractAddr)
+	}
+	require.ElementsMatch(t, [][]string{
+		{"pair0", "pair1", "pair2", "pair3"},
+		{"pair4", "pair5", "pair6", "pair7"},
+		{"pair8", "pair9"},
+	}, source.batches)
+	require.Empty(t, source.attempts, "no pair is queried on its own")
+}
+
+func TestBatchedPools_QueriesPairsOfFailedBatch(t *testing.T) {
+	source := &batchPoolSourceMock{poolSourceMock: newPoolSourceMock(4, nil), batchErr: errors.New("batch failed")}
+	store, err := NewBatchedPools(source, configs.PoolSnapshotConfig{Concurrency: 1, BatchSize: 4}, logging.Discard)
+	require.NoError(t, err)
+
+	poolInfos, err := store.GetPoolInfos(10)
+
+	require.NoError(t, err)
+	require.Len(t, poolInfos, 4)
+	require.Len(t, source.batches, 1)
+	require.Equal(t, map[string]int{"pair0": 1, "pair1": 1, "pair2": 1, "pair3": 1}, source.attempts)
+}
@@ -0,0 +1,162 @@
+package srcstore
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/dezswap/cosmwasm-etl/parser"
+	"github.com/dezswap/cosmwasm-etl/parser/dex"
+	"github.com/dezswap/cosmwasm-etl/pkg/logging"
+	"github.com/dezswap/cosmwasm-etl/pkg/terra/rpc"
+)
+
+const defaultSubscriptionReconnectDelay = 5 * time.Second
+
+type subscribeFunc func(ctx context.Context, wsUrl string, onHeight func(height uint64)) error
+
+// subscriptionStore wakes height waiters on CometBFT NewBlock events and
+// delegates every read, the synced height included, to the wrapped store. The
+// chain tip of an event is never reported as the synced height, since a
+// collector-backed store only serves a height once it has been collected.
+type subscriptionStore struct {
+	dex.SourceDataStore
+	wsUrl          string
+	subscribe      subscribeFunc
+	reconnectDelay time.Duration
+	logger         logging.Logger
+
+	mu        sync.Mutex
+	connected bool
+	updated   chan struct{}
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+type subscriptionHeaderStore struct {
+	*subscriptionStore
+	headers dex.BlockHeaderSource
+}
+
+type subscriptionStreamStore struct {
+	*subscriptionHeaderStore
+	stream dex.StreamSource
+}
+
+var _ dex.SourceDataStore = (*subscriptionStore)(nil)
+var _ dex.HeightNotifier = (*subscriptionStore)(nil)
+var _ dex.BlockHeaderSource = (*subscriptionHeaderStore)(nil)
+var _ dex.StreamSource = (*subscriptionStreamStore)(nil)
+
+// NewSubscription wraps source with a NewBlock websocket subscription on wsUrl.
+// The returned store implements dex.HeightNotifier, and dex.BlockHeaderSource
+// when source does. A header source that also implements dex.StreamSource
+// keeps it.
+func NewSubscription(wsUrl string, source dex.SourceDataStore, logger logging.Logger) dex.SourceDataStore {
+	return newSubscriptionStore(wsUrl, source, rpc.SubscribeNewBlocks, defaultSubscriptionReconnectDelay, logger)
+}
+
+func newSubscriptionStore(wsUrl string, source dex.SourceDataStore, subscribe subscribeFunc, reconnectDelay time.Duration, logger logging.Logger) dex.SourceDataStore {
+	ctx, cancel := context.WithCancel(context.Background())
+	store := &subscriptionStore{
+		SourceDataStore: source,
+		wsUrl:           wsUrl,
+		subscribe:       subscribe,
+		reconnectDelay:  reconnectDelay,
+		logger:          logger,
+		updated:         make(chan struct{}),
+		cancel:          cancel,
+		done:            make(chan struct{}),
+	}
+	go store.run(ctx)
+
+	if headers, ok := source.(dex.BlockHeaderSource); ok {
+		headerStore := &subscriptionHeaderStore{subscriptionStore: store, headers: headers}
+		if stream, ok := source.(dex.StreamSource); ok {
+			return &subscriptionStreamStore{subscriptionHeaderStore: headerStore, stream: stream}
+		}
+		return headerStore
+	}
+	return store
+}
+
+func (s *subscriptionHeaderStore) GetBlockHeader(height uint64) (dex.BlockHeader, error) {
+	return s.headers.GetBlockHeader(height)
+}
+
+func (s *subscriptionStreamStore) StreamSourceTxs(ctx context.Context, from, to uint64, handle func(height uint64, txs parser.RawTxs) error) error {
+	return s.stream.StreamSourceTxs(ctx, from, to, handle)
+}
+
+// WaitForHeight implements dex.HeightNotifier. The wrapped store is checked
+// again on every NewBlock event, so without a live subscription it sleeps for
+// timeout unless the wrapped store is already above after.
+func (s *subscriptionStore) WaitForHeight(after uint64, timeout time.Duration) bool {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	for {
+		s.mu.Lock()
+		updated := s.updated
+		s.mu.Unlock()
+
+		height, err := s.SourceDataStore.GetSourceSyncedHeight()
+		if err == nil && height > after {
+			return true
+		}
+
+		select {
+		case <-updated:
+		case <-timer.C:
+			return false
+		}
+	}
+}
+
+// Close stops the subscription and waits for the websocket to be released.
+func (s *subscriptionStore) Close() {
+	s.cancel()
+	<-s.done
+}
+
+func (s *subscriptionStore) run(ctx context.Context) {
+	defer close(s.done)
+
+	for {
+		err := s.subscribe(ctx, s.wsUrl, s.onHeight)
+		s.setConnected(false)
+		if ctx.Err() != nil {
+			return
+		}
+		s.logger.Warnf("NewBlock subscription dropped, polling source height until reconnected: %v", err)
+
+		select {
+		case <-time.After(s.reconnectDelay):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (s *subscriptionStore) onHeight(height uint64) {
+	s.mu.Lock()
+	if !s.connected {
+		s.logger.Infof("NewBlock subscription established at height %d", height)
+	}
+	s.connected = true
+	s.notifyLocked()
+	s.mu.Unlock()
+}
+
+func (s *subscriptionStore) setConnected(connected bool) {
+	s.mu.Lock()
+	s.connected = connected
+	s.notifyLocked()
+	s.mu.Unlock()
+}
+
+func (s *subscriptionStore) notifyLocked() {
+	close(s.updated)
+	s.updated = make(chan struct{})
+}
@@ -0,0 +1,164 @@
+package srcstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dezswap/cosmwasm-etl/parser/dex"
+	"github.com/dezswap/cosmwasm-etl/pkg/logging"
+	"github.com/dezswap/cosmwasm-etl/pkg/terra/rpc"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+)
+
+// newBlockServer is a minimal CometBFT websocket stand-in that acknowledges the
+// NewBlock subscription, emits the given heights and then either keeps the
+// socket open or drops it.
+func newBlockServer(t *testing.T, heights []uint64, drop bool) *httptest.Server {
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		var req map[string]interface{}
+		if err := conn.ReadJSON(&req); err != nil {
+			return
+		}
+		params, _ := req["params"].(map[string]interface{})
+		if params["query"] != rpc.NewBlockQuery {
+			return
+		}
+		_ = conn.WriteMessage(websocket.TextMessage, []byte(`{"jsonrpc":"2.0","id":1,"result":{}}`))
+		for _, height := range heights {
+			msg := fmt.Sprintf(`{"jsonrpc":"2.0","id":1,"result":{"query":"%s","data":{"type":"tendermint/event/NewBlock","value":{"block":{"header":{"height":"%d"}}}}}}`, rpc.NewBlockQuery, height)
+			if err := conn.WriteMessage(websocket.TextMessage, []byte(msg)); err != nil {
+				return
+			}
+		}
+		if drop {
+			return
+		}
+		_, _, _ = conn.ReadMessage()
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func wsUrl(server *httptest.Server) string {
+	return "ws" + strings.TrimPrefix(server.URL, "http") + "/websocket"
+}
+
+func TestSubscriptionStore_ReportsWrappedHeightAboveChainTip(t *testing.T) {
+	server := newBlockServer(t, []uint64{10, 11}, false)
+	fallback := &fakeCollectorFallback{height: 5}
+	store := newSubscriptionStore(wsUrl(server), fallback, rpc.SubscribeNewBlocks, time.Hour, logging.Discard)
+	defer store.(*subscriptionStore).Close()
+
+	require.False(t, store.(dex.HeightNotifier).WaitForHeight(5, 50*time.Millisecond))
+
+	height, err := store.GetSourceSyncedHeight()
+
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), height)
+}
+
+// heightSource is a wrapped store whose height a test advances while the
+// subscription goroutine runs.
+type heightSource struct {
+	fakeCollectorFallback
+	height atomic.Uint64
+}
+
+func (s *heightSource) GetSourceSyncedHeight() (uint64, error) {
+	return s.height.Load(), nil
+}
+
+func TestSubscriptionStore_WakesOnNewBlock(t *testing.T) {
+	source := &heightSource{}
+	source.height.Store(10)
+	blocks := make(chan uint64)
+	subscribe := func(ctx context.Context, _ string, onHeight func(uint64)) error {
+		for {
+			select {
+			case height := <-blocks:
+				onHeight(height)
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}
+	store := newSubscriptionStore("ws://stand-in", source, subscribe, time.Hour, logging.Discard)
+	defer store.(*subscriptionStore).Close()
+
+	woken := make(chan bool)
+	go func() { woken <- store.(dex.HeightNotifier).WaitForHeight(10, time.Minute) }()
+
+	// the wrapped store has not collected the new block yet
+	blocks <- 11
+	source.height.Store(11)
+	blocks <- 12
+
+	select {
+	case ok := <-woken:
+		require.True(t, ok)
+	case <-time.After(time.Second):
+		t.Fatal("waiter was not woken by NewBlock")
+	}
+	height, err := store.GetSourceSyncedHeight()
+	require.NoError(t, err)
+	require.Equal(t, uint64(11), height)
+}
+
+func TestSubscriptionStore_PollsWrappedStoreWhenSocketDrops(t *testing.T) {
+	server := newBlockServer(t, []uint64{10}, true)
+	fallback := &fakeCollectorFallback{height: 12}
+	store := newSubscriptionStore(wsUrl(server), fallback, rpc.SubscribeNewBlocks, time.Hour, logging.Discard)
+	defer store.(*subscriptionStore).Close()
+
+	require.Eventually(t, func() bool {
+		height, err := store.GetSourceSyncedHeight()
+		return err == nil && height == 12
+	}, time.Second, 10*time.Millisecond)
+	require.True(t, fallback.called)
+	require.False(t, store.(dex.HeightNotifier).WaitForHeight(12, 10*time.Millisecond))
+}
+
+func TestSubscriptionStore_PollsWrappedStoreWhenDialFails(t *testing.T) {
+	fallback := &fakeCollectorFallback{height: 7}
+	store := newSubscriptionStore("ws://127.0.0.1:1/websocket", fallback, rpc.SubscribeNewBlocks, time.Hour, logging.Discard)
+	defer store.(*subscriptionStore).Close()
+
+	height, err := store.GetSourceSyncedHeight()
+
+	require.NoError(t, err)
+	require.Equal(t, uint64(7), height)
+}
+
+func TestSubscriptionStore_ReconnectsAfterDrop(t *testing.T) {
+	calls := make(chan struct{}, 10)
+	subscribe := func(_ context.Context, _ string, onHeight func(uint64)) error {
+		calls <- struct{}{}
+		onHeight(20)
+		return errors.New("socket closed")
+	}
+	store := newSubscriptionStore("ws://stand-in", &fakeCollectorFallback{}, subscribe, time.Millisecond, logging.Discard)
+	defer store.(*subscriptionStore).Close()
+
+	for i := 0; i < 2; i++ {
+		select {
+		case <-calls:
+		case <-time.After(time.Second):
+			t.Fatal("subscription was not retried")
+		}
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/dezswap/cosmwasm-etl/parser"
 	p_dex "github.com/dezswap/cosmwasm-etl/parser/dex"
+	"github.com/dezswap/cosmwasm-etl/pkg/dex"
 	"github.com/dezswap/cosmwasm-etl/pkg/dex/terraswap"
 	"github.com/dezswap/cosmwasm-etl/pkg/eventlog"
 	"github.com/dezswap/cosmwasm-etl/pkg/terra/rpc"
@@ -15,6 +16,10 @@ import (
 
 const columbusCosmosSdk50StartHeight = 28214400
 
+// msgIndexKey is the attribute cosmos-sdk v0.50 adds to the events of a tx
+// message since a tx result has no per-message log.
+const msgIndexKey = "msg_index"
+
 type chainDataAdapter interface {
 	AllPairs(height uint64) ([]p_dex.Pair, error)
 	TxSenderOf(hash string) (string, error)
@@ -33,6 +38,10 @@ type baseRawDataStoreImpl struct {
 }
 
 var _ p_dex.SourceDataStore = &baseRawDataStoreImpl{}
+var _ p_dex.BlockHeaderSource = &baseRawDataStoreImpl{}
+var _ p_dex.BlockSource = &baseRawDataStoreImpl{}
+var _ p_dex.PairSource = &baseRawDataStoreImpl{}
+var _ p_dex.PoolSource = &baseRawDataStoreImpl{}
 
 func NewBaseStore(rpc rpc.Rpc, client terraswap.QueryClient, cda chainDataAdapter) p_dex.SourceDataStore {
 	return &baseRawDataStoreImpl{rpc, client, cda}
@@ -53,6 +62,59 @@ func (r *baseRawDataStoreImpl) GetSourceSyncedHeight() (uint64, error) {
 	return uint64(height), nil
 }
 
+// GetBlockHeader implements p_dex.BlockHeaderSource
+func (r *baseRawDataStoreImpl) GetBlockHeader(height uint64) (p_dex.BlockHeader, error) {
+	res, err := r.rpc.Block(height)
+	if err != nil {
+		return p_dex.BlockHeader{}, errors.Wrap(err, "baseRawDataStoreImpl.GetBlockHeader")
+	}
+
+	return p_dex.BlockHeader{
+		Height:     height,
+		Hash:       res.Result.BlockId.Hash,
+		ParentHash: res.Result.Block.Header.LastBlockId.Hash,
+	}, nil
+}
+
+// GetPairs implements p_dex.PairSource
+func (r *baseRawDataStoreImpl) GetPairs(height uint64) ([]p_dex.Pair, error) {
+	pairs, err := r.AllPairs(height)
+	if err != nil {
+		return nil, errors.Wrap(err, "baseRawDataStoreImpl.GetPairs")
+	}
+	return pairs, nil
+}
+
+// GetPoolInfo implements p_dex.PoolSource
+func (r *baseRawDataStoreImpl) GetPoolInfo(pair p_dex.Pair, height uint64) (p_dex.PoolInfo, error) {
+	poolRes, err := r.QueryPool(pair.ContractAddr, height)
+	if err != nil {
+		return p_dex.PoolInfo{}, errors.Wrap(err, "baseRawDataStoreImpl.GetPoolInfo")
+	}
+	poolInfo, err := toPoolInfo(pair, poolRes)
+	if err != nil {
+		return p_dex.PoolInfo{}, errors.Wrap(err, "baseRawDataStoreImpl.GetPoolInfo")
+	}
+	return poolInfo, nil
+}
+
+// toPoolInfo labels the pool amounts of pair with its asset addresses.
+func toPoolInfo(pair p_dex.Pair, poolRes *dex.PoolInfoRes) (p_dex.PoolInfo, error) {
+	if len(poolRes.Assets) != len(pair.Assets) {
+		return p_dex.PoolInfo{}, errors.Errorf("pool %s has %d assets, pair has %d", pair.ContractAddr, len(poolRes.Assets), len(pair.Assets))
+	}
+	assets := make([]p_dex.Asset, 0, len(pair.Assets))
+	for idx, addr := range pair.Assets {
+		assets = append(assets, p_dex.Asset{Addr: addr, Amount: poolRes.Assets[idx].Amount})
+	}
+	return p_dex.PoolInfo{
+		ContractAddr: pair.ContractAddr,
+		Assets:       assets,
+		LpAddr:       pair.LpAddr,
+		TotalShare:   poolRes.TotalShare,
+	}, nil
+}
+
 // GetPoolInfos implements p_dex.RawDataStore
 func (r *baseRawDataStoreImpl) GetPoolInfos(height uint64) ([]p_dex.PoolInfo, error) {
 	allPairs, err := r.AllPairs(height)
@@ -63,41 +125,60 @@ func (r *baseRawDataStoreImpl) GetPoolInfos(height uint64) ([]p_dex.PoolInfo, er
 	poolInfos := make([]p_dex.PoolInfo, len(allPairs))
 
 	for idx, pair := range allPairs {
-		poolRes, err := r.QueryPool(pair.ContractAddr, height)
+		poolInfos[idx], err = r.GetPoolInfo(pair, height)
 		if err != nil {
 			return nil, errors.Wrap(err, "baseRawDataStoreImpl.GetPoolInfos")
 		}
-		poolInfos[idx] = p_dex.PoolInfo{
-			ContractAddr: pair.ContractAddr,
-			Assets: []p_dex.Asset{
-				{Addr: pair.Assets[0], Amount: poolRes.Assets[0].Amount},
-				{Addr: pair.Assets[1], Amount: poolRes.Assets[1].Amount},
-			},
-			LpAddr:     pair.LpAddr,
-			TotalShare: poolRes.TotalShare,
-		}
 	}
 	return poolInfos, nil
 }
 
 // GetSourceTxs implements p_dex.RawDataStore
 func (r *baseRawDataStoreImpl) GetSourceTxs(height uint64) (parser.RawTxs, error) {
+	_, txs, err := r.GetBlock(height)
+	return txs, err
+}
+
+// GetBlock implements p_dex.BlockSource
+func (r *baseRawDataStoreImpl) GetBlock(height uint64) (p_dex.BlockHeader, parser.RawTxs, error) {
+	header, txs, err := r.readBlock(height, r.convertTxResult)
+	if err != nil {
+		return p_dex.BlockHeader{}, nil, errors.Wrap(err, "baseRawDataStoreImpl.GetBlock")
+	}
+	return header, txs, nil
+}
+
+func (r *baseRawDataStoreImpl) convertTxResult(height uint64, txHash string, result rpc.RpcTxResultRes, blockTs time.Time) (parser.RawTx, error) {
+	if height >= columbusCosmosSdk50StartHeight {
+		return r.convertEventsToRawTx(txHash, result.Events, blockTs)
+	}
+	return r.convertLogToRawTx(txHash, result.Log, blockTs)
+}
+
+// readBlock reads the header and the successful txs of a block from one
+// block response and its block results, converting each tx with convert.
+func (r *baseRawDataStoreImpl) readBlock(height uint64, convert func(height uint64, txHash string, result rpc.RpcTxResultRes, blockTs time.Time) (parser.RawTx, error)) (p_dex.BlockHeader, parser.RawTxs, error) {
 	rpcRes, err := r.rpc.Block(height)
 	if err != nil {
-		return nil, errors.Wrap(err, "baseRawDataStoreImpl.GetSourceTxs")
+		return p_dex.BlockHeader{}, nil, err
 	}
 	blockRes := rpcRes.Result
 	blockTime := blockRes.Block.Header.Time
 	txHashes := blockRes.TxsHashStrings()
+	header := p_dex.BlockHeader{
+		Height:     height,
+		Hash:       blockRes.BlockId.Hash,
+		ParentHash: blockRes.Block.Header.LastBlockId.Hash,
+	}
 
 	rpcResultRes, err := r.rpc.BlockResults(height)
 	if err != nil {
-		return nil, errors.Wrap(err, "baseRawDataStoreImpl.GetSourceTxs")
+		return p_dex.BlockHeader{}, nil, err
 	}
 
 	txResults := rpcResultRes.Result.TxsResults
 	if len(txHashes) != len(txResults) {
-		return nil, errors.New("baseRawDataStoreImpl.GetSourceTxs: txs length mismatch")
+		return p_dex.BlockHeader{}, nil, errors.New("txs length mismatch")
 	}
 
 	rawTxs := []parser.RawTx{}
@@ -106,20 +187,14 @@ func (r *baseRawDataStoreImpl) GetSourceTxs(height uint64) (parser.RawTxs, error
 			continue
 		}
 
-		var tx parser.RawTx
-		var err error
-		if height >= columbusCosmosSdk50StartHeight {
-			tx, err = r.convertEventsToRawTx(txHash, txResults[i].Events, blockTime)
-		} else {
-			tx, err = r.convertLogToRawTx(txHash, txResults[i].Log, blockTime)
-		}
+		tx, err := convert(height, txHash, txResults[i], blockTime)
 		if err != nil {
-			return nil, err
+			return p_dex.BlockHeader{}, nil, err
 		}
 
 		rawTxs = append(rawTxs, tx)
 	}
-	return rawTxs, nil
+	return header, rawTxs, nil
 }
 
 // convertLogToRawTx unmarshal raw log data into a structured RawTx, extracting event attributes and sender.
@@ -196,11 +271,13 @@ func groupEventsAttrByType(events []rpc.RpcEventRes) map[eventlog.LogType]eventl
 	logResultMap := make(map[eventlog.LogType]eventlog.Attributes)
 
 	for _, event := range events {
+		msgIndex := eventMsgIndex(event)
 		attributes := eventlog.Attributes{}
 		for _, attr := range event.Attributes {
 			attributes = append(attributes, eventlog.Attribute{
-				Key:   attr.Key,
-				Value: attr.Value,
+				Key:      attr.Key,
+				Value:    attr.Value,
+				MsgIndex: msgIndex,
 			})
 		}
 		logType := eventlog.LogType(event.Type)
@@ -211,3 +288,17 @@ func groupEventsAttrByType(events []rpc.RpcEventRes) map[eventlog.LogType]eventl
 	}
 	return logResultMap
 }
+
+// eventMsgIndex returns the index of the message that emitted event, 0 when
+// the chain does not tag events with it.
+func eventMsgIndex(event rpc.RpcEventRes) int {
+	for _, attr := range event.Attributes {
+		if attr.Key != msgIndexKey {
+			continue
+		}
+		if idx, err := strconv.Atoi(attr.Value); err == nil {
+			return idx
+		}
+	}
+	return 0
+}
@@ -281,6 +281,26 @@ func Test_groupEventsAttrByType(t *testing.T) {
 	assert.ElementsMatch(t, []eventlog.Attribute{{Key: "k1", Value: "v1"}, {Key: "k3", Value: "v3"}}, result["wasm"])
 }
 
+func Test_groupEventsAttrByType_MsgIndex(t *testing.T) {
+	events := []rpc.RpcEventRes{
+		{
+			Type:       "wasm",
+			Attributes: []rpc.RpcAttributeRes{{Key: "action", Value: "swap"}, {Key: "msg_index", Value: "0"}},
+		},
+		{
+			Type:       "wasm",
+			Attributes: []rpc.RpcAttributeRes{{Key: "action", Value: "swap"}, {Key: "msg_index", Value: "1"}},
+		},
+	}
+	result := groupEventsAttrByType(events)
+	assert.Equal(t, eventlog.Attributes{
+		{Key: "action", Value: "swap", MsgIndex: 0},
+		{Key: "msg_index", Value: "0", MsgIndex: 0},
+		{Key: "action", Value: "swap", MsgIndex: 1},
+		{Key: "msg_index", Value: "1", MsgIndex: 1},
+	}, result["wasm"])
+}
+
 func Test_groupEventsAttrByType_Empty(t *testing.T) {
 	empty := groupEventsAttrByType([]rpc.RpcEventRes{})
 	assert.Empty(t, empty)
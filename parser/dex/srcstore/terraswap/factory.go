@@ -13,7 +13,6 @@ import (
 	"github.com/dezswap/cosmwasm-etl/pkg/terra/col4"
 	terra_cosmos45 "github.com/dezswap/cosmwasm-etl/pkg/terra/cosmos45"
 	"github.com/dezswap/cosmwasm-etl/pkg/terra/rpc"
-	"github.com/pkg/errors"
 )
 
 func NewFromConfig(c configs.NodeConfig, factoryAddress string) (pdex.SourceDataStore, error) {
@@ -30,7 +29,9 @@ func NewFromConfig(c configs.NodeConfig, factoryAddress string) (pdex.SourceData
 		terraswapQueryClient := dts_colv2.NewColumbusV2Client(lcd)
 		return NewCol5Store(factoryAddress, r, lcd, terraswapQueryClient), nil
 	case dts.PISCO_FACTORY:
-		return nil, errors.New("not implemented yet")
+		lcd := terra_cosmos45.NewLcd(c.RestClientConfig.LcdHost, httpClient)
+		terraswapQueryClient := dts_phoenix.NewPhoenixClient(lcd)
+		return NewPiscoStore(factoryAddress, r, lcd, terraswapQueryClient), nil
 	case dts.CLASSIC_V1_FACTORY:
 		lcd := col4.NewLcd(c.RestClientConfig.LcdHost, httpClient)
 		terraswapQueryClient := dts_colv1.NewCol4Client(lcd)
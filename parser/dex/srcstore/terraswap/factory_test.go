@@ -22,6 +22,14 @@ func TestNewFromConfigCreatesSupportedStores(t *testing.T) {
 				require.IsType(t, &phoenixSourceDataStore{}, store)
 			},
 		},
+		{
+			name:    "pisco",
+			factory: dts.PISCO_FACTORY,
+			assert: func(t *testing.T, store interface{}) {
+				require.IsType(t, &phoenixSourceDataStore{}, store)
+				require.Zero(t, store.(*phoenixSourceDataStore).sdk50StartHeight)
+			},
+		},
 		{
 			name:    "columbus v2",
 			factory: dts.CLASSIC_V2_FACTORY,
@@ -48,12 +56,6 @@ func TestNewFromConfigCreatesSupportedStores(t *testing.T) {
 	}
 }
 
-func TestNewFromConfigRejectsUnsupportedFactory(t *testing.T) {
-	_, err := NewFromConfig(factoryNodeConfig(), string(dts.PISCO_FACTORY))
-
-	require.EqualError(t, err, "not implemented yet")
-}
-
 func TestNewFromConfigRejectsUnknownFactory(t *testing.T) {
 	_, err := NewFromConfig(factoryNodeConfig(), "terra1unknown")
 
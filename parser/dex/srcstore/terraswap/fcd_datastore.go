@@ -0,0 +1,127 @@
+package terraswap
+
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/dezswap/cosmwasm-etl/parser"
+	p_dex "github.com/dezswap/cosmwasm-etl/parser/dex"
+	"github.com/dezswap/cosmwasm-etl/pkg/db/schemas"
+	"github.com/dezswap/cosmwasm-etl/pkg/eventlog"
+	"github.com/pkg/errors"
+)
+
+const executeContractLogType = eventlog.LogType("execute_contract")
+
+// FcdTxLogReader reads the tx logs stored by the FCD address-history collector.
+type FcdTxLogReader interface {
+	TxLogsByHeight(chainID string, height int) ([]schemas.FcdTxLog, error)
+	CollectedHeight(chainID string) (uint64, error)
+}
+
+// PoolSnapshotReader reads pool snapshots stored by the collector.
+type PoolSnapshotReader interface {
+	GetPoolInfos(chainID string, height uint64) ([]p_dex.PoolInfo, error)
+}
+
+// fcdSourceDataStore replays collected FCD tx logs without a node. Txs are
+// those of the collected addresses only, so the source is as complete as the
+// FCD collection it reads.
+type fcdSourceDataStore struct {
+	chainId string
+	logs    FcdTxLogReader
+	pools   PoolSnapshotReader
+}
+
+var _ p_dex.SourceDataStore = &fcdSourceDataStore{}
+
+// NewFcdStore reads txs from logs and pools from the collector snapshots of
+// chainId. The factory must be among the collected addresses for create_pair
+// txs to be replayed.
+func NewFcdStore(chainId string, logs FcdTxLogReader, pools PoolSnapshotReader) p_dex.SourceDataStore {
+	return &fcdSourceDataStore{chainId: chainId, logs: logs, pools: pools}
+}
+
+// GetSourceSyncedHeight implements p_dex.RawDataStore. FCD collects each
+// address from its newest tx down, so the source is synced only up to the
+// height every address of the chain has been collected through.
+func (r *fcdSourceDataStore) GetSourceSyncedHeight() (uint64, error) {
+	height, err := r.logs.CollectedHeight(r.chainId)
+	if err != nil {
+		return 0, errors.Wrap(err, "fcdSourceDataStore.GetSourceSyncedHeight")
+	}
+	return height, nil
+}
+
+// GetSourceTxs implements p_dex.RawDataStore. A tx collected for several
+// addresses is returned once, in FCD order.
+func (r *fcdSourceDataStore) GetSourceTxs(height uint64) (parser.RawTxs, error) {
+	txLogs, err := r.logs.TxLogsByHeight(r.chainId, int(height))
+	if err != nil {
+		return nil, errors.Wrap(err, "fcdSourceDataStore.GetSourceTxs")
+	}
+	sort.SliceStable(txLogs, func(i, j int) bool { return txLogs[i].FcdOffset < txLogs[j].FcdOffset })
+
+	rawTxs := parser.RawTxs{}
+	seen := map[string]bool{}
+	for _, txLog := range txLogs {
+		if seen[txLog.Hash] {
+			continue
+		}
+		seen[txLog.Hash] = true
+
+		tx, err := fcdTxLogToRawTx(txLog)
+		if err != nil {
+			return nil, errors.Wrap(err, "fcdSourceDataStore.GetSourceTxs")
+		}
+		rawTxs = append(rawTxs, tx)
+	}
+	return rawTxs, nil
+}
+
+// GetPoolInfos implements p_dex.RawDataStore
+func (r *fcdSourceDataStore) GetPoolInfos(height uint64) ([]p_dex.PoolInfo, error) {
+	poolInfos, err := r.pools.GetPoolInfos(r.chainId, height)
+	if err != nil {
+		return nil, errors.Wrap(err, "fcdSourceDataStore.GetPoolInfos")
+	}
+	return poolInfos, nil
+}
+
+// fcdTxLogToRawTx takes the sender from the message event, or from the
+// execute_contract event when the message event has none, since there is no
+// node to look the tx up.
+func fcdTxLogToRawTx(txLog schemas.FcdTxLog) (parser.RawTx, error) {
+	var logs logResults
+	if err := json.Unmarshal([]byte(txLog.EventLog), &logs); err != nil {
+		return parser.RawTx{}, errors.Wrapf(err, "failed to unmarshal log JSON for tx %s", txLog.Hash)
+	}
+	logResultMap := groupLogAttrByType(logs)
+
+	tx := parser.RawTx{
+		Hash:       txLog.Hash,
+		Timestamp:  txLog.Timestamp,
+		LogResults: make([]eventlog.LogResult, 0, len(logResultMap)),
+	}
+	logTypes := make([]string, 0, len(logResultMap))
+	for logType := range logResultMap {
+		logTypes = append(logTypes, string(logType))
+	}
+	sort.Strings(logTypes)
+	for _, logType := range logTypes {
+		tx.LogResults = append(tx.LogResults, eventlog.LogResult{
+			Type:       eventlog.LogType(logType),
+			Attributes: logResultMap[eventlog.LogType(logType)],
+		})
+	}
+
+	for _, logType := range []eventlog.LogType{eventlog.Message, executeContractLogType} {
+		for _, attr := range logResultMap[logType] {
+			if attr.Key == "sender" {
+				tx.Sender = attr.Value
+				return tx, nil
+			}
+		}
+	}
+	return tx, nil
+}
@@ -0,0 +1,92 @@
+package terraswap
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	p_dex "github.com/dezswap/cosmwasm-etl/parser/dex"
+	"github.com/dezswap/cosmwasm-etl/pkg/db/schemas"
+	"github.com/dezswap/cosmwasm-etl/pkg/eventlog"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockFcdLogs struct {
+	chainId   string
+	logs      map[int][]schemas.FcdTxLog
+	collected uint64
+}
+
+func (m *mockFcdLogs) TxLogsByHeight(chainId string, height int) ([]schemas.FcdTxLog, error) {
+	if chainId != m.chainId {
+		return nil, nil
+	}
+	return m.logs[height], nil
+}
+func (m *mockFcdLogs) CollectedHeight(chainId string) (uint64, error) {
+	if chainId != m.chainId {
+		return 0, nil
+	}
+	return m.collected, nil
+}
+
+type mockPoolSnapshots struct {
+	chainId string
+	height  uint64
+}
+
+func (m *mockPoolSnapshots) GetPoolInfos(chainId string, height uint64) ([]p_dex.PoolInfo, error) {
+	m.chainId, m.height = chainId, height
+	return []p_dex.PoolInfo{{ContractAddr: "pair"}}, nil
+}
+
+func Test_fcdSourceDataStore(t *testing.T) {
+	ts := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+	withSender, _ := json.Marshal(mockLogResultWithSender)
+	executeOnly, _ := json.Marshal(logResults{{
+		Events: eventlog.LogResults{{
+			Type:       executeContractLogType,
+			Attributes: eventlog.Attributes{{Key: "sender", Value: "terra1exec"}, {Key: "contract_address", Value: "pair"}},
+		}},
+	}})
+	logs := &mockFcdLogs{chainId: "columbus-4", collected: 4_724_000, logs: map[int][]schemas.FcdTxLog{
+		10: {
+			{FcdOffset: 30, Hash: "second", Address: "pair", Timestamp: ts, EventLog: string(executeOnly)},
+			{FcdOffset: 20, Hash: "first", Address: "pair", Timestamp: ts, EventLog: string(withSender)},
+			{FcdOffset: 20, Hash: "first", Address: "token", Timestamp: ts, EventLog: string(withSender)},
+		},
+	}}
+	pools := &mockPoolSnapshots{}
+	store := NewFcdStore("columbus-4", logs, pools)
+
+	height, err := store.GetSourceSyncedHeight()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(4_724_000), height)
+
+	txs, err := store.GetSourceTxs(10)
+	assert.NoError(t, err)
+	assert.Len(t, txs, 2)
+	assert.Equal(t, "first", txs[0].Hash)
+	assert.Equal(t, mockSender, txs[0].Sender)
+	assert.Equal(t, ts, txs[0].Timestamp)
+	assert.Equal(t, "second", txs[1].Hash)
+	assert.Equal(t, "terra1exec", txs[1].Sender)
+
+	txs, err = store.GetSourceTxs(11)
+	assert.NoError(t, err)
+	assert.Empty(t, txs)
+
+	other := NewFcdStore("columbus-5", logs, pools)
+	height, err = other.GetSourceSyncedHeight()
+	assert.NoError(t, err)
+	assert.Zero(t, height)
+	txs, err = other.GetSourceTxs(10)
+	assert.NoError(t, err)
+	assert.Empty(t, txs)
+
+	poolInfos, err := store.GetPoolInfos(10)
+	assert.NoError(t, err)
+	assert.Equal(t, []p_dex.PoolInfo{{ContractAddr: "pair"}}, poolInfos)
+	assert.Equal(t, "columbus-4", pools.chainId)
+	assert.Equal(t, uint64(10), pools.height)
+}
@@ -2,9 +2,12 @@ package terraswap
 
 import (
 	"encoding/json"
+	"time"
 
+	wasm "github.com/CosmWasm/wasmd/x/wasm/types"
 	"github.com/dezswap/cosmwasm-etl/parser"
 	"github.com/dezswap/cosmwasm-etl/parser/dex"
+	pkgdex "github.com/dezswap/cosmwasm-etl/pkg/dex"
 	"github.com/dezswap/cosmwasm-etl/pkg/dex/terraswap"
 	"github.com/dezswap/cosmwasm-etl/pkg/terra/cosmos45"
 	"github.com/dezswap/cosmwasm-etl/pkg/terra/lcd"
@@ -16,19 +19,38 @@ import (
 // https://github.com/cosmos/cosmos-sdk/blob/release/v0.50.x/UPGRADING.md
 const cosmosSdk50StartHeight = 16395000
 
+const smartContractStatePath = "/cosmwasm.wasm.v1.Query/SmartContractState"
+
 type cosmos47Resultog struct {
 	MsgIndex int               `json:"msg_index"`
 	Log      string            `json:"log"`
 	Events   []rpc.RpcEventRes `json:"events"`
 }
 
+// phoenixSourceDataStore reads phoenix-1 and pisco-1, which run the same
+// contracts. Since cosmos-sdk v0.50 a tx result has no log and carries its
+// events directly.
 type phoenixSourceDataStore struct {
 	*baseRawDataStoreImpl
+	// sdk50StartHeight is the first height after the v0.50 upgrade. Zero
+	// means the upgrade height is unknown and the format is chosen per tx.
+	sdk50StartHeight uint64
 }
 
 var _ dex.SourceDataStore = &phoenixSourceDataStore{}
+var _ dex.BatchPoolSource = &phoenixSourceDataStore{}
 
 func NewPhoenixStore(factoryAddress string, rpc rpc.Rpc, lcd lcd.Lcd[cosmos45.LcdTxRes], client terraswap.QueryClient) dex.SourceDataStore {
+	return newPhoenixStore(factoryAddress, rpc, lcd, client, cosmosSdk50StartHeight)
+}
+
+// NewPiscoStore reads pisco-1, which upgraded to cosmos-sdk v0.50 at another
+// height than phoenix-1.
+func NewPiscoStore(factoryAddress string, rpc rpc.Rpc, lcd lcd.Lcd[cosmos45.LcdTxRes], client terraswap.QueryClient) dex.SourceDataStore {
+	return newPhoenixStore(factoryAddress, rpc, lcd, client, 0)
+}
+
+func newPhoenixStore(factoryAddress string, rpc rpc.Rpc, lcd lcd.Lcd[cosmos45.LcdTxRes], client terraswap.QueryClient, sdk50StartHeight uint64) *phoenixSourceDataStore {
 	return &phoenixSourceDataStore{
 		baseRawDataStoreImpl: &baseRawDataStoreImpl{rpc, client, &col5ChainDataAdapter{
 			factoryAddress: factoryAddress,
@@ -37,54 +59,82 @@ func NewPhoenixStore(factoryAddress string, rpc rpc.Rpc, lcd lcd.Lcd[cosmos45.Lc
 			lcd:            lcd,
 			QueryClient:    client,
 		}},
+		sdk50StartHeight: sdk50StartHeight,
 	}
 }
 
-func (r *phoenixSourceDataStore) GetSourceTxs(height uint64) (parser.RawTxs, error) {
-	rpcRes, err := r.rpc.Block(height)
-	if err != nil {
-		return nil, errors.Wrap(err, "phoenixSourceDataStore.GetSourceTxs")
+// hasResultEvents reports whether a tx result carries its events directly
+// instead of in its log.
+func (r *phoenixSourceDataStore) hasResultEvents(height uint64, log string) bool {
+	if r.sdk50StartHeight == 0 {
+		return log == ""
 	}
-	blockRes := rpcRes.Result
-	blockTime := blockRes.Block.Header.Time
-	txHashes := blockRes.TxsHashStrings()
+	return height > r.sdk50StartHeight
+}
+
+func (r *phoenixSourceDataStore) GetSourceTxs(height uint64) (parser.RawTxs, error) {
+	_, txs, err := r.GetBlock(height)
+	return txs, err
+}
 
-	rpcResultRes, err := r.rpc.BlockResults(height)
+// GetBlock implements dex.BlockSource
+func (r *phoenixSourceDataStore) GetBlock(height uint64) (dex.BlockHeader, parser.RawTxs, error) {
+	header, txs, err := r.readBlock(height, r.convertTxResult)
 	if err != nil {
-		return nil, errors.Wrap(err, "phoenixSourceDataStore.GetSourceTxs")
+		return dex.BlockHeader{}, nil, errors.Wrap(err, "phoenixSourceDataStore.GetBlock")
 	}
+	return header, txs, nil
+}
 
-	txResults := rpcResultRes.Result.TxsResults
-	if len(txHashes) != len(txResults) {
-		return nil, errors.New("phoenixSourceDataStore.GetSourceTxs: txs length mismatch")
+func (r *phoenixSourceDataStore) convertTxResult(height uint64, txHash string, result rpc.RpcTxResultRes, blockTs time.Time) (parser.RawTx, error) {
+	if r.hasResultEvents(height, result.Log) {
+		return r.convertEventsToRawTx(txHash, result.Events, blockTs)
 	}
+	var logs []cosmos47Resultog
+	if err := json.Unmarshal([]byte(result.Log), &logs); err != nil {
+		return parser.RawTx{}, errors.Wrapf(err, "failed to unmarshal log JSON for tx %s", txHash)
+	}
+	var events []rpc.RpcEventRes
+	for _, l := range logs {
+		events = append(events, l.Events...)
+	}
+	return r.convertEventsToRawTx(txHash, events, blockTs)
+}
 
-	rawTxs := []parser.RawTx{}
-	for i, txHash := range txHashes {
-		if txResults[i].Code != 0 {
-			continue
+// GetPoolInfosOf implements dex.BatchPoolSource. The pool queries of pairs
+// are sent to the rpc as one batch of smart contract state queries.
+func (r *phoenixSourceDataStore) GetPoolInfosOf(pairs []dex.Pair, height uint64) ([]dex.PoolInfo, error) {
+	queries := make([][]byte, len(pairs))
+	for idx, pair := range pairs {
+		req := wasm.QuerySmartContractStateRequest{Address: pair.ContractAddr, QueryData: []byte(pkgdex.PAIR_QUERY_POOL_STRING)}
+		data, err := req.Marshal()
+		if err != nil {
+			return nil, errors.Wrap(err, "phoenixSourceDataStore.GetPoolInfosOf")
 		}
+		queries[idx] = data
+	}
+
+	results, err := r.rpc.AbciQueries(smartContractStatePath, queries, height)
+	if err != nil {
+		return nil, errors.Wrap(err, "phoenixSourceDataStore.GetPoolInfosOf")
+	}
 
-		var tx parser.RawTx
-		var err error
-		if height > cosmosSdk50StartHeight {
-			tx, err = r.convertEventsToRawTx(txHash, txResults[i].Events, blockTime)
-		} else {
-			var logs []cosmos47Resultog
-			if err = json.Unmarshal([]byte(txResults[i].Log), &logs); err != nil {
-				return nil, errors.Wrapf(err, "failed to unmarshal log JSON for tx %s", txHash)
-			}
-			var events []rpc.RpcEventRes
-			for _, l := range logs {
-				events = append(events, l.Events...)
-			}
-			tx, err = r.convertEventsToRawTx(txHash, events, blockTime)
+	poolInfos := make([]dex.PoolInfo, len(pairs))
+	for idx, result := range results {
+		if result.Response.Code != 0 {
+			return nil, errors.Errorf("phoenixSourceDataStore.GetPoolInfosOf: pool %s: %s", pairs[idx].ContractAddr, result.Response.Log)
 		}
-		if err != nil {
-			return nil, err
+		res := wasm.QuerySmartContractStateResponse{}
+		if err := res.Unmarshal(result.Response.Value); err != nil {
+			return nil, errors.Wrapf(err, "phoenixSourceDataStore.GetPoolInfosOf: pool %s", pairs[idx].ContractAddr)
+		}
+		poolRes := pkgdex.PoolInfoRes{}
+		if err := json.Unmarshal(res.Data, &poolRes); err != nil {
+			return nil, errors.Wrapf(err, "phoenixSourceDataStore.GetPoolInfosOf: pool %s", pairs[idx].ContractAddr)
+		}
+		if poolInfos[idx], err = toPoolInfo(pairs[idx], &poolRes); err != nil {
+			return nil, errors.Wrap(err, "phoenixSourceDataStore.GetPoolInfosOf")
 		}
-
-		rawTxs = append(rawTxs, tx)
 	}
-	return rawTxs, nil
+	return poolInfos, nil
 }
@@ -1 +1,137 @@
 package terraswap
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+	"time"
+
+	wasm "github.com/CosmWasm/wasmd/x/wasm/types"
+	"github.com/cometbft/cometbft/types"
+	"github.com/dezswap/cosmwasm-etl/parser"
+	"github.com/dezswap/cosmwasm-etl/parser/dex"
+	"github.com/dezswap/cosmwasm-etl/pkg/eventlog"
+	"github.com/dezswap/cosmwasm-etl/pkg/terra/rpc"
+	"github.com/stretchr/testify/assert"
+)
+
+// piscoBlockResultsFixture holds a cosmos-sdk v0.47 tx whose events are in
+// the log, a failed tx and a v0.50 tx whose log is empty.
+const piscoBlockResultsFixture = `{"jsonrpc":"2.0","id":-1,"result":{"height":"100","txs_results":[
+	{"code":0,"log":"[{\"msg_index\":0,\"events\":[{\"type\":\"message\",\"attributes\":[{\"key\":\"action\",\"value\":\"/cosmwasm.wasm.v1.MsgExecuteContract\"},{\"key\":\"sender\",\"value\":\"terra1logsender\"}]},{\"type\":\"wasm\",\"attributes\":[{\"key\":\"_contract_address\",\"value\":\"terra1pair\"},{\"key\":\"action\",\"value\":\"swap\"}]}]}]"},
+	{"code":5,"log":"out of gas"},
+	{"code":0,"log":"","events":[{"type":"message","attributes":[{"key":"action","value":"/cosmwasm.wasm.v1.MsgExecuteContract"},{"key":"sender","value":"terra1eventsender"},{"key":"msg_index","value":"0"}]},{"type":"wasm","attributes":[{"key":"_contract_address","value":"terra1pair"},{"key":"action","value":"provide_liquidity"},{"key":"msg_index","value":"0"}]}]}
+]}}`
+
+type mockPhoenixRpc struct {
+	block   *rpc.RpcRes[rpc.RpcBlockRes]
+	results *rpc.RpcRes[rpc.RpcBlockResultRes]
+	queries []rpc.RpcAbciQueryRes
+	sent    [][]byte
+}
+
+func (m *mockPhoenixRpc) Status() (*rpc.RpcRes[rpc.RpcStatusRes], error) { panic("not implemented") }
+func (m *mockPhoenixRpc) Block(height ...uint64) (*rpc.RpcRes[rpc.RpcBlockRes], error) {
+	return m.block, nil
+}
+func (m *mockPhoenixRpc) BlockResults(height ...uint64) (*rpc.RpcRes[rpc.RpcBlockResultRes], error) {
+	return m.results, nil
+}
+
+func (m *mockPhoenixRpc) AbciQueries(path string, data [][]byte, height uint64) ([]rpc.RpcAbciQueryRes, error) {
+	m.sent = append(m.sent, data...)
+	return m.queries, nil
+}
+
+func newMockPhoenixRpc(t *testing.T, blockTime time.Time, txs types.Txs) *mockPhoenixRpc {
+	m := &mockPhoenixRpc{block: &rpc.RpcRes[rpc.RpcBlockRes]{}}
+	m.block.Result.Block.Header.Time = blockTime
+	m.block.Result.Block.Data.Txs = txs
+	assert.NoError(t, json.Unmarshal([]byte(piscoBlockResultsFixture), &m.results))
+	return m
+}
+
+func Test_phoenixSourceDataStore_PiscoChoosesFormatPerTx(t *testing.T) {
+	blockTime := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	txs := types.Txs{types.Tx("v47"), types.Tx("failed"), types.Tx("v50")}
+	store := NewPiscoStore("factory", newMockPhoenixRpc(t, blockTime, txs), &mockCol5Lcd{}, nil)
+
+	rawTxs, err := store.GetSourceTxs(100)
+
+	assert.NoError(t, err)
+	assert.Len(t, rawTxs, 2)
+	assert.Equal(t, hex.EncodeToString(txs[0].Hash()), rawTxs[0].Hash)
+	assert.Equal(t, "terra1logsender", rawTxs[0].Sender)
+	assert.Equal(t, "swap", wasmAction(rawTxs[0]))
+	assert.Equal(t, hex.EncodeToString(txs[2].Hash()), rawTxs[1].Hash)
+	assert.Equal(t, "terra1eventsender", rawTxs[1].Sender)
+	assert.Equal(t, "provide_liquidity", wasmAction(rawTxs[1]))
+	for _, tx := range rawTxs {
+		assert.Equal(t, blockTime, tx.Timestamp)
+	}
+}
+
+func Test_phoenixSourceDataStore_LengthMismatch(t *testing.T) {
+	store := NewPiscoStore("factory", newMockPhoenixRpc(t, time.Now(), types.Txs{types.Tx("only")}), &mockCol5Lcd{}, nil)
+
+	_, err := store.GetSourceTxs(100)
+
+	assert.EqualError(t, err, "phoenixSourceDataStore.GetBlock: txs length mismatch")
+}
+
+func wasmAction(tx parser.RawTx) string {
+	for _, log := range tx.LogResults {
+		if log.Type != eventlog.WasmType {
+			continue
+		}
+		for _, attr := range log.Attributes {
+			if attr.Key == "action" {
+				return attr.Value
+			}
+		}
+	}
+	return ""
+}
+
+func smartQueryResult(t *testing.T, code uint32, data string) rpc.RpcAbciQueryRes {
+	value, err := (&wasm.QuerySmartContractStateResponse{Data: []byte(data)}).Marshal()
+	assert.NoError(t, err)
+	res := rpc.RpcAbciQueryRes{}
+	res.Response.Code = code
+	res.Response.Value = value
+	res.Response.Log = "query failed"
+	return res
+}
+
+func Test_phoenixSourceDataStore_GetPoolInfosOf(t *testing.T) {
+	m := &mockPhoenixRpc{queries: []rpc.RpcAbciQueryRes{
+		smartQueryResult(t, 0, `{"assets":[{"amount":"1"},{"amount":"2"}],"total_share":"3"}`),
+		smartQueryResult(t, 0, `{"assets":[{"amount":"4"},{"amount":"5"}],"total_share":"6"}`),
+	}}
+	store := NewPhoenixStore("factory", m, &mockCol5Lcd{}, nil).(dex.BatchPoolSource)
+	pairs := []dex.Pair{
+		{ContractAddr: "pair0", LpAddr: "lp0", Assets: []string{"a", "b"}},
+		{ContractAddr: "pair1", LpAddr: "lp1", Assets: []string{"c", "d"}},
+	}
+
+	poolInfos, err := store.GetPoolInfosOf(pairs, 100)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []dex.PoolInfo{
+		{ContractAddr: "pair0", LpAddr: "lp0", TotalShare: "3", Assets: []dex.Asset{{Addr: "a", Amount: "1"}, {Addr: "b", Amount: "2"}}},
+		{ContractAddr: "pair1", LpAddr: "lp1", TotalShare: "6", Assets: []dex.Asset{{Addr: "c", Amount: "4"}, {Addr: "d", Amount: "5"}}},
+	}, poolInfos)
+	assert.Len(t, m.sent, 2)
+	req := wasm.QuerySmartContractStateRequest{}
+	assert.NoError(t, req.Unmarshal(m.sent[1]))
+	assert.Equal(t, "pair1", req.Address)
+}
+
+func Test_phoenixSourceDataStore_GetPoolInfosOfFailedQuery(t *testing.T) {
+	m := &mockPhoenixRpc{queries: []rpc.RpcAbciQueryRes{smartQueryResult(t, 5, "")}}
+	store := NewPhoenixStore("factory", m, &mockCol5Lcd{}, nil).(dex.BatchPoolSource)
+
+	_, err := store.GetPoolInfosOf([]dex.Pair{{ContractAddr: "pair0"}}, 100)
+
+	assert.EqualError(t, err, "phoenixSourceDataStore.GetPoolInfosOf: pool pair0: query failed")
+}
@@ -21,6 +21,8 @@ type starfleitApp struct {
 	// state
 	pairs       map[string]dex.Pair
 	lpPairAddrs map[string]string
+
+	parsersVersion dex.ParsersVersion
 }
 
 var _ dex.TargetApp = &starfleitApp{}
@@ -49,7 +51,7 @@ func New(repo dex.PairRepo, logger logging.Logger, c configs.ParserDexConfig, ch
 		lpPairAddrs[p.LpAddr] = p.ContractAddr
 	}
 
-	return &starfleitApp{repo, parsers, dex.DexMixin{}, chainId, pairs, lpPairAddrs}, nil
+	return &starfleitApp{repo, parsers, dex.DexMixin{}, chainId, pairs, lpPairAddrs, dex.ParsersVersion{}}, nil
 }
 
 func (p *starfleitApp) ParseTxs(tx parser.RawTx, height uint64) ([]dex.ParsedTx, error) {
@@ -63,7 +65,7 @@ func (p *starfleitApp) ParseTxs(tx parser.RawTx, height uint64) ([]dex.ParsedTx,
 		p.pairs[ctx.ContractAddr] = dex.Pair{
 			ContractAddr: ctx.ContractAddr,
 			LpAddr:       ctx.LpAddr,
-			Assets:       []string{ctx.Assets[0].Addr, ctx.Assets[1].Addr},
+			Assets:       ctx.AssetAddrs(),
 		}
 		p.lpPairAddrs[ctx.LpAddr] = ctx.ContractAddr
 		ctx.Sender = tx.Sender
@@ -138,6 +140,14 @@ func (p *starfleitApp) IsValidationExceptionCandidate(contractAddress string) bo
 }
 
 func (p *starfleitApp) UpdateParsers(tokenExceptions map[string]bool, height uint64) error {
+	mapperVersion, err := pairMapperVersion(p.chainId, height)
+	if err != nil {
+		return errors.Wrap(err, "updateParsers")
+	}
+	if !p.parsersVersion.Stale(len(p.pairs), mapperVersion, tokenExceptions) {
+		return nil
+	}
+
 	pairFilter := make(map[string]bool)
 	for k := range p.pairs {
 		pairFilter[k] = true
@@ -187,5 +197,6 @@ func (p *starfleitApp) UpdateParsers(tokenExceptions map[string]bool, height uin
 		p.Parsers.BurnParser = parser.NewParser(burnRule, dex.NewBurnMapper())
 	}
 
+	p.parsersVersion.Record(len(p.pairs), mapperVersion, tokenExceptions)
 	return nil
 }
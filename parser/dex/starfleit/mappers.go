@@ -38,23 +38,21 @@ func (m *createPairMapper) MatchedToParsedTx(res eventlog.MatchedResult, optiona
 		return nil, errors.Wrap(err, "createPairMapper.MatchedToParsedTx")
 	}
 	m.SortResult(res)
-	assets := strings.Split(res[sf.FactoryPairIdx].Value, "-")
-	if len(assets) != 2 {
-		msg := fmt.Sprintf("expected assets length(%d)", 2)
-		return nil, errors.New(msg)
+	assets, err := dex.SplitPairAssets(res[sf.FactoryPairIdx].Value, "-")
+	if err != nil {
+		return nil, errors.Wrap(err, "createPairMapper.MatchedToParsedTx")
 	}
 
-	return []*dex.ParsedTx{{
+	tx := &dex.ParsedTx{
 		Type:         dex.CreatePair,
 		Sender:       "",
 		ContractAddr: res[sf.FactoryPairAddrIdx].Value,
-		Assets: [2]dex.Asset{
-			{Addr: assets[0]},
-			{Addr: assets[1]},
-		},
-		LpAddr:   res[sf.FactoryLpAddrIdx].Value,
-		LpAmount: "",
-	}}, nil
+		LpAddr:       res[sf.FactoryLpAddrIdx].Value,
+		LpAmount:     "",
+		MsgIndex:     eventlog.MsgIndex(res),
+	}
+	tx.SetAssets(dex.EmptyAssets(assets))
+	return []*dex.ParsedTx{tx}, nil
 }
 
 // match implements mapper
@@ -158,10 +156,7 @@ func (m *transferMapper) MatchedToParsedTx(res eventlog.MatchedResult, optionals
 		return nil, errors.Wrap(err, "transferMapper.MatchedToParsedTx")
 	}
 
-	assets := [2]dex.Asset{
-		{Addr: pair.Assets[0]},
-		{Addr: pair.Assets[1]},
-	}
+	assets := dex.EmptyAssets(pair.Assets)
 	amountStrs := strings.Split(matchMap[pdex.TransferAmountKey].Value, ",")
 	if len(amountStrs) == 0 {
 		return nil, errors.New("empty amount or wrong format(amounts separated by ,)")
@@ -182,24 +177,23 @@ func (m *transferMapper) MatchedToParsedTx(res eventlog.MatchedResult, optionals
 		assets[idx] = asset
 	}
 
-	return []*dex.ParsedTx{{
+	tx := &dex.ParsedTx{
 		Type:         dex.Transfer,
 		Sender:       from,
 		ContractAddr: pair.ContractAddr,
-		Assets:       assets,
 		LpAddr:       "",
 		LpAmount:     "",
 		Meta: map[string]interface{}{
 			"recipient": to,
 		},
-	}}, nil
+		MsgIndex: eventlog.MsgIndex(res),
+	}
+	tx.SetAssets(assets)
+	return []*dex.ParsedTx{tx}, nil
 }
 
 func (*wasmTransferMapper) matchedToParsedTx(pair *dex.Pair, from, to, targetToken, amount string, isFromPair bool) ([]*dex.ParsedTx, error) {
-	assets := [2]dex.Asset{
-		{Addr: pair.Assets[0]},
-		{Addr: pair.Assets[1]},
-	}
+	assets := dex.EmptyAssets(pair.Assets)
 	idx := dex.IndexOf(pair.Assets, targetToken)
 	if idx == -1 {
 		msg := fmt.Sprintf("wrong asset(%s), pair(%s) assets(%s)", targetToken, pair.ContractAddr, pair.Assets)
@@ -211,17 +205,18 @@ func (*wasmTransferMapper) matchedToParsedTx(pair *dex.Pair, from, to, targetTok
 		assets[idx].Amount = amount
 	}
 
-	return []*dex.ParsedTx{{
+	tx := &dex.ParsedTx{
 		Type:         dex.Transfer,
 		Sender:       from,
 		ContractAddr: pair.ContractAddr,
-		Assets:       assets,
 		LpAddr:       "",
 		LpAmount:     "",
 		Meta: map[string]interface{}{
 			"recipient": to,
 		},
-	}}, nil
+	}
+	tx.SetAssets(assets)
+	return []*dex.ParsedTx{tx}, nil
 }
 
 func (*transferMapperMixin) pairBy(pairSet map[string]dex.Pair, from, to string) (*dex.Pair, bool, error) {
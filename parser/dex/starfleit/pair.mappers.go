@@ -38,23 +38,34 @@ type pairV2Mapper struct {
 	*pairMapperMixin
 }
 
-func pairMapperBy(chainId string, height uint64, pairSet map[string]dex.Pair) (parser.Mapper[dex.ParsedTx], error) {
-	base := &pairMapperMixin{pdex.MapperMixin{}, pairSet}
+// pairMapperVersion returns 1 before the v2 pair contracts of chainId were
+// deployed at height and 2 after.
+func pairMapperVersion(chainId string, height uint64) (int, error) {
 	if strings.HasPrefix(chainId, sf.TestnetPrefix) {
 		if height < sf.TestnetV2Height {
-			return &pairMapperImpl{base}, nil
-		} else {
-			return &pairMapperImpl{&pairV2Mapper{base}}, nil
+			return 1, nil
 		}
+		return 2, nil
 	} else if strings.HasPrefix(chainId, sf.MainnetPrefix) {
 		if height < sf.MainnetV2Height {
-			return &pairMapperImpl{base}, nil
-		} else {
-			return &pairMapperImpl{&pairV2Mapper{base}}, nil
+			return 1, nil
 		}
+		return 2, nil
 	}
 
-	return nil, errors.New("chainId is not supported")
+	return 0, errors.New("chainId is not supported")
+}
+
+func pairMapperBy(chainId string, height uint64, pairSet map[string]dex.Pair) (parser.Mapper[dex.ParsedTx], error) {
+	version, err := pairMapperVersion(chainId, height)
+	if err != nil {
+		return nil, err
+	}
+	base := &pairMapperMixin{pdex.MapperMixin{}, pairSet}
+	if version == 1 {
+		return &pairMapperImpl{base}, nil
+	}
+	return &pairMapperImpl{&pairV2Mapper{base}}, nil
 }
 
 func (m *pairMapperImpl) MatchedToParsedTx(res eventlog.MatchedResult, optionals ...interface{}) ([]*dex.ParsedTx, error) {
@@ -99,6 +110,7 @@ func (m *pairMapperMixin) swapMatchedToParsedTx(res eventlog.MatchedResult, pair
 	matchMap, err := eventlog.ResultToItemMapForKeys(
 		res,
 		pdex.PairSwapOfferAssetKey,
+		pdex.PairSwapAskAssetKey,
 		pdex.PairSwapOfferAmountKey,
 		pdex.PairSwapReturnAmountKey,
 		pdex.PairSwapSenderKey,
@@ -108,28 +120,26 @@ func (m *pairMapperMixin) swapMatchedToParsedTx(res eventlog.MatchedResult, pair
 		return nil, errors.Wrap(err, "pairMapperMixin.swapMatchedToParsedTx")
 	}
 
-	offerAsset := matchMap[pdex.PairSwapOfferAssetKey]
-	offerIdx := 0
-	if pair.Assets[1] == offerAsset.Value {
-		offerIdx = 1
-	}
-	returnIdx := (offerIdx + 1) % 2
-
-	assets := [2]dex.Asset{
-		{Addr: pair.Assets[0]},
-		{Addr: pair.Assets[1]},
+	assets, err := dex.SwapAssets(
+		pair,
+		matchMap[pdex.PairSwapOfferAssetKey].Value,
+		matchMap[pdex.PairSwapOfferAmountKey].Value,
+		matchMap[pdex.PairSwapAskAssetKey].Value,
+		matchMap[pdex.PairSwapReturnAmountKey].Value,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "pairMapperMixin.swapMatchedToParsedTx")
 	}
 
-	assets[offerIdx].Amount = matchMap[pdex.PairSwapOfferAmountKey].Value
-	assets[returnIdx].Amount = fmt.Sprintf("-%s", matchMap[pdex.PairSwapReturnAmountKey].Value)
-
-	return []*dex.ParsedTx{{
+	tx := &dex.ParsedTx{
 		Type:             dex.Swap,
 		Sender:           matchMap[pdex.PairSwapSenderKey].Value,
 		ContractAddr:     res[sf.PairAddrIdx].Value,
-		Assets:           assets,
 		CommissionAmount: matchMap[pdex.PairSwapCommissionAmountKey].Value,
-	}}, nil
+		MsgIndex:         eventlog.MsgIndex(res),
+	}
+	tx.SetAssets(assets)
+	return []*dex.ParsedTx{tx}, nil
 }
 
 func (m *pairMapperMixin) provideMatchedToParsedTx(res eventlog.MatchedResult, pair dex.Pair) ([]*dex.ParsedTx, error) {
@@ -142,18 +152,18 @@ func (m *pairMapperMixin) provideMatchedToParsedTx(res eventlog.MatchedResult, p
 		return nil, errors.Wrap(err, "pairMapper.provideMatchedToParsedTx")
 	}
 
-	if assets[0].Addr != pair.Assets[0] {
-		assets = []dex.Asset{assets[1], assets[0]}
-	}
+	assets = dex.SortAssetsByPair(pair, assets)
 
-	return []*dex.ParsedTx{{
+	tx := &dex.ParsedTx{
 		Type:         dex.Provide,
 		Sender:       res[sf.PairProvideSenderIdx].Value,
 		ContractAddr: res[sf.PairAddrIdx].Value,
-		Assets:       [2]dex.Asset{assets[0], assets[1]},
 		LpAddr:       pair.LpAddr,
 		LpAmount:     res[sf.PairProvideShareIdx].Value,
-	}}, nil
+		MsgIndex:     eventlog.MsgIndex(res),
+	}
+	tx.SetAssets(assets)
+	return []*dex.ParsedTx{tx}, nil
 }
 
 func (m *pairMapperMixin) withdrawMatchedToParsedTx(res eventlog.MatchedResult, pair dex.Pair) ([]*dex.ParsedTx, error) {
@@ -169,18 +179,18 @@ func (m *pairMapperMixin) withdrawMatchedToParsedTx(res eventlog.MatchedResult,
 		assets[idx].Amount = fmt.Sprintf("-%s", assets[idx].Amount)
 	}
 
-	if assets[0].Addr != pair.Assets[0] {
-		assets = []dex.Asset{assets[1], assets[0]}
-	}
+	assets = dex.SortAssetsByPair(pair, assets)
 
-	return []*dex.ParsedTx{{
+	tx := &dex.ParsedTx{
 		Type:         dex.Withdraw,
 		Sender:       res[sf.PairWithdrawSenderIdx].Value,
 		ContractAddr: res[sf.PairAddrIdx].Value,
-		Assets:       [2]dex.Asset{assets[0], assets[1]},
 		LpAddr:       pair.LpAddr,
 		LpAmount:     res[sf.PairWithdrawWithdrawShareIdx].Value,
-	}}, nil
+		MsgIndex:     eventlog.MsgIndex(res),
+	}
+	tx.SetAssets(assets)
+	return []*dex.ParsedTx{tx}, nil
 
 }
 
@@ -202,17 +212,17 @@ func (m *pairV2Mapper) provideMatchedToParsedTx(res eventlog.MatchedResult, pair
 		res[sf.PairV2RefundAssetsIdx].Key: refundAssets,
 	}
 
-	if assets[0].Addr != pair.Assets[0] {
-		assets = []dex.Asset{assets[1], assets[0]}
-	}
+	assets = dex.SortAssetsByPair(pair, assets)
 
-	return []*dex.ParsedTx{{
+	tx := &dex.ParsedTx{
 		Type:         dex.Provide,
 		Sender:       res[sf.PairV2ProvideSenderIdx].Value,
 		ContractAddr: res[sf.PairAddrIdx].Value,
-		Assets:       [2]dex.Asset{assets[0], assets[1]},
 		LpAddr:       pair.LpAddr,
 		LpAmount:     res[sf.PairV2ProvideShareIdx].Value,
 		Meta:         meta,
-	}}, nil
+		MsgIndex:     eventlog.MsgIndex(res),
+	}
+	tx.SetAssets(assets)
+	return []*dex.ParsedTx{tx}, nil
 }
@@ -21,6 +21,8 @@ type terraswapApp struct {
 	// state
 	pairs         map[string]p_dex.Pair
 	flaggedAssets map[string]bool
+
+	parsersVersion p_dex.ParsersVersion
 }
 
 var _ p_dex.TargetApp = &terraswapApp{}
@@ -44,7 +46,7 @@ func New(repo p_dex.PairRepo, logger logging.Logger, c configs.ParserDexConfig)
 		return nil, errors.Wrap(err, "columbusv1.New")
 	}
 
-	return &terraswapApp{repo, &parsers, p_dex.DexMixin{}, pairs, make(map[string]bool)}, nil
+	return &terraswapApp{repo, &parsers, p_dex.DexMixin{}, pairs, make(map[string]bool), p_dex.ParsersVersion{}}, nil
 }
 
 func (p *terraswapApp) ParseTxs(tx parser.RawTx, height uint64) ([]p_dex.ParsedTx, error) {
@@ -58,7 +60,7 @@ func (p *terraswapApp) ParseTxs(tx parser.RawTx, height uint64) ([]p_dex.ParsedT
 		p.pairs[ctx.ContractAddr] = p_dex.Pair{
 			ContractAddr: ctx.ContractAddr,
 			LpAddr:       ctx.LpAddr,
-			Assets:       []string{ctx.Assets[0].Addr, ctx.Assets[1].Addr},
+			Assets:       ctx.AssetAddrs(),
 		}
 		ctx.Sender = tx.Sender
 		txDtos = append(txDtos, *ctx)
@@ -118,6 +120,10 @@ func (p *terraswapApp) IsValidationExceptionCandidate(contractAddress string) bo
 }
 
 func (p *terraswapApp) UpdateParsers(tokenExceptions map[string]bool, height uint64) error {
+	if !p.parsersVersion.Stale(len(p.pairs), 0, tokenExceptions) {
+		return nil
+	}
+
 	pairFilter := make(map[string]bool)
 	for k := range p.pairs {
 		pairFilter[k] = true
@@ -148,5 +154,6 @@ func (p *terraswapApp) UpdateParsers(tokenExceptions map[string]bool, height uin
 		return errors.Wrap(err, "createParsers")
 	}
 	p.Parsers.Transfer = parser.NewParser[p_dex.ParsedTx](transferRule, p_dex.NewTransferMapper(p.pairs))
+	p.parsersVersion.Record(len(p.pairs), 0, tokenExceptions)
 	return nil
 }
@@ -50,18 +50,12 @@ func (m *pairMapper) swapMatchedToParsedTx(res eventlog.MatchedResult, pair dex.
 		return nil, errors.Wrap(err, "pairMapper.swapMatchedToParsedTx")
 	}
 
-	offerAsset := res[cv1.PairSwapOfferAssetIdx].Value
-	offerIdx := 0
-	if pair.Assets[1] == offerAsset {
-		offerIdx = 1
-	}
-	returnIdx := (offerIdx + 1) % 2
-
-	assets := [2]dex.Asset{
-		{Addr: pair.Assets[0]},
-		{Addr: pair.Assets[1]},
+	offerIdx, returnIdx, err := dex.SwapAssetIndexes(pair, res[cv1.PairSwapOfferAssetIdx].Value, res[cv1.PairSwapAskAssetIdx].Value)
+	if err != nil {
+		return nil, errors.Wrap(err, "pairMapper.swapMatchedToParsedTx")
 	}
 
+	assets := dex.EmptyAssets(pair.Assets)
 	assets[offerIdx].Amount = res[cv1.PairSwapOfferAmountIdx].Value
 	assets[returnIdx].Amount = fmt.Sprintf("-%s", res[cv1.PairSwapReturnAmountIdx].Value)
 
@@ -69,10 +63,9 @@ func (m *pairMapper) swapMatchedToParsedTx(res eventlog.MatchedResult, pair dex.
 		return nil, errors.Wrap(err, "pairMapper.swapMatchedToParsedTx")
 	}
 
-	return []*dex.ParsedTx{{
+	tx := &dex.ParsedTx{
 		Type:             dex.Swap,
 		ContractAddr:     res[cv1.PairAddrIdx].Value,
-		Assets:           assets,
 		CommissionAmount: res[cv1.PairSwapCommissionAmountIdx].Value,
 		Meta: map[string]interface{}{
 			res[cv1.PairSwapTaxAmountIdx].Key: dex.Asset{
@@ -80,7 +73,10 @@ func (m *pairMapper) swapMatchedToParsedTx(res eventlog.MatchedResult, pair dex.
 				Amount: res[cv1.PairSwapTaxAmountIdx].Value,
 			},
 		},
-	}}, nil
+		MsgIndex: eventlog.MsgIndex(res),
+	}
+	tx.SetAssets(assets)
+	return []*dex.ParsedTx{tx}, nil
 }
 
 func (m *pairMapper) provideMatchedToParsedTx(res eventlog.MatchedResult, pair dex.Pair) ([]*dex.ParsedTx, error) {
@@ -93,17 +89,17 @@ func (m *pairMapper) provideMatchedToParsedTx(res eventlog.MatchedResult, pair d
 		return nil, errors.Wrap(err, "pairMapper.provideMatchedToParsedTx")
 	}
 
-	if assets[0].Addr != pair.Assets[0] {
-		assets = []dex.Asset{assets[1], assets[0]}
-	}
+	assets = dex.SortAssetsByPair(pair, assets)
 
-	return []*dex.ParsedTx{{
+	tx := &dex.ParsedTx{
 		Type:         dex.Provide,
 		ContractAddr: res[cv1.PairAddrIdx].Value,
-		Assets:       [2]dex.Asset{assets[0], assets[1]},
 		LpAddr:       pair.LpAddr,
 		LpAmount:     res[cv1.PairProvideShareIdx].Value,
-	}}, nil
+		MsgIndex:     eventlog.MsgIndex(res),
+	}
+	tx.SetAssets(assets)
+	return []*dex.ParsedTx{tx}, nil
 }
 
 func (m *pairMapper) withdrawMatchedToParsedTx(res eventlog.MatchedResult, pair dex.Pair) ([]*dex.ParsedTx, error) {
@@ -119,19 +115,19 @@ func (m *pairMapper) withdrawMatchedToParsedTx(res eventlog.MatchedResult, pair
 		assets[idx].Amount = fmt.Sprintf("-%s", assets[idx].Amount)
 	}
 
-	if assets[0].Addr != pair.Assets[0] {
-		assets = []dex.Asset{assets[1], assets[0]}
-	}
+	assets = dex.SortAssetsByPair(pair, assets)
 
-	return []*dex.ParsedTx{{
+	tx := &dex.ParsedTx{
 		Type:         dex.Withdraw,
 		ContractAddr: res[cv1.PairAddrIdx].Value,
-		Assets:       [2]dex.Asset{{Addr: assets[0].Addr, Amount: "0"}, {Addr: assets[1].Addr, Amount: "0"}},
 		LpAddr:       pair.LpAddr,
 		LpAmount:     res[cv1.PairWithdrawWithdrawShareIdx].Value,
 		Meta: map[string]interface{}{
 			"withdraw_assets": assets,
 		},
-	}}, nil
+		MsgIndex: eventlog.MsgIndex(res),
+	}
+	tx.SetAssets(dex.ZeroAssets(assets))
+	return []*dex.ParsedTx{tx}, nil
 
 }
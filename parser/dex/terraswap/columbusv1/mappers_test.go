@@ -31,7 +31,7 @@ func Test_PairMapper(t *testing.T) {
 				{Key: "tax_amount", Value: "0"},
 				{Key: "spread_amount", Value: "2"}, {Key: "commission_amount", Value: "302"},
 			},
-			[]*dex.ParsedTx{{"", time.Time{}, dex.Swap, "", pair.ContractAddr, [2]dex.Asset{{pair.Assets[0], "100000"}, {pair.Assets[1], "-100583"}}, "", "", "302", 0, map[string]interface{}{"tax_amount": dex.Asset{pair.Assets[1], "0"}}}},
+			[]*dex.ParsedTx{{"", time.Time{}, dex.Swap, "", pair.ContractAddr, [2]dex.Asset{{pair.Assets[0], "100000"}, {pair.Assets[1], "-100583"}}, nil, "", "", "302", 0, map[string]interface{}{"tax_amount": dex.Asset{pair.Assets[1], "0"}}}},
 			"",
 		},
 		{
@@ -44,7 +44,7 @@ func Test_PairMapper(t *testing.T) {
 				{Key: "tax_amount", Value: "583"},
 				{Key: "spread_amount", Value: "2"}, {Key: "commission_amount", Value: "300"},
 			},
-			[]*dex.ParsedTx{{"", time.Time{}, dex.Swap, "", pair.ContractAddr, [2]dex.Asset{{pair.Assets[0], "-100000"}, {pair.Assets[1], "100000"}}, "", "", "300", 0, map[string]interface{}{"tax_amount": dex.Asset{pair.Assets[0], "583"}}}},
+			[]*dex.ParsedTx{{"", time.Time{}, dex.Swap, "", pair.ContractAddr, [2]dex.Asset{{pair.Assets[0], "-100000"}, {pair.Assets[1], "100000"}}, nil, "", "", "300", 0, map[string]interface{}{"tax_amount": dex.Asset{pair.Assets[0], "583"}}}},
 			"",
 		},
 		{
@@ -64,7 +64,7 @@ func Test_PairMapper(t *testing.T) {
 				{Key: "assets", Value: fmt.Sprintf("%s%s, %s%s", "1000", pair.Assets[0], "10000", pair.Assets[1])},
 				{Key: "share", Value: "998735"},
 			},
-			[]*dex.ParsedTx{{"", time.Time{}, dex.Provide, "", pair.ContractAddr, [2]dex.Asset{{pair.Assets[0], "1000"}, {pair.Assets[1], "10000"}}, pair.LpAddr, "998735", "", 0, nil}},
+			[]*dex.ParsedTx{{"", time.Time{}, dex.Provide, "", pair.ContractAddr, [2]dex.Asset{{pair.Assets[0], "1000"}, {pair.Assets[1], "10000"}}, nil, pair.LpAddr, "998735", "", 0, nil}},
 			"",
 		},
 		{
@@ -87,7 +87,7 @@ func Test_PairMapper(t *testing.T) {
 				{Key: "withdrawn_share", Value: "12418119"},
 				{Key: "refund_assets", Value: fmt.Sprintf("%s%s, %s%s", "24999998", pair.Assets[0], "24939789", pair.Assets[1])},
 			},
-			[]*dex.ParsedTx{{"", time.Time{}, dex.Withdraw, "", pair.ContractAddr, [2]dex.Asset{{pair.Assets[0], "0"}, {pair.Assets[1], "0"}}, pair.LpAddr, "12418119", "", 0, map[string]interface{}{"withdraw_assets": []dex.Asset{{pair.Assets[0], "-24999998"}, {pair.Assets[1], "-24939789"}}}}},
+			[]*dex.ParsedTx{{"", time.Time{}, dex.Withdraw, "", pair.ContractAddr, [2]dex.Asset{{pair.Assets[0], "0"}, {pair.Assets[1], "0"}}, nil, pair.LpAddr, "12418119", "", 0, map[string]interface{}{"withdraw_assets": []dex.Asset{{pair.Assets[0], "-24999998"}, {pair.Assets[1], "-24939789"}}}}},
 			"",
 		},
 		{
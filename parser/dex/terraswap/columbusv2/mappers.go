@@ -46,6 +46,7 @@ func (m *pairMapper) swapMatchedToParsedTx(res eventlog.MatchedResult, pair dex.
 		res,
 		columbusv2.PairAddrKey,
 		pdex.PairSwapOfferAssetKey,
+		pdex.PairSwapAskAssetKey,
 		pdex.PairSwapOfferAmountKey,
 		pdex.PairSwapReturnAmountKey,
 		pdex.PairSwapSenderKey,
@@ -56,18 +57,12 @@ func (m *pairMapper) swapMatchedToParsedTx(res eventlog.MatchedResult, pair dex.
 		return nil, errors.Wrap(err, "pairMapper.swapMatchedToParsedTx")
 	}
 
-	offerAsset := matchMap[pdex.PairSwapOfferAssetKey].Value
-	offerIdx := 0
-	if pair.Assets[1] == offerAsset {
-		offerIdx = 1
-	}
-	returnIdx := (offerIdx + 1) % 2
-
-	assets := [2]dex.Asset{
-		{Addr: pair.Assets[0]},
-		{Addr: pair.Assets[1]},
+	offerIdx, returnIdx, err := dex.SwapAssetIndexes(pair, matchMap[pdex.PairSwapOfferAssetKey].Value, matchMap[pdex.PairSwapAskAssetKey].Value)
+	if err != nil {
+		return nil, errors.Wrap(err, "pairMapper.swapMatchedToParsedTx")
 	}
 
+	assets := dex.EmptyAssets(pair.Assets)
 	assets[offerIdx].Amount = matchMap[pdex.PairSwapOfferAmountKey].Value
 	assets[returnIdx].Amount = fmt.Sprintf("-%s", matchMap[pdex.PairSwapReturnAmountKey].Value)
 
@@ -78,15 +73,16 @@ func (m *pairMapper) swapMatchedToParsedTx(res eventlog.MatchedResult, pair dex.
 		}
 	}
 
-	return []*dex.ParsedTx{{
+	tx := &dex.ParsedTx{
 		Type:             dex.Swap,
 		ContractAddr:     matchMap[columbusv2.PairAddrKey].Value,
 		Sender:           matchMap[pdex.PairSwapSenderKey].Value,
-		Assets:           assets,
 		CommissionAmount: matchMap[pdex.PairSwapCommissionAmountKey].Value,
 		MsgIndex:         eventlog.MsgIndex(res),
 		Meta:             nil,
-	}}, nil
+	}
+	tx.SetAssets(assets)
+	return []*dex.ParsedTx{tx}, nil
 }
 
 func (m *pairMapper) provideMatchedToParsedTx(res eventlog.MatchedResult, pair dex.Pair) ([]*dex.ParsedTx, error) {
@@ -106,9 +102,7 @@ func (m *pairMapper) provideMatchedToParsedTx(res eventlog.MatchedResult, pair d
 	if err != nil {
 		return nil, errors.Wrap(err, "pairMapper.provideMatchedToParsedTx")
 	}
-	if assets[0].Addr != pair.Assets[0] {
-		assets = []dex.Asset{assets[1], assets[0]}
-	}
+	assets = dex.SortAssetsByPair(pair, assets)
 
 	meta := map[string]interface{}{}
 	refundItem, ok := matchMap[columbusv2.PairProvideRefundAssetKey]
@@ -117,9 +111,7 @@ func (m *pairMapper) provideMatchedToParsedTx(res eventlog.MatchedResult, pair d
 		if err != nil {
 			return nil, errors.Wrap(err, "pairMapper.provideMatchedToParsedTx")
 		}
-		if refundAssets[0].Addr != pair.Assets[0] {
-			refundAssets = []dex.Asset{refundAssets[1], refundAssets[0]}
-		}
+		refundAssets = dex.SortAssetsByPair(pair, refundAssets)
 
 		assets, err = m.applyRefundAsset(assets, refundAssets)
 		if err != nil {
@@ -128,15 +120,16 @@ func (m *pairMapper) provideMatchedToParsedTx(res eventlog.MatchedResult, pair d
 		meta[columbusv2.PairProvideRefundAssetKey] = refundAssets
 	}
 
-	return []*dex.ParsedTx{{
+	tx := &dex.ParsedTx{
 		Type:         dex.Provide,
 		ContractAddr: matchMap[columbusv2.PairAddrKey].Value,
 		Sender:       matchMap[columbusv2.PairProvideSenderKey].Value,
-		Assets:       [2]dex.Asset(assets),
 		LpAddr:       pair.LpAddr,
 		LpAmount:     matchMap[columbusv2.PairProvideShareKey].Value,
 		MsgIndex:     eventlog.MsgIndex(res),
-	}}, nil
+	}
+	tx.SetAssets(assets)
+	return []*dex.ParsedTx{tx}, nil
 }
 
 func (m *pairMapper) withdrawMatchedToParsedTx(res eventlog.MatchedResult, pair dex.Pair) ([]*dex.ParsedTx, error) {
@@ -159,22 +152,21 @@ func (m *pairMapper) withdrawMatchedToParsedTx(res eventlog.MatchedResult, pair
 		assets[idx].Amount = fmt.Sprintf("-%s", assets[idx].Amount)
 	}
 
-	if assets[0].Addr != pair.Assets[0] {
-		assets = []dex.Asset{assets[1], assets[0]}
-	}
+	assets = dex.SortAssetsByPair(pair, assets)
 
-	return []*dex.ParsedTx{{
+	tx := &dex.ParsedTx{
 		Type:         dex.Withdraw,
 		ContractAddr: matchMap[columbusv2.PairAddrKey].Value,
 		Sender:       matchMap[columbusv2.PairWithdrawSenderKey].Value,
-		Assets:       [2]dex.Asset{{Addr: assets[0].Addr, Amount: "0"}, {Addr: assets[1].Addr, Amount: "0"}},
 		LpAddr:       pair.LpAddr,
 		LpAmount:     matchMap[columbusv2.PairWithdrawWithdrawShareKey].Value,
 		MsgIndex:     eventlog.MsgIndex(res),
 		Meta: map[string]interface{}{
 			"withdraw_assets": assets,
 		},
-	}}, nil
+	}
+	tx.SetAssets(dex.ZeroAssets(assets))
+	return []*dex.ParsedTx{tx}, nil
 
 }
 
@@ -21,6 +21,8 @@ type terraswapApp struct {
 	pairs         map[string]dex.Pair
 	lpPairAddrs   map[string]string
 	flaggedAssets map[string]bool
+
+	parsersVersion dex.ParsersVersion
 }
 
 var _ dex.TargetApp = &terraswapApp{}
@@ -49,7 +51,7 @@ func New(repo dex.PairRepo, logger logging.Logger, c configs.ParserDexConfig) (d
 		lpPairAddrs[p.LpAddr] = p.ContractAddr
 	}
 
-	return &terraswapApp{repo, &parsers, dex.DexMixin{}, pairs, lpPairAddrs, make(map[string]bool)}, nil
+	return &terraswapApp{repo, &parsers, dex.DexMixin{}, pairs, lpPairAddrs, make(map[string]bool), dex.ParsersVersion{}}, nil
 }
 
 func (p *terraswapApp) ParseTxs(tx parser.RawTx, height uint64) ([]dex.ParsedTx, error) {
@@ -63,7 +65,7 @@ func (p *terraswapApp) ParseTxs(tx parser.RawTx, height uint64) ([]dex.ParsedTx,
 		p.pairs[ctx.ContractAddr] = dex.Pair{
 			ContractAddr: ctx.ContractAddr,
 			LpAddr:       ctx.LpAddr,
-			Assets:       []string{ctx.Assets[0].Addr, ctx.Assets[1].Addr},
+			Assets:       ctx.AssetAddrs(),
 		}
 		p.lpPairAddrs[ctx.LpAddr] = ctx.ContractAddr
 		ctx.Sender = tx.Sender
@@ -142,6 +144,10 @@ func (p *terraswapApp) IsValidationExceptionCandidate(contractAddress string) bo
 }
 
 func (p *terraswapApp) UpdateParsers(tokenExceptions map[string]bool, height uint64) error {
+	if !p.parsersVersion.Stale(len(p.pairs), 0, tokenExceptions) {
+		return nil
+	}
+
 	pairFilter := make(map[string]bool)
 	for k := range p.pairs {
 		pairFilter[k] = true
@@ -187,5 +193,6 @@ func (p *terraswapApp) UpdateParsers(tokenExceptions map[string]bool, height uin
 		p.Parsers.BurnParser = parser.NewParser(burnRule, dex.NewBurnMapper())
 	}
 
+	p.parsersVersion.Record(len(p.pairs), 0, tokenExceptions)
 	return nil
 }
@@ -46,6 +46,7 @@ func (m *pairMapper) swapMatchedToParsedTx(res eventlog.MatchedResult, pair dex.
 		res,
 		phoenix.PairAddrKey,
 		pdex.PairSwapOfferAssetKey,
+		pdex.PairSwapAskAssetKey,
 		pdex.PairSwapOfferAmountKey,
 		pdex.PairSwapReturnAmountKey,
 		pdex.PairSwapSenderKey,
@@ -55,29 +56,27 @@ func (m *pairMapper) swapMatchedToParsedTx(res eventlog.MatchedResult, pair dex.
 		return nil, errors.Wrap(err, "pairMapper.swapMatchedToParsedTx")
 	}
 
-	offerAsset := matchMap[pdex.PairSwapOfferAssetKey].Value
-	offerIdx := 0
-	if pair.Assets[1] == offerAsset {
-		offerIdx = 1
-	}
-	returnIdx := (offerIdx + 1) % 2
-
-	assets := [2]dex.Asset{
-		{Addr: pair.Assets[0]},
-		{Addr: pair.Assets[1]},
+	assets, err := dex.SwapAssets(
+		pair,
+		matchMap[pdex.PairSwapOfferAssetKey].Value,
+		matchMap[pdex.PairSwapOfferAmountKey].Value,
+		matchMap[pdex.PairSwapAskAssetKey].Value,
+		matchMap[pdex.PairSwapReturnAmountKey].Value,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "pairMapper.swapMatchedToParsedTx")
 	}
 
-	assets[offerIdx].Amount = matchMap[pdex.PairSwapOfferAmountKey].Value
-	assets[returnIdx].Amount = fmt.Sprintf("-%s", matchMap[pdex.PairSwapReturnAmountKey].Value)
-
-	return []*dex.ParsedTx{{
+	tx := &dex.ParsedTx{
 		Type:             dex.Swap,
 		ContractAddr:     matchMap[phoenix.PairAddrKey].Value,
 		Sender:           matchMap[pdex.PairSwapSenderKey].Value,
-		Assets:           assets,
 		CommissionAmount: matchMap[pdex.PairSwapCommissionAmountKey].Value,
 		Meta:             nil,
-	}}, nil
+		MsgIndex:         eventlog.MsgIndex(res),
+	}
+	tx.SetAssets(assets)
+	return []*dex.ParsedTx{tx}, nil
 }
 
 func (m *pairMapper) provideMatchedToParsedTx(res eventlog.MatchedResult, pair dex.Pair) ([]*dex.ParsedTx, error) {
@@ -97,9 +96,7 @@ func (m *pairMapper) provideMatchedToParsedTx(res eventlog.MatchedResult, pair d
 	if err != nil {
 		return nil, errors.Wrap(err, "pairMapper.provideMatchedToParsedTx")
 	}
-	if assets[0].Addr != pair.Assets[0] {
-		assets = []dex.Asset{assets[1], assets[0]}
-	}
+	assets = dex.SortAssetsByPair(pair, assets)
 
 	meta := map[string]interface{}{}
 	refundItem, ok := matchMap[phoenix.PairProvideRefundAssetKey]
@@ -108,9 +105,7 @@ func (m *pairMapper) provideMatchedToParsedTx(res eventlog.MatchedResult, pair d
 		if err != nil {
 			return nil, errors.Wrap(err, "pairMapper.provideMatchedToParsedTx")
 		}
-		if refundAssets[0].Addr != pair.Assets[0] {
-			refundAssets = []dex.Asset{refundAssets[1], refundAssets[0]}
-		}
+		refundAssets = dex.SortAssetsByPair(pair, refundAssets)
 
 		assets, err = m.applyRefundAsset(assets, refundAssets)
 		if err != nil {
@@ -119,14 +114,16 @@ func (m *pairMapper) provideMatchedToParsedTx(res eventlog.MatchedResult, pair d
 		meta[phoenix.PairProvideRefundAssetKey] = refundAssets
 	}
 
-	return []*dex.ParsedTx{{
+	tx := &dex.ParsedTx{
 		Type:         dex.Provide,
 		ContractAddr: matchMap[phoenix.PairAddrKey].Value,
 		Sender:       matchMap[phoenix.PairProvideSenderKey].Value,
-		Assets:       [2]dex.Asset(assets),
 		LpAddr:       pair.LpAddr,
 		LpAmount:     matchMap[phoenix.PairProvideShareKey].Value,
-	}}, nil
+		MsgIndex:     eventlog.MsgIndex(res),
+	}
+	tx.SetAssets(assets)
+	return []*dex.ParsedTx{tx}, nil
 }
 
 func (m *pairMapper) withdrawMatchedToParsedTx(res eventlog.MatchedResult, pair dex.Pair) ([]*dex.ParsedTx, error) {
@@ -149,18 +146,18 @@ func (m *pairMapper) withdrawMatchedToParsedTx(res eventlog.MatchedResult, pair
 		assets[idx].Amount = fmt.Sprintf("-%s", assets[idx].Amount)
 	}
 
-	if assets[0].Addr != pair.Assets[0] {
-		assets = []dex.Asset{assets[1], assets[0]}
-	}
+	assets = dex.SortAssetsByPair(pair, assets)
 
-	return []*dex.ParsedTx{{
+	tx := &dex.ParsedTx{
 		Type:         dex.Withdraw,
 		ContractAddr: matchMap[phoenix.PairAddrKey].Value,
 		Sender:       matchMap[phoenix.PairWithdrawSenderKey].Value,
-		Assets:       [2]dex.Asset{assets[0], assets[1]},
 		LpAddr:       pair.LpAddr,
 		LpAmount:     matchMap[phoenix.PairWithdrawWithdrawShareKey].Value,
-	}}, nil
+		MsgIndex:     eventlog.MsgIndex(res),
+	}
+	tx.SetAssets(assets)
+	return []*dex.ParsedTx{tx}, nil
 
 }
 
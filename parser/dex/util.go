@@ -22,12 +22,14 @@ func IndexOf[T comparable](slice []T, target T) int {
 	return -1
 }
 
+// GetAssetsFromAssetsString parses the comma separated assets of a pool with
+// two or more assets.
 func GetAssetsFromAssetsString(amountsAssets string) ([]Asset, error) {
 	assets := strings.Split(amountsAssets, ",")
 	for i, a := range assets {
 		assets[i] = strings.TrimSpace(a)
 	}
-	if len(assets) != 2 {
+	if len(assets) < 2 {
 		return nil, errors.New(fmt.Sprintf("wrong format of assetsAmount(%s)", amountsAssets))
 	}
 
@@ -42,6 +44,77 @@ func GetAssetsFromAssetsString(amountsAssets string) ([]Asset, error) {
 	return res, nil
 }
 
+// SplitPairAssets splits the assets of a create_pair event joined by sep.
+func SplitPairAssets(pairAssets string, sep string) ([]string, error) {
+	assets := strings.Split(pairAssets, sep)
+	if len(assets) < 2 {
+		return nil, errors.New(fmt.Sprintf("expected at least 2 assets in pair(%s)", pairAssets))
+	}
+	return assets, nil
+}
+
+// EmptyAssets returns the assets of addrs without amounts.
+func EmptyAssets(addrs []string) []Asset {
+	return pairAssets(Pair{Assets: addrs})
+}
+
+// ZeroAssets returns the assets of assets with zero amounts.
+func ZeroAssets(assets []Asset) []Asset {
+	zeros := make([]Asset, 0, len(assets))
+	for _, asset := range assets {
+		zeros = append(zeros, Asset{Addr: asset.Addr, Amount: "0"})
+	}
+	return zeros
+}
+
+// SortAssetsByPair returns assets in the order of the pair assets. Pair assets
+// missing from assets have an empty amount, and assets the pair does not hold
+// follow in their given order.
+func SortAssetsByPair(pair Pair, assets []Asset) []Asset {
+	sorted := pairAssets(pair)
+	for _, asset := range assets {
+		idx := IndexOf(pair.Assets, asset.Addr)
+		if idx == -1 {
+			sorted = append(sorted, asset)
+			continue
+		}
+		sorted[idx] = asset
+	}
+	return sorted
+}
+
+// SwapAssetIndexes returns the pair indexes of the offer and the ask asset.
+// Two asset pairs swap for the other asset, so askAsset may be empty there.
+func SwapAssetIndexes(pair Pair, offerAsset, askAsset string) (int, int, error) {
+	if len(pair.Assets) == 2 {
+		offerIdx := 0
+		if pair.Assets[1] == offerAsset {
+			offerIdx = 1
+		}
+		return offerIdx, (offerIdx + 1) % 2, nil
+	}
+
+	offerIdx, askIdx := IndexOf(pair.Assets, offerAsset), IndexOf(pair.Assets, askAsset)
+	if offerIdx == -1 || askIdx == -1 || offerIdx == askIdx {
+		return 0, 0, errors.New(fmt.Sprintf("swap of offer(%s) for ask(%s) is not in pair(%s) assets(%s)", offerAsset, askAsset, pair.ContractAddr, pair.Assets))
+	}
+	return offerIdx, askIdx, nil
+}
+
+// SwapAssets returns the pair assets with offerAmount at the offer asset and
+// the negated returnAmount at the ask asset.
+func SwapAssets(pair Pair, offerAsset, offerAmount, askAsset, returnAmount string) ([]Asset, error) {
+	offerIdx, askIdx, err := SwapAssetIndexes(pair, offerAsset, askAsset)
+	if err != nil {
+		return nil, err
+	}
+
+	assets := pairAssets(pair)
+	assets[offerIdx].Amount = offerAmount
+	assets[askIdx].Amount = fmt.Sprintf("-%s", returnAmount)
+	return assets, nil
+}
+
 func GetAssetFromAmountAssetString(amountAsset string) (Asset, error) {
 	amountAsset = strings.TrimSpace(amountAsset)
 	regex, _ := regexp.Compile(`\d+`)
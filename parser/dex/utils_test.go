@@ -21,6 +21,7 @@ func Test_GetAssetsFromAssetsString(t *testing.T) {
 		{"1000, 1000b", nil, "wrong asset0 format"},
 		{"10001000b", nil, "wrong format"},
 		{"1000aaaa,1000b", []Asset{{"aaaa", "1000"}, {"b", "1000"}}, ""},
+		{"1a, 2b, 3c", []Asset{{"a", "1"}, {"b", "2"}, {"c", "3"}}, ""},
 	}
 	assert := assert.New(t)
 
@@ -36,6 +37,27 @@ func Test_GetAssetsFromAssetsString(t *testing.T) {
 	}
 }
 
+func Test_SwapAssets(t *testing.T) {
+	pair := Pair{ContractAddr: "pair", Assets: []string{"a", "b", "c"}}
+
+	assets, err := SwapAssets(pair, "c", "10", "a", "9")
+	assert.NoError(t, err)
+	assert.Equal(t, []Asset{{"a", "-9"}, {"b", ""}, {"c", "10"}}, assets)
+
+	_, err = SwapAssets(pair, "c", "10", "", "9")
+	assert.Error(t, err)
+
+	assets, err = SwapAssets(Pair{Assets: []string{"a", "b"}}, "b", "10", "", "9")
+	assert.NoError(t, err)
+	assert.Equal(t, []Asset{{"a", "-9"}, {"b", "10"}}, assets)
+}
+
+func Test_SortAssetsByPair(t *testing.T) {
+	pair := Pair{Assets: []string{"a", "b", "c"}}
+
+	assert.Equal(t, []Asset{{"a", "1"}, {"b", ""}, {"c", "3"}, {"d", "4"}}, SortAssetsByPair(pair, []Asset{{"d", "4"}, {"c", "3"}, {"a", "1"}}))
+}
+
 func Test_GetAssetFromAssetAmountString(t *testing.T) {
 	tcs := []struct {
 		amountAsset string
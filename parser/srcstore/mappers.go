@@ -1,6 +1,8 @@
 package srcstore
 
 import (
+	"strconv"
+
 	"github.com/aws/smithy-go/time"
 	"github.com/cometbft/cometbft/abci/types"
 	"github.com/dezswap/cosmwasm-etl/collector/datastore"
@@ -8,6 +10,10 @@ import (
 	"github.com/dezswap/cosmwasm-etl/pkg/eventlog"
 )
 
+// msgIndexKey is the attribute cosmos-sdk tags the events of a message with
+// since v0.50.
+const msgIndexKey = "msg_index"
+
 type Mapper interface {
 	BlockToRawTxs(block *datastore.BlockTxsDTO) parser.RawTxs
 	TxToParserRawTx(rawTx datastore.TxDTO) parser.RawTx
@@ -63,11 +69,13 @@ func groupLogAttrByType(events []types.Event) map[eventlog.LogType]eventlog.Attr
 	logResultMap := make(map[eventlog.LogType]eventlog.Attributes)
 
 	for _, event := range events {
+		msgIndex := eventMsgIndex(event)
 		attributes := eventlog.Attributes{}
 		for _, attr := range event.Attributes {
 			attributes = append(attributes, eventlog.Attribute{
-				Key:   string(attr.Key),
-				Value: string(attr.Value),
+				Key:      string(attr.Key),
+				Value:    string(attr.Value),
+				MsgIndex: msgIndex,
 			})
 		}
 		logType := eventlog.LogType(event.Type)
@@ -79,3 +87,17 @@ func groupLogAttrByType(events []types.Event) map[eventlog.LogType]eventlog.Attr
 
 	return logResultMap
 }
+
+// eventMsgIndex returns the index of the message that emitted event, 0 when
+// the chain does not tag events with it.
+func eventMsgIndex(event types.Event) int {
+	for _, attr := range event.Attributes {
+		if string(attr.Key) != msgIndexKey {
+			continue
+		}
+		if idx, err := strconv.Atoi(string(attr.Value)); err == nil {
+			return idx
+		}
+	}
+	return 0
+}
@@ -142,7 +142,7 @@ func (r *readRepoImpl) LastHeightOfPrice() (uint64, error) {
 
 func (r *readRepoImpl) GetParsedTxsWithLimit(startHeight uint64, limit int) ([]schemas.ParsedTxWithPrice, error) {
 	query := `
-select p.id pair_id, pt.chain_id, pt.asset0_amount, pt.asset1_amount,
+select p.id pair_id, pt.chain_id, pt.asset0_amount, pt.asset1_amount, pt.extra_asset_amounts,
        pt.commission0_amount, pt.commission1_amount, pt.height, pt.timestamp
 from parsed_tx pt join pair p on pt.chain_id = p.chain_id and pt.contract = p.contract
 where pt.chain_id = ?
@@ -385,6 +385,11 @@ group by pair_id
 		asset1StatsMap[s.PairId] = s
 	}
 
+	extraStatsMap, err := r.extraAssetStats(startTs, endTs)
+	if err != nil {
+		return nil, errors.Wrap(err, "readRepoImpl.PairStats")
+	}
+
 	for _, asset0 := range asset0Stats {
 		if asset1, ok := asset1StatsMap[asset0.PairId]; ok {
 			lastVolume0, err := util.ExponentToDecimal(asset0.LastSwapPrice)
@@ -434,6 +439,8 @@ group by pair_id
 				Commission1:        asset1.Commission1,
 				Commission0InPrice: asset0.Commission0InPrice,
 				Commission1InPrice: asset1.Commission1InPrice,
+				ExtraVolumes:       extraStatsMap[asset0.PairId].ExtraVolumes,
+				ExtraCommissions:   extraStatsMap[asset0.PairId].ExtraCommissions,
 				PriceToken:         priceToken,
 				TxCnt:              asset0.TxCnt,
 				ProviderCnt:        asset0.ProviderCnt,
@@ -445,6 +452,45 @@ group by pair_id
 	return
 }
 
+// extraAssetStats sums the swap volumes and commissions of the extra assets
+// of pools with more than two assets by their position. The stats of two asset
+// pools are left out.
+func (r *readRepoImpl) extraAssetStats(startTs float64, endTs float64) (map[uint64]schemas.PairStats30m, error) {
+	query := `
+select p.id pair_id,
+       e.idx,
+       coalesce(sum(abs(e.amount)) filter (where pt.type = 'swap'), 0) as volume,
+       coalesce(sum(e.commission), 0) as commission
+from parsed_tx pt
+    join pair p on pt.chain_id = p.chain_id and pt.contract = p.contract
+    cross join lateral unnest(pt.extra_asset_amounts, pt.extra_commission_amounts) with ordinality as e(amount, commission, idx)
+where pt.chain_id = ?
+  and pt.timestamp >= ?
+  and pt.timestamp < ?
+  and pt.type in ('swap', 'provide', 'withdraw')
+group by p.id, e.idx
+order by p.id, e.idx
+`
+	var rows []struct {
+		PairId     uint64
+		Idx        int
+		Volume     string
+		Commission string
+	}
+	if tx := r.db.Raw(query, r.chainId, startTs, endTs).Scan(&rows); tx.Error != nil {
+		return nil, errors.Wrap(tx.Error, "readRepoImpl.extraAssetStats")
+	}
+
+	stats := make(map[uint64]schemas.PairStats30m)
+	for _, row := range rows {
+		s := stats[row.PairId]
+		s.ExtraVolumes = append(s.ExtraVolumes, row.Volume)
+		s.ExtraCommissions = append(s.ExtraCommissions, row.Commission)
+		stats[row.PairId] = s
+	}
+	return stats, nil
+}
+
 func (r *readRepoImpl) latestPairStat(pairId uint64) (schemas.PairStats30m, error) {
 	var stat schemas.PairStats30m
 
@@ -506,6 +552,8 @@ WHERE pt.chain_id = ?
   and pt.timestamp >= ?
   and pt.timestamp < ?
   and pt.type in ('swap', 'provide', 'withdraw')
+  -- a router swap is the user's volume once, on its first hop
+  and coalesce((pt.meta->>'route_hop')::int, 0) = 0
 )
 SELECT
     address,
@@ -585,6 +633,7 @@ token_price_by_height AS (
 SELECT lh.pair_id,
        lh.liquidity0,
        lh.liquidity1,
+       lh.extra_liquidities,
        lh.liquidity0 * COALESCE(t0.price, 0) / POWER(10, t0.token_decimals) as liquidity0_in_price,
        lh.liquidity1 * COALESCE(t1.price, 0) / POWER(10, t1.token_decimals) as liquidity1_in_price
 FROM lp_history lh
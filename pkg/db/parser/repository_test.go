@@ -706,6 +706,46 @@ func (s *aggregatorReadRepoSuite) Test_AccountStats_GroupsByAccountAndPair() {
 	assert.Contains(grouped, fmt.Sprintf("%s:%d", accountB, uint64(201)))
 }
 
+func (s *aggregatorReadRepoSuite) Test_AccountStats_ExcludesIntermediateRouteHops() {
+	assert := assert.New(s.T())
+	require := require.New(s.T())
+
+	priceToken := "uusd"
+	assetA := "terra0assetA"
+	assetB := "terra0assetB"
+	account := "terra0wallet"
+
+	require.NoError(s.DB.Exec(`TRUNCATE TABLE parsed_tx, price, tokens, pair CASCADE`).Error)
+	require.NoError(s.DB.Exec(
+		`INSERT INTO pair(id, chain_id, contract, asset0, asset1, lp) VALUES
+         (301, $1, 'terra0pairA', $2, $3, 'terra0lpA'),
+         (302, $1, 'terra0pairB', $3, $4, 'terra0lpB')`,
+		chainName, priceToken, assetA, assetB,
+	).Error)
+	require.NoError(s.DB.Exec(
+		`INSERT INTO tokens(id, chain_id, address, decimals) VALUES
+         (3100, $1, $2, 0),
+         (3101, $1, $3, 0),
+         (3102, $1, $4, 0)`,
+		chainName, priceToken, assetA, assetB,
+	).Error)
+	require.NoError(s.DB.Exec(
+		`INSERT INTO parsed_tx(chain_id, height, timestamp, hash, type, sender, contract, asset0, asset0_amount, asset1, asset1_amount, lp, lp_amount, commission_amount, commission0_amount, commission1_amount, meta)
+         VALUES
+         ($1, 100, $2, 'route-hash', 'swap', $3, 'terra0pairA', $4, '10', $5, '-7', 'terra0lpA', '0', '0', '0', '0', '{"route_id": "route-hash-0", "route_hop": 0}'),
+         ($1, 100, $2, 'route-hash', 'swap', $3, 'terra0pairB', $5, '7', $6, '-3', 'terra0lpB', '0', '0', '0', '0', '{"route_id": "route-hash-0", "route_hop": 1}')`,
+		chainName, start, account, priceToken, assetA, assetB,
+	).Error)
+
+	actual, err := s.Repo.AccountStats(start, end, priceToken)
+
+	require.NoError(err)
+	require.Len(actual, 1)
+	assert.Equal(uint64(301), actual[0].PairId)
+	assert.Equal(uint64(1), actual[0].SwapTxCnt)
+	assert.Equal("10", actual[0].SwapVolumeInPrice)
+}
+
 func (s *aggregatorReadRepoSuite) Test_AccountStats_CountsDistinctHashesButSumsRows() {
 	assert := assert.New(s.T())
 	require := require.New(s.T())
@@ -15,12 +15,14 @@ type Account struct {
 }
 
 type LpHistory struct {
-	Height     uint64  `json:"height"`
-	PairId     uint64  `json:"pair_id"`
-	ChainId    string  `json:"chain_id"`
-	Liquidity0 string  `json:"liquidity0"`
-	Liquidity1 string  `json:"liquidity1"`
-	Timestamp  float64 `json:"timestamp"`
+	Height     uint64 `json:"height"`
+	PairId     uint64 `json:"pair_id"`
+	ChainId    string `json:"chain_id"`
+	Liquidity0 string `json:"liquidity0"`
+	Liquidity1 string `json:"liquidity1"`
+	// ExtraLiquidities follow liquidity1 in pools of more than two assets.
+	ExtraLiquidities pq.StringArray `gorm:"type:numeric[]" json:"extra_liquidities"`
+	Timestamp        float64        `json:"timestamp"`
 }
 
 type Price struct {
@@ -42,20 +44,22 @@ type Route struct {
 }
 
 type ParsedTxWithPrice struct {
-	PairId            uint64  `json:"pair_id"`
-	ChainId           string  `json:"chain_id"`
-	Asset0Amount      string  `json:"asset0_amount"`
-	Asset1Amount      string  `json:"asset1_amount"`
-	Asset0Liquidity   string  `json:"asset0_liquidity"`
-	Asset1Liquidity   string  `json:"asset1_liquidity"`
-	Commission0Amount string  `json:"commission0_amount"`
-	Commission1Amount string  `json:"commission1_amount"`
-	Price0            string  `json:"price0"`
-	Price1            string  `json:"price1"`
-	Decimals0         int64   `json:"decimals0"`
-	Decimals1         int64   `json:"decimals1"`
-	Height            uint64  `json:"height"`
-	Timestamp         float64 `json:"timestamp"`
+	PairId       uint64 `json:"pair_id"`
+	ChainId      string `json:"chain_id"`
+	Asset0Amount string `json:"asset0_amount"`
+	Asset1Amount string `json:"asset1_amount"`
+	// ExtraAssetAmounts follow asset1_amount in pools of more than two assets.
+	ExtraAssetAmounts pq.StringArray `gorm:"type:numeric[]" json:"extra_asset_amounts"`
+	Asset0Liquidity   string         `json:"asset0_liquidity"`
+	Asset1Liquidity   string         `json:"asset1_liquidity"`
+	Commission0Amount string         `json:"commission0_amount"`
+	Commission1Amount string         `json:"commission1_amount"`
+	Price0            string         `json:"price0"`
+	Price1            string         `json:"price1"`
+	Decimals0         int64          `json:"decimals0"`
+	Decimals1         int64          `json:"decimals1"`
+	Height            uint64         `json:"height"`
+	Timestamp         float64        `json:"timestamp"`
 }
 
 type PairStatsRecent struct {
@@ -79,30 +83,36 @@ type PairStatsRecent struct {
 }
 
 type PairStats30m struct {
-	YearUtc            int     `json:"year_utc"`
-	MonthUtc           int     `json:"month_utc"`
-	DayUtc             int     `json:"day_utc"`
-	HourUtc            int     `json:"hour_utc"`
-	MinuteUtc          int     `json:"minute_utc"`
-	PairId             uint64  `json:"pair_id"`
-	ChainId            string  `json:"chain_id"`
-	Volume0            string  `json:"volume0"`
-	Volume1            string  `json:"volume1"`
-	Volume0InPrice     string  `json:"volume0_in_price"`
-	Volume1InPrice     string  `json:"volume1_in_price"`
-	LastSwapPrice      string  `json:"last_swap_price"`
-	Liquidity0         string  `json:"liquidity0"`
-	Liquidity1         string  `json:"liquidity1"`
-	Liquidity0InPrice  string  `json:"liquidity0_in_price"`
-	Liquidity1InPrice  string  `json:"liquidity1_in_price"`
-	Commission0        string  `json:"commission0"`
-	Commission1        string  `json:"commission1"`
-	Commission0InPrice string  `json:"commission0_in_price"`
-	Commission1InPrice string  `json:"commission1_in_price"`
-	PriceToken         string  `json:"price_token"`
-	TxCnt              int     `json:"tx_cnt"`
-	ProviderCnt        uint64  `json:"provider_cnt"`
-	Timestamp          float64 `json:"timestamp"`
+	YearUtc            int    `json:"year_utc"`
+	MonthUtc           int    `json:"month_utc"`
+	DayUtc             int    `json:"day_utc"`
+	HourUtc            int    `json:"hour_utc"`
+	MinuteUtc          int    `json:"minute_utc"`
+	PairId             uint64 `json:"pair_id"`
+	ChainId            string `json:"chain_id"`
+	Volume0            string `json:"volume0"`
+	Volume1            string `json:"volume1"`
+	Volume0InPrice     string `json:"volume0_in_price"`
+	Volume1InPrice     string `json:"volume1_in_price"`
+	LastSwapPrice      string `json:"last_swap_price"`
+	Liquidity0         string `json:"liquidity0"`
+	Liquidity1         string `json:"liquidity1"`
+	Liquidity0InPrice  string `json:"liquidity0_in_price"`
+	Liquidity1InPrice  string `json:"liquidity1_in_price"`
+	Commission0        string `json:"commission0"`
+	Commission1        string `json:"commission1"`
+	Commission0InPrice string `json:"commission0_in_price"`
+	Commission1InPrice string `json:"commission1_in_price"`
+	// ExtraVolumes, ExtraLiquidities and ExtraCommissions follow the asset1
+	// values in pools of more than two assets. They are amounts only, the in
+	// price values cover asset0 and asset1.
+	ExtraVolumes     pq.StringArray `gorm:"type:numeric[]" json:"extra_volumes"`
+	ExtraLiquidities pq.StringArray `gorm:"type:numeric[]" json:"extra_liquidities"`
+	ExtraCommissions pq.StringArray `gorm:"type:numeric[]" json:"extra_commissions"`
+	PriceToken       string         `json:"price_token"`
+	TxCnt            int            `json:"tx_cnt"`
+	ProviderCnt      uint64         `json:"provider_cnt"`
+	Timestamp        float64        `json:"timestamp"`
 }
 
 type AccountStats30m struct {
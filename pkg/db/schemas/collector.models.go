@@ -17,15 +17,31 @@ func (c FcdTxLog) TableName() string {
 	return "fcd_tx_log"
 }
 
+type FcdAddressProgress struct {
+	ChainId         string `json:"chainId"`
+	Address         string `json:"address"`
+	Completed       bool   `json:"completed"`
+	CollectedHeight uint64 `json:"collectedHeight"`
+	CreatedAt       int64  `json:"createdAt" gorm:"->"`
+	UpdatedAt       int64  `json:"updatedAt" gorm:"->"`
+}
+
+func (c FcdAddressProgress) TableName() string {
+	return "fcd_address_progress"
+}
+
 type CollectorJSON []byte
 
 type CollectorBlock struct {
-	ChainId   string        `json:"chainId"`
-	Height    uint64        `json:"height"`
-	BlockTime time.Time     `json:"blockTime"`
-	Txs       CollectorJSON `json:"txs" gorm:"type:jsonb"`
-	CreatedAt int64         `json:"createdAt" gorm:"->"`
-	UpdatedAt int64         `json:"updatedAt" gorm:"->"`
+	ChainId       string        `json:"chainId"`
+	Height        uint64        `json:"height"`
+	BlockTime     time.Time     `json:"blockTime"`
+	BlockHash     string        `json:"blockHash"`
+	ParentHash    string        `json:"parentHash"`
+	Txs           CollectorJSON `json:"txs" gorm:"type:jsonb"`
+	FilterVersion uint          `json:"filterVersion"`
+	CreatedAt     int64         `json:"createdAt" gorm:"->"`
+	UpdatedAt     int64         `json:"updatedAt" gorm:"->"`
 }
 
 type CollectorPoolSnapshot struct {
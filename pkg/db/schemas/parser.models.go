@@ -2,6 +2,7 @@ package schemas
 
 import (
 	"github.com/dezswap/cosmwasm-etl/parser/dex"
+	"github.com/lib/pq"
 )
 
 type Meta map[string]interface{}
@@ -13,6 +14,9 @@ type PoolInfo struct {
 	Asset0Amount string `json:"asset0Amount" faker:"amountString"`
 	Asset1Amount string `json:"asset1Amount" faker:"amountString"`
 	LpAmount     string `json:"lpAmount" faker:"amountString"`
+	// ExtraAssetAmounts follow asset1 in pools of more than two assets and
+	// are NULL for two asset pools.
+	ExtraAssetAmounts pq.StringArray `gorm:"type:numeric[]" json:"extraAssetAmounts" faker:"-"`
 
 	Meta Meta `json:"meta" faker:"meta"`
 }
@@ -35,6 +39,11 @@ type ParsedTx struct {
 	CommissionAmount  string     `json:"commissionAmount" faker:"amountString"`
 	Commission0Amount string     `json:"commission0Amount" faker:"amountString"`
 	Commission1Amount string     `json:"commission1Amount" faker:"amountString"`
+	// ExtraAssets, ExtraAssetAmounts and ExtraCommissionAmounts follow asset1
+	// in pools of more than two assets and are NULL for two asset pools.
+	ExtraAssets            pq.StringArray `gorm:"type:varchar[]" json:"extraAssets" faker:"-"`
+	ExtraAssetAmounts      pq.StringArray `gorm:"type:numeric[]" json:"extraAssetAmounts" faker:"-"`
+	ExtraCommissionAmounts pq.StringArray `gorm:"type:numeric[]" json:"extraCommissionAmounts" faker:"-"`
 
 	Meta Meta `json:"meta" faker:"meta"`
 }
@@ -44,7 +53,10 @@ type Pair struct {
 	Contract string `json:"contract"`
 	Asset0   string `json:"asset0"`
 	Asset1   string `json:"asset1"`
-	Lp       string `json:"lp"`
+	// ExtraAssets follow asset1 in pools of more than two assets and are NULL
+	// for two asset pools.
+	ExtraAssets pq.StringArray `gorm:"type:varchar[]" json:"extraAssets" faker:"-"`
+	Lp          string         `json:"lp"`
 
 	Meta Meta `json:"meta" faker:"meta"`
 }
@@ -56,6 +68,9 @@ type SyncedHeight struct {
 	// nil means no validation is pending. A positive value means the parser must
 	// validate that height next and only advance it after successful validation.
 	ValidationHeight *uint64 `json:"validationHeight"`
+	// ReparseSyncedHeight is the synced height before an unfinished reparse.
+	// nil means no reparse is pending.
+	ReparseSyncedHeight *uint64 `json:"reparseSyncedHeight"`
 }
 
 type PairValidationException struct {
@@ -79,5 +94,16 @@ type ParseQuarantine struct {
 	Error      string   `json:"error"`
 	RawTx      JSON     `json:"rawTx"`
 	Status     string   `json:"status"`
+	Reason     string   `json:"reason"`
 	ResolvedAt *float64 `json:"resolvedAt"`
 }
+
+type PoolValidationResult struct {
+	Id            uint64  `json:"id"`
+	ChainId       string  `json:"chainId"`
+	Height        uint64  `json:"height"`
+	Status        string  `json:"status"`
+	MismatchCount int     `json:"mismatchCount"`
+	Mismatches    JSON    `json:"mismatches"`
+	CheckedAt     float64 `gorm:"->" json:"checkedAt"`
+}
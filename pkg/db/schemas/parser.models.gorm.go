@@ -30,6 +30,9 @@ func (TokenParseException) TableName() string {
 func (ParseQuarantine) TableName() string {
 	return "parse_quarantine"
 }
+func (PoolValidationResult) TableName() string {
+	return "pool_validation_result"
+}
 
 func (Meta) GormDataType() string {
 	return "json"
@@ -0,0 +1,39 @@
+package astroport
+
+type PairAction string
+
+const (
+	SwapAction     = PairAction("swap")
+	ProvideAction  = PairAction("provide_liquidity")
+	WithdrawAction = PairAction("withdraw_liquidity")
+)
+
+const (
+	PairAddrIdx = iota
+	PairActionIdx
+)
+
+const (
+	PairAddrKey   = "_contract_address"
+	PairActionKey = "action"
+)
+
+// maker_fee_amount is emitted by every astroport pair version while
+// fee_share_amount only exists since the fee sharing upgrade.
+const (
+	PairSwapMakerFeeAmountKey = "maker_fee_amount"
+	PairSwapFeeShareAmountKey = "fee_share_amount"
+)
+
+const (
+	PairProvideAssetsKey   = "assets"
+	PairProvideSenderKey   = "sender"
+	PairProvideReceiverKey = "receiver"
+	PairProvideShareKey    = "share"
+)
+
+const (
+	PairWithdrawRefundAssetsKey  = "refund_assets"
+	PairWithdrawSenderKey        = "sender"
+	PairWithdrawWithdrawShareKey = "withdrawn_share"
+)
@@ -0,0 +1,64 @@
+package astroport
+
+import (
+	"github.com/dezswap/cosmwasm-etl/pkg/dex"
+	"github.com/dezswap/cosmwasm-etl/pkg/eventlog"
+	"github.com/pkg/errors"
+)
+
+// CreateCreatePairRuleFinder finds create_pair of the factory. The pair and
+// its liquidity token are reported by the pair's instantiate reply, which
+// follows the factory attributes in the same wasm event.
+func CreateCreatePairRuleFinder(factoryAddr string) (eventlog.LogFinder, error) {
+	if factoryAddr == "" {
+		return nil, errors.New("no factory address")
+	}
+
+	rule := createPairRule
+	rule.Items[dex.FactoryAddrIdx].Filter = factoryAddr
+
+	return eventlog.NewLogFinder(rule)
+}
+
+// CreatePairCommonRulesFinder finds swap, provide_liquidity and
+// withdraw_liquidity of pairs with all their attributes, since the
+// attributes differ between astroport pair versions.
+func CreatePairCommonRulesFinder(pairs map[string]bool) (eventlog.LogFinder, error) {
+	var filter func(v string) bool
+	if pairs != nil {
+		filter = func(v string) bool {
+			_, ok := pairs[v]
+			return ok
+		}
+	}
+	rule := pairCommonRule
+	rule.Items[PairAddrIdx].Filter = filter
+	return eventlog.NewLogFinder(rule)
+}
+
+// Track cw20 transfer
+func CreateWasmCommonTransferRuleFinder() (eventlog.LogFinder, error) {
+	return eventlog.NewLogFinder(wasmTransferCommonRule)
+}
+
+var createPairRule = eventlog.Rule{Type: eventlog.WasmType, Items: eventlog.RuleItems{
+	eventlog.RuleItem{Key: "_contract_address", Filter: nil},
+	eventlog.RuleItem{Key: "action", Filter: "create_pair"},
+	eventlog.RuleItem{Key: "pair", Filter: nil},
+	eventlog.RuleItem{Key: "_contract_address", Filter: nil},
+	eventlog.RuleItem{Key: "liquidity_token_addr", Filter: nil},
+}}
+
+var pairCommonRule = eventlog.Rule{Type: eventlog.WasmType, Until: "_contract_address", Items: eventlog.RuleItems{
+	eventlog.RuleItem{Key: "_contract_address", Filter: nil},
+	eventlog.RuleItem{Key: "action", Filter: func(v string) bool {
+		return v == string(SwapAction) || v == string(ProvideAction) || v == string(WithdrawAction)
+	}},
+}}
+
+var wasmTransferCommonRule = eventlog.Rule{Type: eventlog.WasmType, Until: "_contract_address", Items: eventlog.RuleItems{
+	eventlog.RuleItem{Key: "_contract_address", Filter: nil},
+	eventlog.RuleItem{Key: "action", Filter: func(v string) bool {
+		return v == dex.WasmTransferAction || v == dex.WasmTransferFromAction
+	}},
+}}
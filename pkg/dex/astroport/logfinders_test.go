@@ -0,0 +1,135 @@
+package astroport
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/dezswap/cosmwasm-etl/pkg/dex"
+	"github.com/dezswap/cosmwasm-etl/pkg/eventlog"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testFactoryAddr = "terra14x9fr055x5hvr48hzy2t4q7kvjvfttsvxusa4xsdcy702mnzsvuqprer8r"
+	testPairAddr    = "terra1fd68ah02gr2y8ze7tm9te7m70zlmc7vjyyhs6xlhsdmqqcjud4dql4wpxr"
+)
+
+func Test_CreateCreatePairRuleFinder(t *testing.T) {
+	tcs := []struct {
+		factoryAddr       string
+		rawLogStr         string
+		expectedResultLen int
+		errMsg            string
+	}{
+		{testFactoryAddr, CreatePairRawLogStr, 1, "must match once"},
+		{"terra1otherfactory", CreatePairRawLogStr, 0, "must not match with another factory"},
+		{testFactoryAddr, PairSwapRawLogStr, 0, "must not match with pair logs"},
+		{testFactoryAddr, "[]", 0, "must not match with empty logs"},
+	}
+
+	for idx, tc := range tcs {
+		errMsg := fmt.Sprintf("idx(%d): %s", idx, tc.errMsg)
+		assert := assert.New(t)
+
+		logFinder, err := CreateCreatePairRuleFinder(tc.factoryAddr)
+		assert.NoError(err)
+		eventLogs := eventlog.LogResults{}
+		assert.NoError(json.Unmarshal([]byte(tc.rawLogStr), &eventLogs))
+
+		matchedResults := logFinder.FindFromLogs(eventLogs)
+		assert.Len(matchedResults, tc.expectedResultLen, errMsg)
+		if tc.expectedResultLen > 0 {
+			assert.Len(matchedResults[0], dex.CreatePairMatchedLen, "must return all matched value")
+			assert.Equal(testPairAddr, matchedResults[0][dex.FactoryPairAddrIdx].Value)
+		}
+	}
+
+	_, err := CreateCreatePairRuleFinder("")
+	assert.Error(t, err)
+}
+
+func Test_LogFinders(t *testing.T) {
+	tcs := []struct {
+		rawLogStr  string
+		finderFunc func() (eventlog.LogFinder, error)
+		matchedLen int
+		action     string
+		errMsg     string
+	}{
+		{PairSwapRawLogStr, pairFinder(nil), 1, string(SwapAction), "must match swap"},
+		{PairSwapRawLogStr, pairFinder(map[string]bool{"terra1other": true}), 0, "", "must not match unknown pair"},
+		{PairProvideRawLogStr, pairFinder(map[string]bool{testPairAddr: true}), 1, string(ProvideAction), "must match provide"},
+		{PairWithdrawRawLogStr, pairFinder(map[string]bool{testPairAddr: true}), 1, string(WithdrawAction), "must match withdraw"},
+		{PairSwapRawLogStr, CreateWasmCommonTransferRuleFinder, 1, dex.WasmTransferAction, "must match cw20 transfer"},
+	}
+
+	for idx, tc := range tcs {
+		errMsg := fmt.Sprintf("idx(%d): %s", idx, tc.errMsg)
+		assert := assert.New(t)
+
+		logFinder, err := tc.finderFunc()
+		assert.NoError(err)
+		eventLogs := eventlog.LogResults{}
+		assert.NoError(json.Unmarshal([]byte(tc.rawLogStr), &eventLogs))
+
+		matchedResults := logFinder.FindFromLogs(eventLogs)
+		assert.Len(matchedResults, tc.matchedLen, errMsg)
+		if tc.matchedLen > 0 {
+			assert.Equal(tc.action, matchedResults[0][PairActionIdx].Value, errMsg)
+		}
+	}
+}
+
+func Test_PairCommonRulesFinderKeepsAllSwapAttributes(t *testing.T) {
+	logFinder, err := CreatePairCommonRulesFinder(nil)
+	assert.NoError(t, err)
+	eventLogs := eventlog.LogResults{}
+	assert.NoError(t, json.Unmarshal([]byte(PairSwapRawLogStr), &eventLogs))
+
+	matchedResults := logFinder.FindFromLogs(eventLogs)
+
+	assert.Len(t, matchedResults, 1)
+	matchMap, err := eventlog.ResultToItemMapForKeys(matchedResults[0], PairSwapMakerFeeAmountKey, PairSwapFeeShareAmountKey)
+	assert.NoError(t, err)
+	assert.Equal(t, "1497", matchMap[PairSwapMakerFeeAmountKey].Value)
+	assert.Equal(t, "0", matchMap[PairSwapFeeShareAmountKey].Value)
+}
+
+func pairFinder(pairs map[string]bool) func() (eventlog.LogFinder, error) {
+	return func() (eventlog.LogFinder, error) { return CreatePairCommonRulesFinder(pairs) }
+}
+
+const CreatePairRawLogStr = `[
+	{"type":"message","attributes":[{"key":"action","value":"/cosmwasm.wasm.v1.MsgExecuteContract"},{"key":"module","value":"wasm"},{"key":"sender","value":"terra1g5cad8hl9uwldus279ddc0j4fq7xjude0ynhjv"}]},
+	{"type":"wasm","attributes":[
+		{"key":"_contract_address","value":"terra14x9fr055x5hvr48hzy2t4q7kvjvfttsvxusa4xsdcy702mnzsvuqprer8r"},{"key":"action","value":"create_pair"},{"key":"pair","value":"uluna-terra1nsuqsk6kh58ulczatwev87ttq2z6r3pusulg9r24mfj2fvtzd4uq3exn26"},
+		{"key":"_contract_address","value":"terra1fd68ah02gr2y8ze7tm9te7m70zlmc7vjyyhs6xlhsdmqqcjud4dql4wpxr"},{"key":"liquidity_token_addr","value":"terra1ckmsqdhlky9jxcmtyj64crgzjxad9pvsd58k8zsxsnv4vzvwdt7qke04hl"},
+		{"key":"_contract_address","value":"terra14x9fr055x5hvr48hzy2t4q7kvjvfttsvxusa4xsdcy702mnzsvuqprer8r"},{"key":"action","value":"register"},{"key":"pair_contract_addr","value":"terra1fd68ah02gr2y8ze7tm9te7m70zlmc7vjyyhs6xlhsdmqqcjud4dql4wpxr"}]}
+]`
+
+const PairSwapRawLogStr = `[
+	{"type":"message","attributes":[{"key":"action","value":"/cosmwasm.wasm.v1.MsgExecuteContract"},{"key":"module","value":"wasm"},{"key":"sender","value":"terra15245qvf0g473xscam0hjfag0l8yr65h6pter3x"}]},
+	{"type":"wasm","attributes":[
+		{"key":"_contract_address","value":"terra1fd68ah02gr2y8ze7tm9te7m70zlmc7vjyyhs6xlhsdmqqcjud4dql4wpxr"},{"key":"action","value":"swap"},{"key":"receiver","value":"terra15245qvf0g473xscam0hjfag0l8yr65h6pter3x"},{"key":"sender","value":"terra15245qvf0g473xscam0hjfag0l8yr65h6pter3x"},
+		{"key":"offer_asset","value":"uluna"},{"key":"ask_asset","value":"terra1nsuqsk6kh58ulczatwev87ttq2z6r3pusulg9r24mfj2fvtzd4uq3exn26"},{"key":"offer_amount","value":"1000000"},{"key":"return_amount","value":"995006"},
+		{"key":"spread_amount","value":"1000"},{"key":"commission_amount","value":"2994"},{"key":"maker_fee_amount","value":"1497"},{"key":"fee_share_amount","value":"0"},
+		{"key":"_contract_address","value":"terra1nsuqsk6kh58ulczatwev87ttq2z6r3pusulg9r24mfj2fvtzd4uq3exn26"},{"key":"action","value":"transfer"},{"key":"from","value":"terra1fd68ah02gr2y8ze7tm9te7m70zlmc7vjyyhs6xlhsdmqqcjud4dql4wpxr"},{"key":"to","value":"terra15245qvf0g473xscam0hjfag0l8yr65h6pter3x"},{"key":"amount","value":"995006"}]}
+]`
+
+const PairProvideRawLogStr = `[
+	{"type":"wasm","attributes":[
+		{"key":"_contract_address","value":"terra1fd68ah02gr2y8ze7tm9te7m70zlmc7vjyyhs6xlhsdmqqcjud4dql4wpxr"},{"key":"action","value":"provide_liquidity"},{"key":"sender","value":"terra103rap9vjn3v59frjd90ucmcs5wu0dy0a59fzra"},{"key":"receiver","value":"terra103rap9vjn3v59frjd90ucmcs5wu0dy0a59fzra"},
+		{"key":"assets","value":"1000000uluna, 1000000terra1nsuqsk6kh58ulczatwev87ttq2z6r3pusulg9r24mfj2fvtzd4uq3exn26"},{"key":"share","value":"999000"},
+		{"key":"_contract_address","value":"terra1nsuqsk6kh58ulczatwev87ttq2z6r3pusulg9r24mfj2fvtzd4uq3exn26"},{"key":"action","value":"transfer_from"},{"key":"from","value":"terra103rap9vjn3v59frjd90ucmcs5wu0dy0a59fzra"},{"key":"to","value":"terra1fd68ah02gr2y8ze7tm9te7m70zlmc7vjyyhs6xlhsdmqqcjud4dql4wpxr"},{"key":"by","value":"terra1fd68ah02gr2y8ze7tm9te7m70zlmc7vjyyhs6xlhsdmqqcjud4dql4wpxr"},{"key":"amount","value":"1000000"},
+		{"key":"_contract_address","value":"terra1ckmsqdhlky9jxcmtyj64crgzjxad9pvsd58k8zsxsnv4vzvwdt7qke04hl"},{"key":"action","value":"mint"},{"key":"to","value":"terra103rap9vjn3v59frjd90ucmcs5wu0dy0a59fzra"},{"key":"amount","value":"999000"}]}
+]`
+
+const PairWithdrawRawLogStr = `[
+	{"type":"wasm","attributes":[
+		{"key":"_contract_address","value":"terra1ckmsqdhlky9jxcmtyj64crgzjxad9pvsd58k8zsxsnv4vzvwdt7qke04hl"},{"key":"action","value":"send"},{"key":"from","value":"terra103rap9vjn3v59frjd90ucmcs5wu0dy0a59fzra"},{"key":"to","value":"terra1fd68ah02gr2y8ze7tm9te7m70zlmc7vjyyhs6xlhsdmqqcjud4dql4wpxr"},{"key":"amount","value":"999000"},
+		{"key":"_contract_address","value":"terra1fd68ah02gr2y8ze7tm9te7m70zlmc7vjyyhs6xlhsdmqqcjud4dql4wpxr"},{"key":"action","value":"withdraw_liquidity"},{"key":"sender","value":"terra103rap9vjn3v59frjd90ucmcs5wu0dy0a59fzra"},{"key":"withdrawn_share","value":"999000"},
+		{"key":"refund_assets","value":"999000uluna, 999000terra1nsuqsk6kh58ulczatwev87ttq2z6r3pusulg9r24mfj2fvtzd4uq3exn26"},
+		{"key":"_contract_address","value":"terra1nsuqsk6kh58ulczatwev87ttq2z6r3pusulg9r24mfj2fvtzd4uq3exn26"},{"key":"action","value":"transfer"},{"key":"from","value":"terra1fd68ah02gr2y8ze7tm9te7m70zlmc7vjyyhs6xlhsdmqqcjud4dql4wpxr"},{"key":"to","value":"terra103rap9vjn3v59frjd90ucmcs5wu0dy0a59fzra"},{"key":"amount","value":"999000"},
+		{"key":"_contract_address","value":"terra1ckmsqdhlky9jxcmtyj64crgzjxad9pvsd58k8zsxsnv4vzvwdt7qke04hl"},{"key":"action","value":"burn"},{"key":"from","value":"terra1fd68ah02gr2y8ze7tm9te7m70zlmc7vjyyhs6xlhsdmqqcjud4dql4wpxr"},{"key":"amount","value":"999000"}]}
+]`
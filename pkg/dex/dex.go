@@ -6,6 +6,8 @@ const (
 	Terraswap DexType = "terraswap"
 	Dezswap   DexType = "dezswap"
 	Starfleit DexType = "starfleit"
+	Astroport DexType = "astroport"
+	Generic   DexType = "generic"
 	Unknown   DexType = ""
 )
 
@@ -17,6 +19,10 @@ func ToDexType(candidate string) DexType {
 		return Dezswap
 	case Starfleit:
 		return Starfleit
+	case Astroport:
+		return Astroport
+	case Generic:
+		return Generic
 	default:
 		return Unknown
 	}
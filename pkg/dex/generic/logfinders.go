@@ -0,0 +1,62 @@
+package generic
+
+import (
+	"github.com/dezswap/cosmwasm-etl/pkg/dex"
+	"github.com/dezswap/cosmwasm-etl/pkg/eventlog"
+	"github.com/pkg/errors"
+)
+
+// Create pair results start with the factory address and the action,
+// followed by CreatePairSpec.Attributes.
+const (
+	FactoryAddrIdx = iota
+	FactoryActionIdx
+	FactoryAttributesIdx
+)
+
+const (
+	PairAddrIdx = iota
+	PairActionIdx
+)
+
+func CreateCreatePairRuleFinder(spec Spec, factoryAddr string) (eventlog.LogFinder, error) {
+	if factoryAddr == "" {
+		return nil, errors.New("no factory address")
+	}
+
+	items := eventlog.RuleItems{
+		{Key: spec.ContractAddrKey, Filter: factoryAddr},
+		{Key: spec.ActionKey, Filter: spec.CreatePair.Action},
+	}
+	for _, key := range spec.CreatePair.Attributes {
+		items = append(items, eventlog.RuleItem{Key: key, Filter: nil})
+	}
+	return eventlog.NewLogFinder(eventlog.Rule{Type: eventlog.WasmType, Items: items})
+}
+
+// CreatePairCommonRulesFinder finds the pair actions of the spec with all
+// their attributes up to the next contract.
+func CreatePairCommonRulesFinder(spec Spec, pairs map[string]bool) (eventlog.LogFinder, error) {
+	var filter func(v string) bool
+	if pairs != nil {
+		filter = func(v string) bool {
+			_, ok := pairs[v]
+			return ok
+		}
+	}
+	actions := map[string]bool{spec.Swap.Action: true, spec.Provide.Action: true, spec.Withdraw.Action: true}
+	return eventlog.NewLogFinder(eventlog.Rule{Type: eventlog.WasmType, Until: spec.ContractAddrKey, Items: eventlog.RuleItems{
+		{Key: spec.ContractAddrKey, Filter: filter},
+		{Key: spec.ActionKey, Filter: func(v string) bool { return actions[v] }},
+	}})
+}
+
+// Track cw20 transfer
+func CreateWasmCommonTransferRuleFinder(spec Spec) (eventlog.LogFinder, error) {
+	return eventlog.NewLogFinder(eventlog.Rule{Type: eventlog.WasmType, Until: spec.Mapper.Cw20AddrKey, Items: eventlog.RuleItems{
+		{Key: spec.Mapper.Cw20AddrKey, Filter: nil},
+		{Key: spec.ActionKey, Filter: func(v string) bool {
+			return v == dex.WasmTransferAction || v == dex.WasmTransferFromAction
+		}},
+	}})
+}
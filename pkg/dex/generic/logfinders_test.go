@@ -0,0 +1,109 @@
+package generic
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/dezswap/cosmwasm-etl/pkg/eventlog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testFactoryAddr = "terra1factory"
+	testPairAddr    = "terra1pair"
+	testLpAddr      = "terra1lp"
+)
+
+// a fork that emits the lp token before the assets and renames the pair key
+const forkSpec = `
+name: forkswap
+createPair:
+  attributes: [lp_token, pair_addr, asset_infos]
+  assetsKey: asset_infos
+  assetsSeparator: ","
+  pairAddrKey: pair_addr
+  lpAddrKey: lp_token
+swap:
+  action: trade
+`
+
+var forkCreatePairRawLogStr = `[{"type":"wasm","attributes":[
+	{"key":"_contract_address","value":"` + testFactoryAddr + `"},{"key":"action","value":"create_pair"},
+	{"key":"lp_token","value":"` + testLpAddr + `"},{"key":"pair_addr","value":"` + testPairAddr + `"},{"key":"asset_infos","value":"uluna,terra1token"}]}]`
+
+var forkSwapRawLogStr = `[{"type":"wasm","attributes":[
+	{"key":"_contract_address","value":"` + testPairAddr + `"},{"key":"action","value":"trade"},{"key":"sender","value":"terra1user"},
+	{"key":"_contract_address","value":"terra1token"},{"key":"action","value":"transfer"},{"key":"from","value":"` + testPairAddr + `"}]}]`
+
+func testSpec(t *testing.T) Spec {
+	spec, err := ParseSpec([]byte(forkSpec))
+	require.NoError(t, err)
+	return spec
+}
+
+func Test_CreateCreatePairRuleFinder(t *testing.T) {
+	spec := testSpec(t)
+	tcs := []struct {
+		factoryAddr       string
+		rawLogStr         string
+		expectedResultLen int
+		errMsg            string
+	}{
+		{testFactoryAddr, forkCreatePairRawLogStr, 1, "must match once"},
+		{"terra1otherfactory", forkCreatePairRawLogStr, 0, "must not match with another factory"},
+		{testFactoryAddr, forkSwapRawLogStr, 0, "must not match with pair logs"},
+	}
+
+	for idx, tc := range tcs {
+		errMsg := fmt.Sprintf("idx(%d): %s", idx, tc.errMsg)
+		assert := assert.New(t)
+
+		logFinder, err := CreateCreatePairRuleFinder(spec, tc.factoryAddr)
+		assert.NoError(err)
+		eventLogs := eventlog.LogResults{}
+		assert.NoError(json.Unmarshal([]byte(tc.rawLogStr), &eventLogs))
+
+		matchedResults := logFinder.FindFromLogs(eventLogs)
+		assert.Len(matchedResults, tc.expectedResultLen, errMsg)
+		if tc.expectedResultLen > 0 {
+			assert.Len(matchedResults[0], FactoryAttributesIdx+len(spec.CreatePair.Attributes), "must return all matched value")
+			assert.Equal(testLpAddr, matchedResults[0][FactoryAttributesIdx].Value)
+		}
+	}
+
+	_, err := CreateCreatePairRuleFinder(spec, "")
+	assert.Error(t, err)
+}
+
+func Test_CreatePairCommonRulesFinder(t *testing.T) {
+	spec := testSpec(t)
+	tcs := []struct {
+		pairs             map[string]bool
+		rawLogStr         string
+		expectedResultLen int
+		errMsg            string
+	}{
+		{map[string]bool{testPairAddr: true}, forkSwapRawLogStr, 1, "must match the renamed swap action"},
+		{map[string]bool{"terra1otherpair": true}, forkSwapRawLogStr, 0, "must not match another pair"},
+		{nil, forkSwapRawLogStr, 1, "must match any pair without a filter"},
+		{nil, forkCreatePairRawLogStr, 0, "must not match factory logs"},
+	}
+
+	for idx, tc := range tcs {
+		errMsg := fmt.Sprintf("idx(%d): %s", idx, tc.errMsg)
+		assert := assert.New(t)
+
+		logFinder, err := CreatePairCommonRulesFinder(spec, tc.pairs)
+		assert.NoError(err)
+		eventLogs := eventlog.LogResults{}
+		assert.NoError(json.Unmarshal([]byte(tc.rawLogStr), &eventLogs))
+
+		matchedResults := logFinder.FindFromLogs(eventLogs)
+		assert.Len(matchedResults, tc.expectedResultLen, errMsg)
+		if tc.expectedResultLen > 0 {
+			assert.Len(matchedResults[0], 3, "must stop at the next contract")
+		}
+	}
+}
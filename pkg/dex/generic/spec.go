@@ -0,0 +1,171 @@
+package generic
+
+import (
+	"os"
+
+	"github.com/dezswap/cosmwasm-etl/pkg/dex"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+)
+
+// Spec describes the factory and pair events of a terraswap compatible DEX.
+// Every field defaults to the terraswap layout, so a spec only lists what a
+// fork renamed or reordered.
+type Spec struct {
+	Name string `json:"name"`
+	// ContractAddrKey is the key wasm events report the emitting contract with.
+	ContractAddrKey string `json:"contractAddrKey"`
+	ActionKey       string `json:"actionKey"`
+
+	CreatePair CreatePairSpec `json:"createPair"`
+	Swap       SwapSpec       `json:"swap"`
+	Provide    ProvideSpec    `json:"provide"`
+	Withdraw   WithdrawSpec   `json:"withdraw"`
+	Mapper     MapperSpec     `json:"mapper"`
+}
+
+// CreatePairSpec matches the factory create_pair attributes. Attributes are
+// the keys that follow the action in the order they are emitted, and must
+// include AssetsKey, PairAddrKey and LpAddrKey.
+type CreatePairSpec struct {
+	Action          string   `json:"action"`
+	Attributes      []string `json:"attributes"`
+	AssetsKey       string   `json:"assetsKey"`
+	AssetsSeparator string   `json:"assetsSeparator"`
+	PairAddrKey     string   `json:"pairAddrKey"`
+	LpAddrKey       string   `json:"lpAddrKey"`
+}
+
+// SwapSpec names the swap attributes. MetaKeys are copied into the parsed
+// tx meta when the event has them.
+type SwapSpec struct {
+	Action              string   `json:"action"`
+	SenderKey           string   `json:"senderKey"`
+	OfferAssetKey       string   `json:"offerAssetKey"`
+	OfferAmountKey      string   `json:"offerAmountKey"`
+	AskAssetKey         string   `json:"askAssetKey"`
+	ReturnAmountKey     string   `json:"returnAmountKey"`
+	CommissionAmountKey string   `json:"commissionAmountKey"`
+	MetaKeys            []string `json:"metaKeys"`
+}
+
+// ProvideSpec names the provide_liquidity attributes. RefundAssetsKey is
+// optional and only set for forks that refund the unused part of a provide.
+type ProvideSpec struct {
+	Action          string `json:"action"`
+	SenderKey       string `json:"senderKey"`
+	AssetsKey       string `json:"assetsKey"`
+	ShareKey        string `json:"shareKey"`
+	RefundAssetsKey string `json:"refundAssetsKey"`
+}
+
+type WithdrawSpec struct {
+	Action          string `json:"action"`
+	SenderKey       string `json:"senderKey"`
+	RefundAssetsKey string `json:"refundAssetsKey"`
+	ShareKey        string `json:"shareKey"`
+}
+
+// MapperSpec toggles the parsers shared by every terraswap fork.
+type MapperSpec struct {
+	// Cw20AddrKey is the key cw20 transfer events report the token with.
+	Cw20AddrKey        string `json:"cw20AddrKey"`
+	SkipInitialProvide bool   `json:"skipInitialProvide"`
+	SkipLpBurn         bool   `json:"skipLpBurn"`
+}
+
+// LoadSpec reads a YAML or JSON spec file.
+func LoadSpec(path string) (Spec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Spec{}, errors.Wrap(err, "generic.LoadSpec")
+	}
+	spec, err := ParseSpec(data)
+	if err != nil {
+		return Spec{}, errors.Wrapf(err, "generic.LoadSpec %s", path)
+	}
+	return spec, nil
+}
+
+// ParseSpec decodes a YAML or JSON spec, fills the terraswap defaults and
+// validates the result. Unknown fields are rejected to catch typos.
+func ParseSpec(data []byte) (Spec, error) {
+	spec := Spec{}
+	if err := yaml.UnmarshalStrict(data, &spec); err != nil {
+		return Spec{}, errors.Wrap(err, "generic.ParseSpec")
+	}
+	spec = spec.withDefaults()
+	if err := spec.Validate(); err != nil {
+		return Spec{}, err
+	}
+	return spec, nil
+}
+
+func (s Spec) withDefaults() Spec {
+	def := func(v *string, d string) {
+		if *v == "" {
+			*v = d
+		}
+	}
+	def(&s.ContractAddrKey, "_contract_address")
+	def(&s.ActionKey, "action")
+
+	def(&s.CreatePair.Action, "create_pair")
+	if len(s.CreatePair.Attributes) == 0 {
+		s.CreatePair.Attributes = []string{"pair", s.ContractAddrKey, "liquidity_token_addr"}
+	}
+	def(&s.CreatePair.AssetsKey, "pair")
+	def(&s.CreatePair.AssetsSeparator, "-")
+	def(&s.CreatePair.PairAddrKey, s.ContractAddrKey)
+	def(&s.CreatePair.LpAddrKey, "liquidity_token_addr")
+
+	def(&s.Swap.Action, "swap")
+	def(&s.Swap.SenderKey, dex.PairSwapSenderKey)
+	def(&s.Swap.OfferAssetKey, dex.PairSwapOfferAssetKey)
+	def(&s.Swap.OfferAmountKey, dex.PairSwapOfferAmountKey)
+	def(&s.Swap.AskAssetKey, dex.PairSwapAskAssetKey)
+	def(&s.Swap.ReturnAmountKey, dex.PairSwapReturnAmountKey)
+	def(&s.Swap.CommissionAmountKey, dex.PairSwapCommissionAmountKey)
+
+	def(&s.Provide.Action, "provide_liquidity")
+	def(&s.Provide.SenderKey, "sender")
+	def(&s.Provide.AssetsKey, "assets")
+	def(&s.Provide.ShareKey, "share")
+
+	def(&s.Withdraw.Action, "withdraw_liquidity")
+	def(&s.Withdraw.SenderKey, "sender")
+	def(&s.Withdraw.RefundAssetsKey, "refund_assets")
+	def(&s.Withdraw.ShareKey, "withdrawn_share")
+
+	def(&s.Mapper.Cw20AddrKey, dex.WasmTransferCw20AddrKey)
+	return s
+}
+
+// Validate checks a spec with its defaults filled.
+func (s Spec) Validate() error {
+	if s.Name == "" {
+		return errors.New("spec name is missing")
+	}
+	actions := map[string]bool{}
+	for _, action := range []string{s.Swap.Action, s.Provide.Action, s.Withdraw.Action} {
+		if actions[action] {
+			return errors.Errorf("spec(%s) pair action(%s) is used more than once", s.Name, action)
+		}
+		actions[action] = true
+	}
+
+	attrs := map[string]int{}
+	for _, key := range s.CreatePair.Attributes {
+		attrs[key]++
+	}
+	for _, key := range []string{s.CreatePair.AssetsKey, s.CreatePair.PairAddrKey, s.CreatePair.LpAddrKey} {
+		switch attrs[key] {
+		case 0:
+			return errors.Errorf("spec(%s) create pair attributes must include %s", s.Name, key)
+		case 1:
+		default:
+			return errors.Errorf("spec(%s) create pair attribute %s is ambiguous", s.Name, key)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package generic
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseSpec_Defaults(t *testing.T) {
+	spec, err := ParseSpec([]byte("name: myswap"))
+	require.NoError(t, err)
+
+	require.Equal(t, "_contract_address", spec.ContractAddrKey)
+	require.Equal(t, "action", spec.ActionKey)
+	require.Equal(t, []string{"pair", "_contract_address", "liquidity_token_addr"}, spec.CreatePair.Attributes)
+	require.Equal(t, "-", spec.CreatePair.AssetsSeparator)
+	require.Equal(t, "offer_asset", spec.Swap.OfferAssetKey)
+	require.Equal(t, "provide_liquidity", spec.Provide.Action)
+	require.Equal(t, "withdrawn_share", spec.Withdraw.ShareKey)
+	require.Equal(t, "_contract_address", spec.Mapper.Cw20AddrKey)
+}
+
+func Test_ParseSpec_JSON(t *testing.T) {
+	spec, err := ParseSpec([]byte(`{"name":"myswap","swap":{"action":"trade","metaKeys":["maker_fee_amount"]}}`))
+	require.NoError(t, err)
+
+	require.Equal(t, "trade", spec.Swap.Action)
+	require.Equal(t, []string{"maker_fee_amount"}, spec.Swap.MetaKeys)
+	require.Equal(t, "sender", spec.Swap.SenderKey)
+}
+
+func Test_ParseSpec_Invalid(t *testing.T) {
+	tcs := []struct {
+		spec   string
+		errMsg string
+	}{
+		{"contractAddrKey: addr", "spec name is missing"},
+		{"name: myswap\nswapp: {}", "unknown field"},
+		{"name: myswap\nprovide: {action: swap}", "spec(myswap) pair action(swap) is used more than once"},
+		{"name: myswap\ncreatePair: {attributes: [pair, _contract_address]}", "spec(myswap) create pair attributes must include liquidity_token_addr"},
+		{"name: myswap\ncreatePair: {attributes: [pair, pair, _contract_address, liquidity_token_addr]}", "spec(myswap) create pair attribute pair is ambiguous"},
+	}
+	for _, tc := range tcs {
+		_, err := ParseSpec([]byte(tc.spec))
+		require.ErrorContains(t, err, tc.errMsg, tc.spec)
+	}
+}
+
+func Test_LoadSpec(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "spec.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("name: myswap\nmapper: {skipLpBurn: true}"), 0o600))
+
+	spec, err := LoadSpec(path)
+	require.NoError(t, err)
+	require.Equal(t, "myswap", spec.Name)
+	require.True(t, spec.Mapper.SkipLpBurn)
+
+	_, err = LoadSpec(filepath.Join(t.TempDir(), "missing.yaml"))
+	require.Error(t, err)
+}
+
+func Test_ParseSpec_Example(t *testing.T) {
+	spec, err := LoadSpec("../../../example.dex-spec.yaml")
+	require.NoError(t, err)
+	require.Equal(t, "myswap", spec.Name)
+}
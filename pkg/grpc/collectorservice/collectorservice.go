@@ -0,0 +1,130 @@
+package collectorservice
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	collectorrepo "github.com/dezswap/cosmwasm-etl/collector/repo"
+	"github.com/dezswap/cosmwasm-etl/pkg/grpc/collectorservice/generated/collectorservice"
+)
+
+// CollectorServer serves the rows stored by the collector to remote parsers.
+type CollectorServer struct {
+	collectorservice.UnimplementedCollectorServer
+	repo collectorrepo.Repository
+}
+
+func NewCollectorServer(repo collectorrepo.Repository) *CollectorServer {
+	return &CollectorServer{repo: repo}
+}
+
+// NewGrpcServer returns a gRPC server with the collector service registered.
+func NewGrpcServer(repo collectorrepo.Repository, opts ...grpc.ServerOption) *grpc.Server {
+	server := grpc.NewServer(opts...)
+	collectorservice.RegisterCollectorServer(server, NewCollectorServer(repo))
+	return server
+}
+
+func (s *CollectorServer) GetSyncedHeight(_ context.Context, req *collectorservice.GetSyncedHeightRequest) (*collectorservice.GetSyncedHeightResponse, error) {
+	height, err := s.repo.GetSyncedHeight(req.GetChainId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &collectorservice.GetSyncedHeightResponse{Height: height}, nil
+}
+
+func (s *CollectorServer) GetBlockTxs(_ context.Context, req *collectorservice.GetBlockTxsRequest) (*collectorservice.Block, error) {
+	block, err := s.block(req.GetChainId(), req.GetHeight())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return block, nil
+}
+
+func (s *CollectorServer) GetBlockHeader(_ context.Context, req *collectorservice.GetBlockHeaderRequest) (*collectorservice.BlockHeader, error) {
+	header, err := s.repo.GetBlockHeader(req.GetChainId(), req.GetHeight())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &collectorservice.BlockHeader{Height: header.Height, BlockHash: header.Hash, ParentHash: header.ParentHash}, nil
+}
+
+func (s *CollectorServer) GetPoolInfos(_ context.Context, req *collectorservice.GetPoolInfosRequest) (*collectorservice.GetPoolInfosResponse, error) {
+	poolInfos, err := s.repo.GetPoolInfos(req.GetChainId(), req.GetHeight())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	data, err := json.Marshal(poolInfos)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &collectorservice.GetPoolInfosResponse{Height: req.GetHeight(), PoolInfos: data}, nil
+}
+
+// StreamBlocks sends blocks up to the lower of to_height and the synced
+// height. A zero to_height streams up to the synced height.
+func (s *CollectorServer) StreamBlocks(req *collectorservice.StreamBlocksRequest, stream collectorservice.Collector_StreamBlocksServer) error {
+	synced, err := s.repo.GetSyncedHeight(req.GetChainId())
+	if err != nil {
+		return toStatus(err)
+	}
+	to := req.GetToHeight()
+	if to == 0 || to > synced {
+		to = synced
+	}
+
+	for height := req.GetFromHeight(); height <= to; height++ {
+		if err := stream.Context().Err(); err != nil {
+			return status.FromContextError(err).Err()
+		}
+		block, err := s.block(req.GetChainId(), height)
+		if errors.Is(err, collectorrepo.ErrNotFound) {
+			return nil
+		} else if err != nil {
+			return toStatus(err)
+		}
+		if err := stream.Send(block); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *CollectorServer) block(chainID string, height uint64) (*collectorservice.Block, error) {
+	txs, blockTime, err := s.repo.GetBlockTxs(chainID, height)
+	if err != nil {
+		return nil, err
+	}
+	header, err := s.repo.GetBlockHeader(chainID, height)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(txs)
+	if err != nil {
+		return nil, err
+	}
+	return &collectorservice.Block{
+		Height:     height,
+		BlockTime:  timestamppb.New(blockTime),
+		BlockHash:  header.Hash,
+		ParentHash: header.ParentHash,
+		Txs:        data,
+	}, nil
+}
+
+func toStatus(err error) error {
+	switch {
+	case errors.Is(err, collectorrepo.ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, collectorrepo.ErrUnavailable):
+		return status.Error(codes.Unavailable, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
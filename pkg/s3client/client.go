@@ -59,7 +59,13 @@ type s3ClientInfo struct {
 
 var _ S3ClientInterface = &s3ClientInfo{}
 
+// NewClient returns a bucket client, or a directory-backed client with the
+// same object layout when s3Cfg.LocalDir is set.
 func NewClient(s3Cfg configs.S3Config) (S3ClientInterface, error) {
+	if s3Cfg.LocalDir != "" {
+		return NewLocalClient(s3Cfg.LocalDir)
+	}
+
 	cred := credentials.NewStaticCredentialsProvider(
 		s3Cfg.Key,    // user,
 		s3Cfg.Secret, // key,
@@ -178,6 +184,10 @@ func (client *s3ClientInfo) GetWholeBlockFileListFromS3(path ...string) ([]strin
 }
 
 func (client *s3ClientInfo) GetBlockFilePath(blockNum int64, folderPath ...string) []string {
+	return blockFilePath(blockNum, folderPath...)
+}
+
+func blockFilePath(blockNum int64, folderPath ...string) []string {
 	filename := fmt.Sprintf("%s_%d.json", blockTag, blockNum)
 	return append(folderPath, filename)
 }
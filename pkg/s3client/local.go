@@ -0,0 +1,103 @@
+package s3client
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// localClient stores objects as files under a root directory using the same
+// keys as the bucket, e.g. <root>/<chainId>/block/block_<height>.json and
+// <root>/<chainId>/pair/<height>.json, so collected data can be used without S3.
+type localClient struct {
+	root string
+}
+
+var _ S3ClientInterface = &localClient{}
+
+func NewLocalClient(root string) (S3ClientInterface, error) {
+	if root == "" {
+		return nil, errors.New("NewLocalClient, empty root directory")
+	}
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, errors.Wrap(err, "NewLocalClient, create root directory")
+	}
+	return &localClient{root: root}, nil
+}
+
+func (client *localClient) filePath(path ...string) string {
+	return filepath.Join(append([]string{client.root}, path...)...)
+}
+
+// Write the given binary into the given path, replacing it atomically
+func (client *localClient) UploadFileToS3(data []byte, path ...string) error {
+	target := client.filePath(path...)
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return errors.Wrap(err, "UploadFileToS3, create directory")
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(target), filepath.Base(target)+".tmp*")
+	if err != nil {
+		return errors.Wrap(err, "UploadFileToS3, create temp file")
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return errors.Wrap(err, "UploadFileToS3, write temp file")
+	}
+	if err := tmp.Close(); err != nil {
+		return errors.Wrap(err, "UploadFileToS3, close temp file")
+	}
+	if err := os.Rename(tmp.Name(), target); err != nil {
+		return errors.Wrap(err, "UploadFileToS3, rename temp file")
+	}
+	return nil
+}
+
+// Get the binary from the given path
+func (client *localClient) GetFileFromS3(path ...string) ([]byte, error) {
+	data, err := os.ReadFile(client.filePath(path...))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, errors.Errorf("GetFileFromS3, %s not found", strings.Join(path, "/"))
+	} else if err != nil {
+		return nil, errors.Wrap(err, "GetFileFromS3, read file")
+	}
+	return data, nil
+}
+
+func (client *localClient) GetBlockFilePath(blockNum int64, folderPath ...string) []string {
+	return blockFilePath(blockNum, folderPath...)
+}
+
+func (client *localClient) UploadBlockBinary(blockNum int64, data []byte, folderPath ...string) error {
+	return client.UploadFileToS3(data, client.GetBlockFilePath(blockNum, folderPath...)...)
+}
+
+// if return is 0, it means no block collected yet
+// if return is -1, there is an error
+func (client *localClient) GetLatestProcessedBlockNumber(folderPath ...string) (int64, error) {
+	resp, err := client.GetFileFromS3(append(folderPath, latestFilename)...)
+	if err != nil && strings.Contains(err.Error(), "not found") {
+		return 0, nil
+	} else if err != nil {
+		return -1, errors.Wrap(err, "GetLatestProcessedBlockNumber, GetFileFromS3")
+	}
+
+	blockNo, err := strconv.ParseInt(strings.TrimSpace(string(resp)), 10, 64)
+	if err != nil {
+		return -1, errors.Wrap(err, "GetLatestProcessedBlockNumber, ParseInt")
+	}
+	return blockNo, nil
+}
+
+func (client *localClient) ChangeLatestBlock(currBlockNum int64, folderPath ...string) error {
+	err := client.UploadFileToS3([]byte(strconv.FormatInt(currBlockNum, 10)), append(folderPath, latestFilename)...)
+	if err != nil {
+		err = errors.Wrap(err, "MarkLatestBlock, UploadFileToS3")
+	}
+	return err
+}
@@ -0,0 +1,32 @@
+package s3client
+
+import (
+	"testing"
+
+	"github.com/dezswap/cosmwasm-etl/configs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalClient_UploadAndGet(t *testing.T) {
+	client, err := NewClient(configs.S3Config{LocalDir: t.TempDir()})
+	require.NoError(t, err)
+
+	latest, err := client.GetLatestProcessedBlockNumber(chainId, "block")
+	require.NoError(t, err)
+	require.Equal(t, int64(0), latest)
+
+	require.NoError(t, client.UploadBlockBinary(10, []byte("first"), chainId, "block"))
+	require.NoError(t, client.UploadBlockBinary(10, []byte("second"), chainId, "block"))
+	require.NoError(t, client.ChangeLatestBlock(10, chainId, "block"))
+
+	data, err := client.GetFileFromS3(client.GetBlockFilePath(10, chainId, "block")...)
+	require.NoError(t, err)
+	require.Equal(t, []byte("second"), data)
+
+	latest, err = client.GetLatestProcessedBlockNumber(chainId, "block")
+	require.NoError(t, err)
+	require.Equal(t, int64(10), latest)
+
+	_, err = client.GetFileFromS3(client.GetBlockFilePath(11, chainId, "block")...)
+	require.ErrorContains(t, err, "not found")
+}
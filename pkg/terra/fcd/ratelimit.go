@@ -0,0 +1,43 @@
+package fcd
+
+import (
+	"sync"
+	"time"
+)
+
+type rateLimitedFcd struct {
+	Fcd
+	interval time.Duration
+
+	mu   sync.Mutex
+	next time.Time
+}
+
+// NewRateLimited spaces the requests made through client, from any number of
+// goroutines, to at most requestsPerSec per second. A zero rate returns
+// client unchanged.
+func NewRateLimited(client Fcd, requestsPerSec uint) Fcd {
+	if requestsPerSec == 0 {
+		return client
+	}
+	return &rateLimitedFcd{Fcd: client, interval: time.Second / time.Duration(requestsPerSec)}
+}
+
+// TxsOf implements Fcd.
+func (f *rateLimitedFcd) TxsOf(addr string, option FcdTxsReqQuery) (*FcdTxsRes, error) {
+	f.wait()
+	return f.Fcd.TxsOf(addr, option)
+}
+
+func (f *rateLimitedFcd) wait() {
+	f.mu.Lock()
+	now := time.Now()
+	if f.next.Before(now) {
+		f.next = now
+	}
+	delay := f.next.Sub(now)
+	f.next = f.next.Add(f.interval)
+	f.mu.Unlock()
+
+	time.Sleep(delay)
+}
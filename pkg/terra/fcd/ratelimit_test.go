@@ -0,0 +1,34 @@
+package fcd
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type fcdStub struct{}
+
+func (fcdStub) TxsOf(string, FcdTxsReqQuery) (*FcdTxsRes, error) {
+	return &FcdTxsRes{}, nil
+}
+
+func TestNewRateLimited(t *testing.T) {
+	require.Equal(t, fcdStub{}, NewRateLimited(fcdStub{}, 0))
+
+	client := NewRateLimited(fcdStub{}, 100)
+	start := time.Now()
+	wg := sync.WaitGroup{}
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := client.TxsOf("addr", FcdTxsReqQuery{})
+			require.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	require.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+}
@@ -13,11 +13,40 @@ type RpcRes[T any] struct {
 	Result  T      `json:"result"`
 }
 
+type rpcReq struct {
+	Jsonrpc string            `json:"jsonrpc"`
+	Id      int               `json:"id"`
+	Method  string            `json:"method"`
+	Params  map[string]string `json:"params"`
+}
+
+type rpcBatchRes[T any] struct {
+	Id     int          `json:"id"`
+	Result T            `json:"result"`
+	Error  *RpcErrorRes `json:"error"`
+}
+
+type RpcErrorRes struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    string `json:"data"`
+}
+
+type RpcAbciQueryRes struct {
+	Response struct {
+		Code  uint32 `json:"code"`
+		Log   string `json:"log"`
+		Value []byte `json:"value"`
+	} `json:"response"`
+}
+
 type RpcBlockRes struct {
-	Block struct {
+	BlockId RpcBlockIdRes `json:"block_id"`
+	Block   struct {
 		Header struct {
-			Height string    `json:"height"`
-			Time   time.Time `json:"time"`
+			Height      string        `json:"height"`
+			Time        time.Time     `json:"time"`
+			LastBlockId RpcBlockIdRes `json:"last_block_id"`
 		} `json:"header"`
 		Data struct {
 			Txs types.Txs `json:"txs"`
@@ -25,6 +54,10 @@ type RpcBlockRes struct {
 	} `json:"block"`
 }
 
+type RpcBlockIdRes struct {
+	Hash string `json:"hash"`
+}
+
 func (r *RpcBlockRes) TxsHashStrings() []string {
 	hashes := make([]string, len(r.Block.Data.Txs))
 	for i, tx := range r.Block.Data.Txs {
@@ -1,10 +1,13 @@
 package rpc
 
 import (
+	"bytes"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/pkg/errors"
@@ -14,6 +17,7 @@ const (
 	rpcBlockPath        = "block"
 	rpcBlockResultsPath = "block_results"
 	rpcStatusPath       = "status"
+	rpcAbciQueryMethod  = "abci_query"
 
 	defaultRpcTimeout = 30 * time.Second
 )
@@ -22,6 +26,9 @@ type Rpc interface {
 	Status() (*RpcRes[RpcStatusRes], error)
 	Block(height ...uint64) (*RpcRes[RpcBlockRes], error)
 	BlockResults(height ...uint64) (*RpcRes[RpcBlockResultRes], error)
+	// AbciQueries sends one abci_query of path per data as a single JSON-RPC
+	// batch and returns the results in the order of data.
+	AbciQueries(path string, data [][]byte, height uint64) ([]RpcAbciQueryRes, error)
 }
 
 type rpcImpl struct {
@@ -107,3 +114,59 @@ func (r *rpcImpl) Status() (*RpcRes[RpcStatusRes], error) {
 
 	return &res, nil
 }
+
+// AbciQueries implements Rpc.
+func (r *rpcImpl) AbciQueries(path string, data [][]byte, height uint64) ([]RpcAbciQueryRes, error) {
+	reqs := make([]rpcReq, len(data))
+	for idx, d := range data {
+		reqs[idx] = rpcReq{
+			Jsonrpc: "2.0",
+			Id:      idx,
+			Method:  rpcAbciQueryMethod,
+			Params: map[string]string{
+				"path":   path,
+				"data":   hex.EncodeToString(d),
+				"height": strconv.FormatUint(height, 10),
+			},
+		}
+	}
+	body, err := json.Marshal(reqs)
+	if err != nil {
+		return nil, errors.Wrap(err, "rpcImpl.AbciQueries")
+	}
+	response, err := r.client.Post(r.baseUrl, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return nil, errors.Wrap(err, "rpcImpl.AbciQueries")
+	}
+	defer response.Body.Close()
+
+	resData, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "rpcImpl.AbciQueries")
+	}
+
+	var batch []rpcBatchRes[RpcAbciQueryRes]
+	if err := json.Unmarshal(resData, &batch); err != nil {
+		return nil, errors.Wrap(err, "rpcImpl.AbciQueries")
+	}
+
+	// a batch response may list its results in any order
+	results := make([]RpcAbciQueryRes, len(data))
+	answered := make([]bool, len(data))
+	for _, res := range batch {
+		if res.Id < 0 || res.Id >= len(data) {
+			return nil, errors.Errorf("rpcImpl.AbciQueries: unexpected response id %d", res.Id)
+		}
+		if res.Error != nil {
+			return nil, errors.Errorf("rpcImpl.AbciQueries: query %d: %s %s", res.Id, res.Error.Message, res.Error.Data)
+		}
+		results[res.Id] = res.Result
+		answered[res.Id] = true
+	}
+	for idx, ok := range answered {
+		if !ok {
+			return nil, errors.Errorf("rpcImpl.AbciQueries: no response to query %d", idx)
+		}
+	}
+	return results, nil
+}
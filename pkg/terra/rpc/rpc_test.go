@@ -0,0 +1,46 @@
+package rpc
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAbciQueriesSendsOneBatchAndOrdersResults(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		reqs := []rpcReq{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&reqs))
+		require.Len(t, reqs, 2)
+		require.Equal(t, "abci_query", reqs[0].Method)
+		require.Equal(t, map[string]string{"path": "/path", "data": "0a", "height": "7"}, reqs[0].Params)
+		// answered out of order
+		_, _ = w.Write([]byte(`[
+			{"jsonrpc":"2.0","id":1,"result":{"response":{"code":0,"value":"Ag=="}}},
+			{"jsonrpc":"2.0","id":0,"result":{"response":{"code":0,"value":"AQ=="}}}
+		]`))
+	}))
+	defer server.Close()
+
+	results, err := New(server.URL, &http.Client{}).AbciQueries("/path", [][]byte{{0x0a}, {0x0b}}, 7)
+
+	require.NoError(t, err)
+	require.Equal(t, 1, requests)
+	require.Equal(t, []byte{1}, results[0].Response.Value)
+	require.Equal(t, []byte{2}, results[1].Response.Value)
+}
+
+func TestAbciQueriesFailsOnMissingResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[{"jsonrpc":"2.0","id":0,"result":{"response":{"code":0}}}]`))
+	}))
+	defer server.Close()
+
+	_, err := New(server.URL, &http.Client{}).AbciQueries("/path", [][]byte{{0x0a}, {0x0b}}, 7)
+
+	require.EqualError(t, err, "rpcImpl.AbciQueries: no response to query 1")
+}
@@ -0,0 +1,95 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+
+	"github.com/gorilla/websocket"
+	"github.com/pkg/errors"
+)
+
+const NewBlockQuery = "tm.event='NewBlock'"
+
+type rpcSubscribeReq struct {
+	Jsonrpc string `json:"jsonrpc"`
+	Method  string `json:"method"`
+	Id      int    `json:"id"`
+	Params  struct {
+		Query string `json:"query"`
+	} `json:"params"`
+}
+
+type RpcNewBlockEventRes struct {
+	Query string `json:"query"`
+	Data  struct {
+		Type  string `json:"type"`
+		Value struct {
+			Block struct {
+				Header struct {
+					Height string `json:"height"`
+				} `json:"header"`
+			} `json:"block"`
+		} `json:"value"`
+	} `json:"data"`
+}
+
+type rpcEventMsg struct {
+	RpcRes[RpcNewBlockEventRes]
+	Error *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+		Data    string `json:"data"`
+	} `json:"error,omitempty"`
+}
+
+// SubscribeNewBlocks subscribes to NewBlock events on the CometBFT websocket
+// endpoint (e.g. wss://rpc.example.com/websocket) and calls onHeight for every
+// block. It blocks until ctx is done or the connection drops.
+func SubscribeNewBlocks(ctx context.Context, wsUrl string, onHeight func(height uint64)) error {
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, wsUrl, nil)
+	if err != nil {
+		return errors.Wrap(err, "rpc.SubscribeNewBlocks")
+	}
+	defer conn.Close()
+
+	go func() {
+		<-ctx.Done()
+		_ = conn.Close()
+	}()
+
+	req := rpcSubscribeReq{Jsonrpc: "2.0", Method: "subscribe", Id: 1}
+	req.Params.Query = NewBlockQuery
+	if err := conn.WriteJSON(req); err != nil {
+		return errors.Wrap(err, "rpc.SubscribeNewBlocks")
+	}
+
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return errors.Wrap(err, "rpc.SubscribeNewBlocks")
+		}
+
+		var msg rpcEventMsg
+		if err := json.Unmarshal(data, &msg); err != nil {
+			return errors.Wrap(err, "rpc.SubscribeNewBlocks")
+		}
+		if msg.Error != nil {
+			return errors.Errorf("rpc.SubscribeNewBlocks: %s (%s)", msg.Error.Message, msg.Error.Data)
+		}
+
+		// the subscribe acknowledgement carries an empty result
+		rawHeight := msg.Result.Data.Value.Block.Header.Height
+		if rawHeight == "" {
+			continue
+		}
+		height, err := strconv.ParseUint(rawHeight, 10, 64)
+		if err != nil {
+			return errors.Wrap(err, "rpc.SubscribeNewBlocks")
+		}
+		onHeight(height)
+	}
+}